@@ -1,5 +1,39 @@
 package linepay
 
+import "context"
+
 const (
 	ApiReturnCodeSuccess string = "0000"
+
+	ApiReturnCodeHeaderError string = "1106" // channel id or signature rejected
+
+	ApiReturnCodeTransactionNotFound string = "1150"
+
+	ApiReturnCodeOrderIDExists string = "1172" // a payment of the orderId exists
+
+	ApiReturnCodeRegKeyNotFound       string = "1190"
+	ApiReturnCodeRegKeyExpired        string = "1193"
+	ApiReturnCodePreapprovedForbidden string = "1194"
 )
+
+// PaymentsAPI is implemented by `*Client`, depend on it to replace the client in tests (see package `linepaymock`)
+type PaymentsAPI interface {
+	PaymentsRequest(ctx context.Context, request *PaymentsRequest) (*PaymentsResponse, error)
+	PaymentsRequestV2(ctx context.Context, request *PaymentsRequestV2) (*PaymentsResponse, error)
+	PaymentsConfirm(ctx context.Context, transactionId TransactionID, request *PaymentsConfirmRequest) (*PaymentsConfirmResponse, error)
+	PaymentsCapture(ctx context.Context, transactionId TransactionID, request *PaymentsCaptureRequest) (*PaymentsCaptureResponse, error)
+	PaymentsVoid(ctx context.Context, transactionId TransactionID) (*PaymentsVoidResponse, error)
+	PaymentsRefund(ctx context.Context, transactionId TransactionID, request *PaymentsRefundRequest) (*PaymentsRefundResponse, error)
+	PaymentsDetails(ctx context.Context, request *PaymentsDetailsRequest) (*PaymentsDetailsResponse, error)
+	PaymentsStatus(ctx context.Context, transactionId TransactionID) (*PaymentsStatusResponse, error)
+
+	PaymentsPreapproved(ctx context.Context, regKey string, request *PaymentsPreapprovedRequest) (*PaymentsPreapprovedResponse, error)
+	PaymentsCheckRegKey(ctx context.Context, regKey string, request *PaymentsCheckRegKeyRequest) (*PaymentsCheckRegKeyResponse, error)
+	PaymentsExpireRegKey(ctx context.Context, regKey string) (*PaymentsExpireRegKeyResponse, error)
+
+	PaymentsPreapprovedSealed(ctx context.Context, regKey Sealed, request *PaymentsPreapprovedRequest) (*PaymentsPreapprovedResponse, error)
+	PaymentsCheckRegKeySealed(ctx context.Context, regKey Sealed, request *PaymentsCheckRegKeyRequest) (*PaymentsCheckRegKeyResponse, error)
+	PaymentsExpireRegKeySealed(ctx context.Context, regKey Sealed) (*PaymentsExpireRegKeyResponse, error)
+}
+
+var _ PaymentsAPI = (*Client)(nil)
@@ -0,0 +1,289 @@
+package linepay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ErrCircuitOpen is matched by errors.Is for the *CircuitOpenError of a call refused by an open circuit
+var ErrCircuitOpen = errors.New("linepay: circuit open")
+
+// CircuitOpenError is returned without calling LINE Pay while the circuit of `Operation` is open, until `RetryAt`
+type CircuitOpenError struct {
+	Operation string
+	RetryAt   time.Time
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("linepay: circuit of %s open until %s", e.Operation, e.RetryAt.Format(time.RFC3339))
+}
+
+func (e *CircuitOpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+// CircuitState of the breaker of an operation
+type CircuitState int
+
+const (
+	CircuitClosed   CircuitState = iota // calls are sent
+	CircuitOpen                         // calls fail with a *CircuitOpenError
+	CircuitHalfOpen                     // probe calls are sent, the others fail
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	}
+	return "closed"
+}
+
+// BreakerPolicy of the circuit breaker of every operation, the zero value disables it.
+// `FailureRatio` opens the circuit when this ratio of the calls of `Window` failed on the LINE Pay side, after
+// `MinCalls` calls (10 by default). `Window` 1 minute by default.
+// `OpenTimeout` how long calls fail fast before probing, 30 seconds by default
+// `Probes` calls sent when half-open (1 by default), the circuit closes when they all succeed and opens again on a failure
+//
+// Network errors and timeouts, the caller's deadline included, HTTP 5xx and 429 responses and the returnCodes 9000
+// and 1199 (LINE Pay internal errors) are failures. Other returnCodes, a 4xx response and a call canceled by its
+// caller are not.
+type BreakerPolicy struct {
+	FailureRatio float64
+	MinCalls     int
+	Window       time.Duration
+	OpenTimeout  time.Duration
+	Probes       int
+}
+
+// breakers holds the breaker of every operation, created at the first call
+type breakers struct {
+	policy BreakerPolicy
+
+	mu         sync.Mutex
+	operations map[string]*breaker
+}
+
+func newBreakers(policy BreakerPolicy) *breakers {
+	if policy.FailureRatio <= 0 {
+		return nil
+	}
+	if policy.MinCalls <= 0 {
+		policy.MinCalls = 10
+	}
+	if policy.Window <= 0 {
+		policy.Window = time.Minute
+	}
+	if policy.OpenTimeout <= 0 {
+		policy.OpenTimeout = 30 * time.Second
+	}
+	if policy.Probes <= 0 {
+		policy.Probes = 1
+	}
+	return &breakers{policy: policy, operations: map[string]*breaker{}}
+}
+
+// of returns the breaker of `operation`, nil when breakers are disabled
+func (b *breakers) of(operation string) *breaker {
+	if b == nil {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	br, ok := b.operations[operation]
+	if !ok {
+		br = &breaker{operation: operation, policy: &b.policy}
+		b.operations[operation] = br
+	}
+	return br
+}
+
+func (b *breakers) states() map[string]CircuitState {
+	states := map[string]CircuitState{}
+	if b == nil {
+		return states
+	}
+
+	b.mu.Lock()
+	operations := make([]*breaker, 0, len(b.operations))
+	for _, br := range b.operations {
+		operations = append(operations, br)
+	}
+	b.mu.Unlock()
+
+	now := time.Now()
+	for _, br := range operations {
+		states[br.operation] = br.state(now)
+	}
+	return states
+}
+
+// CircuitStates returns the circuit state of every operation called so far, for health checks.
+// It is empty when `ClientOpts.Breaker` is not set.
+func (client *Client) CircuitStates() map[string]CircuitState {
+	return client.breakers.states()
+}
+
+// CircuitState returns the circuit state of `operation`, CircuitClosed when it was not called or breakers are disabled
+func (client *Client) CircuitState(operation string) CircuitState {
+	if client.breakers == nil {
+		return CircuitClosed
+	}
+	return client.breakers.of(operation).state(time.Now())
+}
+
+// OpenCircuits returns the operations whose circuit is not closed, sorted
+func (client *Client) OpenCircuits() (operations []string) {
+	for operation, state := range client.CircuitStates() {
+		if state != CircuitClosed {
+			operations = append(operations, operation)
+		}
+	}
+	sort.Strings(operations)
+	return
+}
+
+// breaker of an operation, a nil breaker lets every call through
+type breaker struct {
+	operation string
+	policy    *BreakerPolicy
+
+	mu          sync.Mutex
+	circuit     CircuitState
+	windowStart time.Time
+	calls       int
+	failures    int
+	openedAt    time.Time
+	probes      int
+	succeeded   int
+}
+
+// state is the current state, an open circuit becomes half-open after the open timeout
+func (br *breaker) state(now time.Time) CircuitState {
+	br.mu.Lock()
+	defer br.mu.Unlock()
+	return br.current(now)
+}
+
+func (br *breaker) current(now time.Time) CircuitState {
+	if br.circuit == CircuitOpen && !now.Before(br.openedAt.Add(br.policy.OpenTimeout)) {
+		br.set(CircuitHalfOpen, now)
+	}
+	return br.circuit
+}
+
+func (br *breaker) set(state CircuitState, now time.Time) {
+	if state == br.circuit {
+		return
+	}
+	logrus.Warnf("%s circuit %s -> %s", br.operation, br.circuit, state)
+
+	br.circuit = state
+	br.probes, br.succeeded = 0, 0
+	switch state {
+	case CircuitOpen:
+		br.openedAt = now
+	case CircuitClosed:
+		br.windowStart, br.calls, br.failures = now, 0, 0
+	}
+}
+
+// allow admits a call, or returns a *CircuitOpenError
+func (br *breaker) allow() error {
+	if br == nil {
+		return nil
+	}
+
+	br.mu.Lock()
+	defer br.mu.Unlock()
+
+	now := time.Now()
+	switch br.current(now) {
+	case CircuitOpen:
+		return &CircuitOpenError{Operation: br.operation, RetryAt: br.openedAt.Add(br.policy.OpenTimeout)}
+	case CircuitHalfOpen:
+		if br.probes >= br.policy.Probes {
+			return &CircuitOpenError{Operation: br.operation, RetryAt: now.Add(br.policy.OpenTimeout)}
+		}
+		br.probes++
+	}
+	return nil
+}
+
+// record the outcome of an admitted call. `ctx` is the caller's context: a call it canceled is not counted, but gives
+// its probe back. A call past its deadline is a failure, LINE Pay hanging until the callers give up is an outage.
+func (br *breaker) record(ctx context.Context, res *http.Response, body []byte, err error) {
+	if br == nil {
+		return
+	}
+
+	br.mu.Lock()
+	defer br.mu.Unlock()
+
+	now := time.Now()
+	state := br.current(now)
+
+	if errors.Is(ctx.Err(), context.Canceled) {
+		if state == CircuitHalfOpen && br.probes > 0 {
+			br.probes--
+		}
+		return
+	}
+	failed := lineFailure(res, body, err)
+
+	switch state {
+	case CircuitHalfOpen:
+		if failed {
+			br.set(CircuitOpen, now)
+			return
+		}
+		br.succeeded++
+		if br.succeeded >= br.policy.Probes {
+			br.set(CircuitClosed, now)
+		}
+
+	case CircuitClosed:
+		if now.Sub(br.windowStart) >= br.policy.Window {
+			br.windowStart, br.calls, br.failures = now, 0, 0
+		}
+		br.calls++
+		if failed {
+			br.failures++
+		}
+		if br.calls >= br.policy.MinCalls && float64(br.failures) >= br.policy.FailureRatio*float64(br.calls) {
+			br.set(CircuitOpen, now)
+		}
+	}
+}
+
+// lineFailure tells whether a call failed because of LINE Pay (or the way to it), and not because of its request
+func lineFailure(res *http.Response, body []byte, err error) bool {
+	if res == nil {
+		return err != nil
+	}
+	if res.StatusCode >= 500 || res.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	if res.StatusCode != http.StatusOK {
+		return false
+	}
+	if err != nil {
+		return true // body not read
+	}
+	switch returnCodeOf(body) {
+	case "9000", "1199":
+		return true
+	}
+	return false
+}
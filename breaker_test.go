@@ -0,0 +1,178 @@
+package linepay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClient_Breaker(t *testing.T) {
+
+	var calls int32
+	var answer atomic.Value
+	answer.Store("ok")
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		switch answer.Load() {
+		case "down":
+			w.WriteHeader(http.StatusInternalServerError)
+		case "invalid":
+			fmt.Fprint(w, `{"returnCode":"1124","returnMessage":"Amount info error."}`)
+		default:
+			fmt.Fprint(w, `{"returnCode":"0000","returnMessage":"Success."}`)
+		}
+	}))
+	defer ts.Close()
+
+	client, _ := NewClient("1001", "secret", nil, &ClientOpts{
+		APIEndpoint: ts.URL,
+		Breaker:     BreakerPolicy{FailureRatio: 0.5, MinCalls: 4, OpenTimeout: 50 * time.Millisecond},
+	})
+	ctx := context.Background()
+	details := func(ctx context.Context) error {
+		_, err := client.PaymentsDetails(ctx, &PaymentsDetailsRequest{OrderIDs: []string{"o1"}})
+		return err
+	}
+
+	// rejected requests and calls ended by the caller are not LINE Pay failures
+	answer.Store("invalid")
+	for i := 0; i < 4; i++ {
+		details(ctx)
+	}
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	for i := 0; i < 4; i++ {
+		details(canceled)
+	}
+	if state := client.CircuitState(OperationPaymentsDetails); state != CircuitClosed {
+		t.Fatalf("circuit after rejected calls = %v, want closed", state)
+	}
+
+	// 4 failures of 8 calls open the circuit
+	answer.Store("down")
+	for i := 0; i < 4; i++ {
+		details(ctx)
+	}
+	if state := client.CircuitState(OperationPaymentsDetails); state != CircuitOpen {
+		t.Fatalf("circuit after failures = %v, want open", state)
+	}
+
+	before := atomic.LoadInt32(&calls)
+	err := details(ctx)
+	var open *CircuitOpenError
+	if !errors.Is(err, ErrCircuitOpen) || !errors.As(err, &open) || open.Operation != OperationPaymentsDetails || atomic.LoadInt32(&calls) != before {
+		t.Errorf("PaymentsDetails() on an open circuit error = %v", err)
+	}
+	if ops := client.OpenCircuits(); len(ops) != 1 || ops[0] != OperationPaymentsDetails {
+		t.Errorf("OpenCircuits() = %v", ops)
+	}
+
+	// other operations are not affected
+	if _, err := client.PaymentsStatus(ctx, 1); err == nil || errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("PaymentsStatus() error = %v, want the LINE Pay failure", err)
+	}
+
+	// a failed probe opens the circuit again
+	time.Sleep(60 * time.Millisecond)
+	if state := client.CircuitState(OperationPaymentsDetails); state != CircuitHalfOpen {
+		t.Errorf("circuit after the open timeout = %v, want half-open", state)
+	}
+	if err := details(ctx); err == nil || errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("probe error = %v, want the LINE Pay failure", err)
+	}
+	if err := details(ctx); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("PaymentsDetails() after a failed probe error = %v", err)
+	}
+
+	// a successful probe closes it
+	answer.Store("ok")
+	time.Sleep(60 * time.Millisecond)
+	if err := details(ctx); err != nil {
+		t.Errorf("probe error = %v", err)
+	}
+	if states := client.CircuitStates(); states[OperationPaymentsDetails] != CircuitClosed {
+		t.Errorf("CircuitStates() = %v, want details closed", states)
+	}
+}
+
+func TestClient_BreakerDeadline(t *testing.T) {
+
+	// LINE Pay hangs until the callers give up
+	var calls int32
+	hang := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		select {
+		case <-r.Context().Done():
+		case <-hang:
+		}
+	}))
+	defer ts.Close()
+	defer close(hang)
+
+	client, _ := NewClient("1001", "secret", nil, &ClientOpts{
+		APIEndpoint: ts.URL,
+		Breaker:     BreakerPolicy{FailureRatio: 0.5, MinCalls: 4, OpenTimeout: 50 * time.Millisecond},
+	})
+	details := func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		_, err := client.PaymentsDetails(ctx, &PaymentsDetailsRequest{OrderIDs: []string{"o1"}})
+		return err
+	}
+
+	for i := 0; i < 4; i++ {
+		if err := details(); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("PaymentsDetails() error = %v, want the timeout", err)
+		}
+	}
+	if state := client.CircuitState(OperationPaymentsDetails); state != CircuitOpen {
+		t.Fatalf("circuit after timeouts = %v, want open", state)
+	}
+	before := atomic.LoadInt32(&calls)
+	if err := details(); !errors.Is(err, ErrCircuitOpen) || atomic.LoadInt32(&calls) != before {
+		t.Errorf("PaymentsDetails() on an open circuit error = %v", err)
+	}
+
+	// a probe past its deadline opens the circuit again
+	time.Sleep(60 * time.Millisecond)
+	if err := details(); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("probe error = %v, want the timeout", err)
+	}
+	if state := client.CircuitState(OperationPaymentsDetails); state != CircuitOpen {
+		t.Errorf("circuit after a timed out probe = %v, want open", state)
+	}
+}
+
+func TestLineFailure(t *testing.T) {
+
+	tests := []struct {
+		status int
+		body   string
+		err    error
+		want   bool
+	}{
+		{0, "", errors.New("connection refused"), true},
+		{http.StatusServiceUnavailable, "", errors.New("failed response"), true},
+		{http.StatusTooManyRequests, "", errors.New("failed response"), true},
+		{http.StatusBadRequest, "", errors.New("failed response"), false},
+		{http.StatusOK, `{"returnCode":"9000"}`, nil, true},
+		{http.StatusOK, `{"returnCode":"1199"}`, nil, true},
+		{http.StatusOK, `{"returnCode":"1150"}`, nil, false},
+		{http.StatusOK, `{"returnCode":"0000"}`, nil, false},
+	}
+	for _, tt := range tests {
+		var res *http.Response
+		if tt.status != 0 {
+			res = &http.Response{StatusCode: tt.status}
+		}
+		if got := lineFailure(res, []byte(tt.body), tt.err); got != tt.want {
+			t.Errorf("lineFailure(%d, %s, %v) = %v, want %v", tt.status, tt.body, tt.err, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,340 @@
+package linepay
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// RetryClass tells which failures of an Endpoint may be retried by the RetryPolicy
+type RetryClass int
+
+const (
+	// RetryByMethod retries GET on network errors, 429 and 5xx, and POST only on 429 and 503
+	RetryByMethod RetryClass = iota
+	// RetryIdempotent retries like GET whatever the method, for POST endpoints safe to send twice
+	RetryIdempotent
+	// RetryNever never retries
+	RetryNever
+)
+
+// Endpoint describes a LINE Pay API for Client.Call.
+// `Operation` the name given to the Observers, e.g. "PaymentsAuthorizations"
+// `Method` http.MethodGet or http.MethodPost
+// `Path` the v3 path, `{name}` placeholders are replaced in order by the path params of Call. A v2 client calls `/v2`.
+// `Retry` optional, RetryByMethod by default
+// `Timeout` optional, limits the call retries included
+// `Redact` optional, JSON fields hidden in the debug logs of the bodies in addition to regKey and paymentAccessToken
+type Endpoint struct {
+	Operation string
+	Method    string
+	Path      string
+	Retry     RetryClass
+	Timeout   time.Duration
+	Redact    []string
+}
+
+var (
+	// POST /v3/payments/request
+	endpointPaymentsRequest = &Endpoint{Operation: OperationPaymentsRequest, Method: http.MethodPost, Path: "/v3/payments/request"}
+
+	// POST /v3/payments/{transactionId}/confirm
+	endpointPaymentsConfirm = &Endpoint{Operation: OperationPaymentsConfirm, Method: http.MethodPost, Path: "/v3/payments/{transactionId}/confirm"}
+
+	// GET /v3/payments
+	endpointPaymentsDetails = &Endpoint{Operation: OperationPaymentsDetails, Method: http.MethodGet, Path: "/v3/payments", Redact: []string{"shipping"}}
+
+	// POST /v3/payments/authorizations/{transactionId}/capture
+	endpointPaymentsCapture = &Endpoint{Operation: OperationPaymentsCapture, Method: http.MethodPost, Path: "/v3/payments/authorizations/{transactionId}/capture"}
+
+	// POST /v3/payments/authorizations/{transactionId}/void
+	endpointPaymentsVoid = &Endpoint{Operation: OperationPaymentsVoid, Method: http.MethodPost, Path: "/v3/payments/authorizations/{transactionId}/void"}
+
+	// POST /v3/payments/{transactionId}/refund
+	endpointPaymentsRefund = &Endpoint{Operation: OperationPaymentsRefund, Method: http.MethodPost, Path: "/v3/payments/{transactionId}/refund"}
+
+	// GET /v3/payments/requests/{transactionId}/check
+	endpointPaymentsStatus = &Endpoint{Operation: OperationPaymentsStatus, Method: http.MethodGet, Path: "/v3/payments/requests/{transactionId}/check"}
+
+	// POST /v3/payments/preapprovedPay/{regKey}/payment
+	endpointPaymentsPreapproved = &Endpoint{Operation: OperationPaymentsPreapproved, Method: http.MethodPost, Path: "/v3/payments/preapprovedPay/{regKey}/payment"}
+
+	// GET /v3/payments/preapprovedPay/{regKey}/check
+	endpointPaymentsCheckRegKey = &Endpoint{Operation: OperationPaymentsCheckRegKey, Method: http.MethodGet, Path: "/v3/payments/preapprovedPay/{regKey}/check"}
+
+	// POST /v3/payments/preapprovedPay/{regKey}/expire
+	endpointPaymentsExpireRegKey = &Endpoint{Operation: OperationPaymentsExpireRegKey, Method: http.MethodPost, Path: "/v3/payments/preapprovedPay/{regKey}/expire"}
+)
+
+var (
+	pathParamPattern = regexp.MustCompile(`\{[^}]*\}`)
+
+	defaultRedact = []string{"regKey", "paymentAccessToken"}
+)
+
+// queryRequest is implemented by the requests of GET endpoints
+type queryRequest interface {
+	query() url.Values
+}
+
+// moneyRequest is implemented by the requests moving money, for the Observers
+type moneyRequest interface {
+	money() (amount int, currency string)
+}
+
+// Call sends `request` to an endpoint the SDK does not wrap, with the signing, retries, secret rotation and
+// Observers of the wrapped methods. `request` is marshaled as the JSON body of a POST, or is the url.Values of a GET,
+// it may be nil. The body of a 200 response is unmarshaled into `response` when not nil, and its `Meta` field is set
+// when it has one.
+//
+//	var res struct {
+//		ReturnCode string          `json:"returnCode"`
+//		Info       json.RawMessage `json:"info"`
+//	}
+//	meta, err := client.Call(ctx, &linepay.Endpoint{Operation: "PaymentsAuthorizations", Method: http.MethodGet, Path: "/v3/payments/authorizations"},
+//		url.Values{"orderId": {"order-1"}}, &res)
+func (client *Client) Call(ctx context.Context, endpoint *Endpoint, request interface{}, response interface{}, pathParams ...interface{}) (*ResponseMeta, error) {
+	if endpoint == nil {
+		return nil, errors.New("Call: nil endpoint")
+	}
+	return client.execute(ctx, endpoint, request, response, pathParams)
+}
+
+// do calls `endpoint` and decodes its response, `request` may be nil for endpoints without body or query
+func do[Req any, Resp any](ctx context.Context, client *Client, endpoint *Endpoint, request *Req, pathParams ...interface{}) (response *Resp, err error) {
+
+	var body interface{}
+	if request != nil {
+		body = request
+	}
+
+	response = new(Resp)
+	meta, err := client.execute(ctx, endpoint, body, response, pathParams)
+	if meta == nil {
+		response = nil
+	}
+	return
+}
+
+// execute is the plumbing of every call: encode, send, check the status, read and decode.
+// The returned meta is nil when no response body was read.
+func (client *Client) execute(ctx context.Context, endpoint *Endpoint, request interface{}, response interface{}, pathParams []interface{}) (meta *ResponseMeta, err error) {
+
+	name := endpoint.Operation + " " + strings.ToLower(endpoint.Method)
+
+	path, err := endpointPath(endpoint.Path, pathParams)
+	if err != nil {
+		err = fmt.Errorf("%s error = %w", name, err)
+		return
+	}
+
+	call := &CallInfo{Operation: endpoint.Operation, Method: endpoint.Method, Route: endpoint.Path, retry: endpoint.Retry}
+	if m, ok := request.(moneyRequest); ok {
+		call.Amount, call.Currency = m.money()
+	}
+
+	caller := ctx
+	if endpoint.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, endpoint.Timeout)
+		defer cancel()
+	}
+
+	var params url.Values
+	var body []byte
+	switch endpoint.Method {
+	case http.MethodGet:
+		if params, err = queryOf(request); err != nil {
+			err = fmt.Errorf("%s error = %w", name, err)
+			return
+		}
+
+	case http.MethodPost:
+		if request != nil {
+			if body, err = json.Marshal(request); err != nil {
+				err = fmt.Errorf("%s marshal request error = %w", name, err)
+				return
+			}
+		}
+
+	default:
+		err = fmt.Errorf("%s error = unsupported method", name)
+		return
+	}
+
+	// send the request, and read the body of a 200 response
+	breaker := client.breakers.of(endpoint.Operation)
+	send := func(ctx context.Context) (res *http.Response, bodyBytes []byte, err error) {
+
+		if err = breaker.allow(); err != nil {
+			return
+		}
+		defer func() { breaker.record(caller, res, bodyBytes, err) }()
+
+		if endpoint.Method == http.MethodGet {
+			logrus.Debugf("%s %s?%s", name, endpoint.Path, params.Encode())
+			res, err = client.get(ctx, call, path, &params)
+		} else {
+			logrus.Debugf("%s %s body: %s", name, endpoint.Path, redactJSON(body, endpoint.Redact))
+			res, err = client.post(ctx, call, path, body)
+		}
+		if err != nil {
+			err = fmt.Errorf("%s error = %w", name, err)
+			return
+		}
+		defer res.Body.Close()
+
+		if res.StatusCode != http.StatusOK {
+			err = &statusError{StatusCode: res.StatusCode}
+			return
+		}
+
+		bodyBytes, ioerr := ioutil.ReadAll(res.Body)
+		if ioerr != nil {
+			err = fmt.Errorf("ReadAll read body failed: %w", ioerr)
+			return
+		}
+		logrus.Debugf("%s response: %s", name, redactJSON(bodyBytes, endpoint.Redact))
+		return
+	}
+
+	start := time.Now()
+
+	var res *http.Response
+	var bodyBytes []byte
+	var replayed bool
+	if key := IdempotencyKey(ctx); key != "" && endpoint.Method == http.MethodPost {
+		res, bodyBytes, replayed, err = client.idempotent(ctx, key, fingerprint(endpoint.Operation, path, body), send)
+	} else {
+		res, bodyBytes, err = send(ctx)
+	}
+	if err != nil {
+		return
+	}
+
+	meta = newResponseMeta(res, bodyBytes, start)
+	meta.Replayed = replayed
+	if response == nil {
+		return
+	}
+	setMeta(response, meta)
+
+	if err = json.Unmarshal(bodyBytes, response); err != nil {
+		err = fmt.Errorf("%s unmarshal response error = %w", name, err)
+		return
+	}
+	meta.collectExtensions(response)
+
+	return
+}
+
+// statusError is the error of a response other than 200
+type statusError struct {
+	StatusCode int
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("failed response, StatusCode: %d", e.StatusCode)
+}
+
+// endpointPath replaces the `{name}` placeholders of `template` by the escaped `params`
+func endpointPath(template string, params []interface{}) (string, error) {
+
+	placeholders := pathParamPattern.FindAllStringIndex(template, -1)
+	if len(placeholders) != len(params) {
+		return "", fmt.Errorf("'%s' takes %d path params, got %d", template, len(placeholders), len(params))
+	}
+
+	var b strings.Builder
+	last := 0
+	for i, p := range placeholders {
+		v := fmt.Sprint(params[i])
+		if v == "" || v == "." || v == ".." || strings.ContainsAny(v, "/?#") {
+			return "", fmt.Errorf("invalid path param %s", template[p[0]:p[1]])
+		}
+		b.WriteString(template[last:p[0]])
+		b.WriteString(url.PathEscape(v))
+		last = p[1]
+	}
+	b.WriteString(template[last:])
+	return b.String(), nil
+}
+
+func queryOf(request interface{}) (url.Values, error) {
+	switch r := request.(type) {
+	case nil:
+		return url.Values{}, nil
+	case url.Values:
+		return r, nil
+	case *url.Values:
+		return *r, nil
+	case queryRequest:
+		return r.query(), nil
+	}
+	return nil, fmt.Errorf("GET request must be url.Values, not %T", request)
+}
+
+// setMeta sets the `Meta *ResponseMeta` field of the struct `response` points to
+func setMeta(response interface{}, meta *ResponseMeta) {
+	v := reflect.ValueOf(response)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return
+	}
+	f := v.Elem().FieldByName("Meta")
+	if f.IsValid() && f.CanSet() && f.Type() == reflect.TypeOf(meta) {
+		f.Set(reflect.ValueOf(meta))
+	}
+}
+
+// redactJSON hides the values of the `redact` and default fields of a JSON body, for the debug logs
+func redactJSON(body []byte, redact []string) string {
+
+	if len(body) == 0 {
+		return ""
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return "(not JSON)"
+	}
+
+	fields := map[string]bool{}
+	for _, f := range defaultRedact {
+		fields[f] = true
+	}
+	for _, f := range redact {
+		fields[f] = true
+	}
+
+	var walk func(v interface{}) interface{}
+	walk = func(v interface{}) interface{} {
+		switch v := v.(type) {
+		case map[string]interface{}:
+			for k, e := range v {
+				if fields[k] {
+					v[k] = "[REDACTED]"
+				} else {
+					v[k] = walk(e)
+				}
+			}
+		case []interface{}:
+			for i, e := range v {
+				v[i] = walk(e)
+			}
+		}
+		return v
+	}
+
+	b, _ := json.Marshal(walk(v))
+	return string(b)
+}
@@ -0,0 +1,97 @@
+package linepay
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClient_Call(t *testing.T) {
+
+	var calls int
+	var got *http.Request
+	var gotBody string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		b, _ := ioutil.ReadAll(r.Body)
+		got, gotBody = r, string(b)
+		if r.Method == http.MethodPost && calls < 2 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		fmt.Fprint(w, `{"returnCode":"0000","returnMessage":"Success.","info":{"status":"AUTHORIZATION"}}`)
+	}))
+	defer ts.Close()
+
+	client, _ := NewClient("1001", "secret", nil, &ClientOpts{
+		APIEndpoint: ts.URL,
+		Retry:       RetryPolicy{MaxRetries: 2, Backoff: time.Millisecond},
+	})
+	ctx := context.Background()
+
+	var res struct {
+		ReturnCode string          `json:"returnCode"`
+		Info       json.RawMessage `json:"info"`
+		Meta       *ResponseMeta   `json:"-"`
+	}
+	authorizations := &Endpoint{Operation: "PaymentsAuthorizations", Method: http.MethodGet, Path: "/v3/payments/authorizations/{transactionId}"}
+	meta, err := client.Call(ctx, authorizations, url.Values{"orderId": {"order-1"}}, &res, TransactionID(2019049910005496810))
+	if err != nil || res.ReturnCode != ApiReturnCodeSuccess || res.Meta != meta || meta.Extensions["returnMessage"] == nil {
+		t.Fatalf("Call(GET) = %+v, %+v, %v", res, meta, err)
+	}
+	if got.URL.Path != "/v3/payments/authorizations/2019049910005496810" || got.URL.RawQuery != "orderId=order-1" || got.Header.Get("X-LINE-Authorization") == "" {
+		t.Errorf("Call(GET) sent %s %v", got.URL, got.Header)
+	}
+
+	// a POST safe to send twice is retried like a GET
+	calls = 0
+	update := &Endpoint{Operation: "PaymentsUpdate", Method: http.MethodPost, Path: "/v3/payments/{transactionId}/update", Retry: RetryIdempotent}
+	if _, err = client.Call(ctx, update, map[string]string{"memo": "gift"}, nil, TransactionID(1)); err != nil || calls != 2 || gotBody != `{"memo":"gift"}` {
+		t.Errorf("Call(POST) = %v after %d calls, body %s", err, calls, gotBody)
+	}
+
+	calls = 0
+	update.Retry = RetryNever
+	if _, err = client.Call(ctx, update, nil, nil, TransactionID(1)); err == nil || calls != 1 {
+		t.Errorf("Call(RetryNever) = %v after %d calls", err, calls)
+	}
+
+	// path params can not change the endpoint
+	calls = 0
+	for _, regKey := range []string{"../../payments", "a?b=1", ""} {
+		if _, err = client.Call(ctx, endpointPaymentsCheckRegKey, nil, nil, regKey); err == nil {
+			t.Errorf("Call(regKey %q) no error", regKey)
+		}
+	}
+	if _, err = client.Call(ctx, authorizations, nil, nil); err == nil || calls != 0 {
+		t.Errorf("Call() without path param = %v after %d calls", err, calls)
+	}
+
+	if _, err = client.Call(ctx, update, func() {}, nil, 1); err == nil || calls != 0 {
+		t.Errorf("Call() unmarshalable request = %v after %d calls", err, calls)
+	}
+
+	// the decode error names the operation and keeps the JSON error
+	var wrong struct {
+		Info []string `json:"info"`
+	}
+	var typeErr *json.UnmarshalTypeError
+	if _, err = client.Call(ctx, authorizations, nil, &wrong, TransactionID(1)); !errors.As(err, &typeErr) || !strings.HasPrefix(err.Error(), "PaymentsAuthorizations get") {
+		t.Errorf("Call() of a wrong response type error = %v", err)
+	}
+}
+
+func TestRedactJSON(t *testing.T) {
+	body := `{"info":{"regKey":"RK9A7E1B2C3D4","payInfo":[{"method":"CREDIT_CARD","email":"a@b.c"}]}}`
+	if s := redactJSON([]byte(body), []string{"email"}); s != `{"info":{"payInfo":[{"email":"[REDACTED]","method":"CREDIT_CARD"}],"regKey":"[REDACTED]"}}` {
+		t.Errorf("redactJSON() = %s", s)
+	}
+}
@@ -0,0 +1,378 @@
+// Package cassette records the HTTP interactions of a `linepay.Client` to a file and replays them, so integration
+// tests recorded once against the sandbox run offline and deterministically.
+//
+//	recorder, err := cassette.New("testdata/cassettes/refund.json", cassette.ModeReplay, cassette.WithSecrets(channelSecret))
+//	client, err := linepay.NewClient(channelID, channelSecret, nil, &linepay.ClientOpts{Transport: recorder})
+//	...
+//	err = recorder.Save() // writes the cassette in ModeRecord
+//
+// Requests are matched on method, path and normalized body (POST) or query (GET). Headers are ignored, the
+// signature and nonce of `Signer.SignWithBody` change on every call.
+// Saved cassettes never contain `X-LINE-Authorization`, the nonce, the `WithSecrets` values nor the PII fields.
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Mode of a Recorder
+type Mode int
+
+const (
+	// ModeReplay answers from the cassette, a request without a recorded interaction fails with ErrNoInteraction
+	ModeReplay Mode = iota
+	// ModeRecord sends the requests and keeps the interactions, Save writes them
+	ModeRecord
+	// ModePassthrough sends the requests and records nothing
+	ModePassthrough
+)
+
+// ModeEnv is the environment variable read by ModeFromEnv
+const ModeEnv = "LINEPAY_CASSETTE_MODE"
+
+// Redacted replaces the scrubbed values, it is not the "[REDACTED]" of a linepay.SecretString which is refused when
+// decoded, so the replayed regKeys decode
+const Redacted = "[SCRUBBED]"
+
+// DefaultPIIFields are the JSON fields of LINE Pay requests and responses holding personal data or payment
+// credentials, every string under them is redacted
+var DefaultPIIFields = []string{
+	"regKey",
+	"maskedCreditCardNumber",
+	"creditCardNickname",
+	"shippingAddress",
+	"recipient",
+	"email",
+	"phoneNo",
+}
+
+// headers never saved
+var scrubbedHeaders = []string{
+	"X-LINE-Authorization",
+	"X-LINE-Authorization-Nonce",
+	"X-LINE-ChannelSecret",
+	"Authorization",
+	"Cookie",
+	"Set-Cookie",
+}
+
+var (
+	ErrNoInteraction = errors.New("cassette: no recorded interaction")
+)
+
+func (m Mode) String() string {
+	switch m {
+	case ModeRecord:
+		return "record"
+	case ModePassthrough:
+		return "passthrough"
+	}
+	return "replay"
+}
+
+// ParseMode parses `record`, `replay` or `passthrough`, the empty string is ModeReplay
+func ParseMode(s string) (Mode, error) {
+	switch strings.ToLower(s) {
+	case "", "replay":
+		return ModeReplay, nil
+	case "record":
+		return ModeRecord, nil
+	case "passthrough":
+		return ModePassthrough, nil
+	}
+	return ModeReplay, fmt.Errorf("cassette: unknown mode '%s'", s)
+}
+
+// ModeFromEnv reads the mode from LINEPAY_CASSETTE_MODE, ModeReplay by default
+func ModeFromEnv() (Mode, error) {
+	return ParseMode(os.Getenv(ModeEnv))
+}
+
+// Cassette is the file format
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request as saved, `Body` is the normalized JSON body and `Query` the sorted query string
+type Request struct {
+	Method string      `json:"method"`
+	Path   string      `json:"path"`
+	Query  string      `json:"query,omitempty"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+type Response struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body"`
+}
+
+// Option of New
+type Option func(*Recorder)
+
+// WithTransport sends the requests of ModeRecord and ModePassthrough through `rt`, http.DefaultTransport by default
+func WithTransport(rt http.RoundTripper) Option {
+	return func(r *Recorder) { r.transport = rt }
+}
+
+// WithSecrets scrubs `values` (channel secret, regKeys...) anywhere in the saved requests and responses, paths included
+func WithSecrets(values ...string) Option {
+	return func(r *Recorder) {
+		for _, v := range values {
+			if v != "" {
+				r.secrets = append(r.secrets, v)
+			}
+		}
+	}
+}
+
+// WithPIIFields replaces DefaultPIIFields
+func WithPIIFields(fields ...string) Option {
+	return func(r *Recorder) {
+		r.piiFields = map[string]bool{}
+		for _, f := range fields {
+			r.piiFields[f] = true
+		}
+	}
+}
+
+// Recorder is an `http.RoundTripper` recording or replaying a cassette, safe for concurrent use
+type Recorder struct {
+	path      string
+	mode      Mode
+	transport http.RoundTripper
+	secrets   []string
+	piiFields map[string]bool
+
+	mu       sync.Mutex
+	cassette *Cassette
+	used     map[*Interaction]bool
+}
+
+// New opens the cassette at `path`, it must exist in ModeReplay
+func New(path string, mode Mode, opts ...Option) (*Recorder, error) {
+
+	r := &Recorder{
+		path:      path,
+		mode:      mode,
+		transport: http.DefaultTransport,
+		cassette:  &Cassette{},
+		used:      map[*Interaction]bool{},
+	}
+	WithPIIFields(DefaultPIIFields...)(r)
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	if mode != ModeReplay {
+		return r, nil
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cassette: %s", err.Error())
+	}
+	if err := json.Unmarshal(data, r.cassette); err != nil {
+		return nil, fmt.Errorf("cassette: unmarshal %s failed: %s", path, err.Error())
+	}
+	return r, nil
+}
+
+// Mode of the recorder
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	switch r.mode {
+	case ModeReplay:
+		return r.replay(req, r.request(req, body))
+	case ModePassthrough:
+		return r.transport.RoundTrip(req)
+	}
+
+	res, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resBody, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = ioutil.NopCloser(bytes.NewReader(resBody))
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, &Interaction{
+		Request: r.request(req, body),
+		Response: Response{
+			StatusCode: res.StatusCode,
+			Header:     r.header(res.Header),
+			Body:       r.scrubBody(resBody),
+		},
+	})
+	r.mu.Unlock()
+
+	return res, nil
+}
+
+// replay answers with the first unused interaction matching `saved`, so repeated calls (e.g. polling the status)
+// replay the recorded sequence
+func (r *Recorder) replay(req *http.Request, saved Request) (*http.Response, error) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, i := range r.cassette.Interactions {
+		if r.used[i] || !i.Request.matches(saved) {
+			continue
+		}
+		r.used[i] = true
+
+		header := http.Header{}
+		for k, v := range i.Response.Header {
+			header[k] = v
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", i.Response.StatusCode, http.StatusText(i.Response.StatusCode)),
+			StatusCode:    i.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          ioutil.NopCloser(strings.NewReader(i.Response.Body)),
+			ContentLength: int64(len(i.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s %s?%s %s in %s", ErrNoInteraction, saved.Method, saved.Path, saved.Query, saved.Body, r.path)
+}
+
+// Save writes the recorded interactions, it does nothing unless the mode is ModeRecord
+func (r *Recorder) Save() error {
+
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(r.path, append(data, '\n'), 0644)
+}
+
+func (saved Request) matches(req Request) bool {
+	return saved.Method == req.Method && saved.Path == req.Path && saved.Query == req.Query && saved.Body == req.Body
+}
+
+// request is the scrubbed and normalized form of `req`, the same for the recorded and the replayed requests
+func (r *Recorder) request(req *http.Request, body []byte) Request {
+	return Request{
+		Method: req.Method,
+		Path:   r.scrub(req.URL.Path),
+		Query:  r.scrub(req.URL.Query().Encode()),
+		Header: r.header(req.Header),
+		Body:   r.scrubBody(body),
+	}
+}
+
+func (r *Recorder) header(h http.Header) http.Header {
+	out := http.Header{}
+	for k, vs := range h {
+		for _, v := range vs {
+			out.Add(k, r.scrub(v))
+		}
+	}
+	for _, k := range scrubbedHeaders {
+		out.Del(k)
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+func (r *Recorder) scrub(s string) string {
+	for _, secret := range r.secrets {
+		s = strings.Replace(s, secret, Redacted, -1)
+	}
+	return s
+}
+
+// scrubBody redacts the secrets and PII fields of a JSON body, and marshals it again with sorted keys
+func (r *Recorder) scrubBody(body []byte) string {
+
+	if len(body) == 0 {
+		return ""
+	}
+
+	var v interface{}
+	d := json.NewDecoder(bytes.NewReader(body))
+	d.UseNumber()
+	if err := d.Decode(&v); err != nil {
+		return r.scrub(string(body))
+	}
+
+	normalized, err := json.Marshal(r.redact(v, false))
+	if err != nil {
+		return r.scrub(string(body))
+	}
+	return string(normalized)
+}
+
+func (r *Recorder) redact(v interface{}, pii bool) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, e := range v {
+			v[k] = r.redact(e, pii || r.piiFields[k])
+		}
+		return v
+	case []interface{}:
+		for i, e := range v {
+			v[i] = r.redact(e, pii)
+		}
+		return v
+	case string:
+		if pii && v != "" {
+			return Redacted
+		}
+		return r.scrub(v)
+	}
+	return v
+}
@@ -0,0 +1,110 @@
+package cassette
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	linepay "github.com/chy168/line-pay-sdk-go"
+)
+
+const (
+	channelSecret = "a917ab6a2367b536f8e5a6e2977e06f4"
+	regKey        = "RK9A4BD2D0E4DC1AB"
+	cardNumber    = "4321-****-****-1234"
+)
+
+func TestRecorder(t *testing.T) {
+
+	var polls int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/confirm"):
+			w.Write([]byte(`{"returnCode":"0000","returnMessage":"Success.","info":{"transactionId":2019049910005496810,"orderId":"order-1","regKey":"` + regKey + `","payInfo":[{"method":"CREDIT_CARD","amount":100,"maskedCreditCardNumber":"` + cardNumber + `"}]}}`))
+		case strings.HasSuffix(r.URL.Path, "/check"):
+			polls++
+			code := "0000"
+			if polls > 1 {
+				code = "0123"
+			}
+			w.Write([]byte(`{"returnCode":"` + code + `","returnMessage":"Success."}`))
+		default:
+			w.Write([]byte(`{"returnCode":"0000","returnMessage":"Success."}`))
+		}
+	}))
+
+	path := filepath.Join(t.TempDir(), "confirm.json")
+
+	play := func(mode Mode) (*linepay.PaymentsConfirmResponse, []string, error) {
+		recorder, err := New(path, mode, WithSecrets(channelSecret, regKey))
+		if err != nil {
+			t.Fatalf("New(%s) error = %v", mode, err)
+		}
+		client, _ := linepay.NewClient("1234567890", channelSecret, nil, &linepay.ClientOpts{APIEndpoint: ts.URL, Transport: recorder})
+
+		ctx := context.Background()
+		res, err := client.PaymentsConfirm(ctx, 2019049910005496810, &linepay.PaymentsConfirmRequest{Amount: 100, Currency: "TWD"})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var statuses []string
+		for i := 0; i < 2; i++ {
+			status, err := client.PaymentsStatus(ctx, 2019049910005496810)
+			if err != nil {
+				return nil, nil, err
+			}
+			statuses = append(statuses, status.ReturnCode)
+		}
+		if _, err := client.PaymentsPreapproved(ctx, regKey, &linepay.PaymentsPreapprovedRequest{ProductName: "plan", Amount: 100, Currency: "TWD", OrderID: "order-2"}); err != nil {
+			return nil, nil, err
+		}
+		return res, statuses, recorder.Save()
+	}
+
+	if _, _, err := play(ModeRecord); err != nil {
+		t.Fatalf("record error = %v", err)
+	}
+
+	data, _ := ioutil.ReadFile(path)
+	for _, leak := range []string{channelSecret, regKey, cardNumber, "X-Line-Authorization", "Nonce"} {
+		if strings.Contains(string(data), leak) {
+			t.Errorf("cassette leaks %q:\n%s", leak, data)
+		}
+	}
+
+	// replay offline, the signatures and nonces differ from the recording
+	ts.Close()
+
+	res, statuses, err := play(ModeReplay)
+	if err != nil {
+		t.Fatalf("replay error = %v", err)
+	}
+	if res.Info.TransactionID != 2019049910005496810 || res.Info.RegKey.Reveal() != Redacted || res.Info.PayInfo[0].Amount != 100 {
+		t.Errorf("replayed %+v", res.Info)
+	}
+	if strings.Join(statuses, ",") != "0000,0123" {
+		t.Errorf("replayed statuses %v, want the recorded sequence", statuses)
+	}
+
+	recorder, _ := New(path, ModeReplay)
+	client, _ := linepay.NewClient("1234567890", channelSecret, nil, &linepay.ClientOpts{Transport: recorder})
+	if _, err := client.PaymentsConfirm(context.Background(), 1, &linepay.PaymentsConfirmRequest{Amount: 100, Currency: "TWD"}); err == nil || !strings.Contains(err.Error(), ErrNoInteraction.Error()) {
+		t.Errorf("unrecorded request error = %v", err)
+	}
+}
+
+func TestParseMode(t *testing.T) {
+	for s, want := range map[string]Mode{"": ModeReplay, "record": ModeRecord, "PASSTHROUGH": ModePassthrough} {
+		if got, err := ParseMode(s); err != nil || got != want {
+			t.Errorf("ParseMode(%q) = %v, %v", s, got, err)
+		}
+	}
+	if _, err := ParseMode("rewind"); err == nil {
+		t.Errorf("ParseMode(rewind) no error")
+	}
+}
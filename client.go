@@ -5,84 +5,188 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"net/http"
 	"net/url"
 	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
 )
 
 const (
 	APIHostSandbox    = "https://sandbox-api-pay.line.me"
 	APIHostProduction = "https://api-pay.line.me"
 
-	// POST /v3/payments/request
-	endpointV3PaymentsRequest = "/v3/payments/request"
-
-	// POST /v3/payments/{transactionId}/confirm
-	endpointV3PaymentsConfirm = "/v3/payments/%d/confirm"
-
-	// GET /v3/payments
-	endpointV3PaymentsDetails = "/v3/payments"
-
-	// POST /v3/payments/authorizations/{transactionId}/capture
-	endpointV3PaymentsCapture = "/v3/payments/authorizations/%d/capture"
+	APIVersion2 = 2
+	APIVersion3 = 3
 )
 
 type Client struct {
-	channelID     string
-	channelSecret string
-	apiEndpoint   *url.URL
-	httpClient    *http.Client
-	signer        *Signer
+	channelID   string
+	secrets     *channelSecrets
+	apiEndpoint *url.URL
+	httpClient  *http.Client
+	auth        Authenticator
+	apiVersion  int
+	vault       Vault
+	retry       RetryPolicy
+	observers   []Observer
+	events      Publisher
+	idempotency IdempotencyPolicy
+	flights     *flightGroup
+	limits      *limits
+	breakers    *breakers
 }
 
+// `APIEndpoint` optional, overrides the host chosen by `ProductionEnabled` (e.g. a mock server)
+// `Timeout` optional, limits every HTTP call including reading the response
+// `Transport` optional, replaces http.DefaultTransport (e.g. a cassette.Recorder in tests)
+// `Retry` optional, see RetryPolicy
+// `Vault` optional, opens the sealed regKeys given to the `*Sealed` methods
+// `Observers` optional, notified of every API call, see Observer
+// `Events` optional, receives the payment events of the successful calls, see Event
+// `Limits` optional, rate and concurrency limits by operation, see LimitPolicy
+// `Breaker` optional, fails the calls of an operation fast while LINE Pay fails them, see BreakerPolicy
+// `Idempotency` optional, where the calls made with an idempotency key save their response, see WithIdempotencyKey
+// `APIVersion` optional, APIVersion3 (default) or APIVersion2 for the legacy `/v2` endpoints
+// `Auth` optional, replaces the authentication of the API version: the `signer` of NewClient for v3, HeaderAuth for v2
 type ClientOpts struct {
 	ProductionEnabled bool
+	APIEndpoint       string
+	APIVersion        int
+	Auth              Authenticator
+	Timeout           time.Duration
+	Transport         http.RoundTripper
+	Retry             RetryPolicy
+	Vault             Vault
+	Observers         []Observer
+	Events            Publisher
+	Limits            LimitPolicy
+	Breaker           BreakerPolicy
+	Idempotency       IdempotencyPolicy
 }
 
+// NewClient creates a client of the channel, when `signer` is nil a Signer of `channelID` is used. `opts` may be nil.
 func NewClient(channelID, channelSecret string, signer *Signer, opts *ClientOpts) (*Client, error) {
 	if channelSecret == "" || channelID == "" {
 		return nil, errors.New("channel id or secret not correct")
 	}
 
+	if opts == nil {
+		opts = &ClientOpts{}
+	}
+
+	apiVersion := opts.APIVersion
+	if apiVersion == 0 {
+		apiVersion = APIVersion3
+	}
+
+	var auth Authenticator
+	switch {
+	case opts.Auth != nil:
+		auth = opts.Auth
+	case apiVersion == APIVersion2:
+		auth = HeaderAuth{ChannelId: channelID}
+	case apiVersion == APIVersion3 && signer != nil:
+		auth = signer
+	case apiVersion == APIVersion3:
+		auth = &Signer{ChannelId: channelID}
+	default:
+		return nil, fmt.Errorf("unsupported API version %d", opts.APIVersion)
+	}
+
 	apiEndpoint := APIHostSandbox
 	if opts.ProductionEnabled {
 		apiEndpoint = APIHostProduction
 	}
+	if opts.APIEndpoint != "" {
+		apiEndpoint = opts.APIEndpoint
+	}
 
 	uu, err := url.ParseRequestURI(apiEndpoint)
 	if err != nil {
 		return nil, err
 	}
 
+	idempotency := opts.Idempotency
+	if idempotency.Store == nil {
+		idempotency.Store = NewMemoryIdempotencyStore()
+	}
+	if idempotency.TTL <= 0 {
+		idempotency.TTL = DefaultIdempotencyTTL
+	}
+
 	c := &Client{
-		channelID:     channelID,
-		channelSecret: channelSecret,
-		apiEndpoint:   uu,
-		httpClient:    http.DefaultClient,
-		signer:        signer,
+		channelID:   channelID,
+		secrets:     &channelSecrets{current: channelSecret},
+		apiEndpoint: uu,
+		httpClient:  http.DefaultClient,
+		auth:        auth,
+		apiVersion:  apiVersion,
+		vault:       opts.Vault,
+		retry:       opts.Retry,
+		observers:   opts.Observers,
+		events:      opts.Events,
+		idempotency: idempotency,
+		flights:     &flightGroup{},
+		limits:      newLimits(opts.Limits, opts.Observers),
+		breakers:    newBreakers(opts.Breaker),
+	}
+
+	if opts.Timeout > 0 || opts.Transport != nil {
+		c.httpClient = &http.Client{Timeout: opts.Timeout, Transport: opts.Transport}
 	}
 
 	return c, nil
 }
 
-func (client *Client) post(ctx context.Context, endpoint string, body []byte) (res *http.Response, err error) {
+// ChannelID returns the channel the client is bound to
+func (client *Client) ChannelID() string {
+	return client.channelID
+}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", client.url(endpoint), bytes.NewReader(body))
-	if err != nil {
-		err = fmt.Errorf("post request error: %s", err.Error())
-		return
+// APIVersion returns APIVersion3 or APIVersion2
+func (client *Client) APIVersion() int {
+	return client.apiVersion
+}
+
+// RotateSecret replaces the channel secret without rebuilding the client.
+// Until `grace` is over, a call rejected by LINE Pay with the new secret is sent again with the previous one,
+// so the secret can be reissued on the LINE Pay side at any moment of the cutover.
+func (client *Client) RotateSecret(channelSecret string, grace time.Duration) error {
+	if channelSecret == "" {
+		return errors.New("channel secret not correct")
 	}
+	client.secrets.rotate(channelSecret, time.Now().Add(grace))
+	return nil
+}
 
-	header, err := client.signer.SignWithBody(req, client.channelSecret, string(body))
+func (client *Client) post(ctx context.Context, call *CallInfo, endpoint string, body []byte) (res *http.Response, err error) {
 
-	req.Header = header
-	req.Header.Add("Content-Type", "application/json")
+	return client.send(ctx, call, func(ctx context.Context, channelSecret string) (req *http.Request, err error) {
 
-	res, err = client.do(ctx, req)
-	return
+		req, err = http.NewRequestWithContext(ctx, "POST", client.url(endpoint), bytes.NewReader(body))
+		if err != nil {
+			err = fmt.Errorf("post request error: %w", err)
+			return
+		}
+
+		header, err := client.auth.SignWithBody(req, channelSecret, string(body))
+		if err != nil {
+			return
+		}
+
+		req.Header = header
+		req.Header.Add("Content-Type", "application/json")
+		return
+	})
 }
 
-func (client *Client) get(ctx context.Context, endpoint string, params *url.Values) (res *http.Response, err error) {
+func (client *Client) get(ctx context.Context, call *CallInfo, endpoint string, params *url.Values) (res *http.Response, err error) {
 
 	myURL := client.url(endpoint)
 	targetURL, err := url.ParseRequestURI(myURL)
@@ -92,27 +196,98 @@ func (client *Client) get(ctx context.Context, endpoint string, params *url.Valu
 	}
 	targetURL.RawQuery = params.Encode()
 
-	req, err := http.NewRequestWithContext(ctx, "GET", targetURL.String(), nil)
+	return client.send(ctx, call, func(ctx context.Context, channelSecret string) (req *http.Request, err error) {
+
+		req, err = http.NewRequestWithContext(ctx, "GET", targetURL.String(), nil)
+		if err != nil {
+			err = fmt.Errorf("get request error: %w", err)
+			return
+		}
+
+		header, err := client.auth.SignWithBody(req, channelSecret, params.Encode())
+		if err != nil {
+			return
+		}
+
+		req.Header = header
+		return
+	})
+}
+
+// send signs the request with the current secret, during a secret rotation it falls back to the previous secret when the current one is rejected
+func (client *Client) send(ctx context.Context, call *CallInfo, build func(ctx context.Context, channelSecret string) (*http.Request, error)) (res *http.Response, err error) {
+
+	class := RetryByMethod
+	var lim *limiter
+	if call != nil {
+		call.Route = client.versioned(call.Route)
+		class = call.retry
+		lim = client.limits.of(call.Operation)
+	}
+
+	release, err := lim.acquire(ctx)
 	if err != nil {
-		err = fmt.Errorf("get request error: %s", err.Error())
 		return
 	}
 
-	header, err := client.signer.SignWithBody(req, client.channelSecret, params.Encode())
+	res, err = client.observe(ctx, call, func(ctx context.Context) (res *http.Response, err error) {
 
-	req.Header = header
+		current, previous := client.secrets.get(time.Now())
 
-	res, err = client.do(ctx, req)
+		res, err = client.doRetry(ctx, class, lim, func() (*http.Request, error) { return build(ctx, current) })
+		if err != nil || previous == "" {
+			return
+		}
+
+		return client.fallback(ctx, class, lim, res, func() (*http.Request, error) { return build(ctx, previous) })
+	})
+	if err != nil {
+		release()
+		return
+	}
+
+	// the in-flight slot is held until the body is read
+	res.Body = &releasingBody{ReadCloser: res.Body, release: release}
 	return
 }
 
+// fallback sends the request again with the previous secret when LINE Pay rejected `res`
+func (client *Client) fallback(ctx context.Context, class RetryClass, lim *limiter, res *http.Response, build func() (*http.Request, error)) (*http.Response, error) {
+
+	bodyBytes, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("ReadAll read body failed: %w", err)
+	}
+
+	if !credentialsRejected(res.StatusCode, bodyBytes) {
+		res.Body = ioutil.NopCloser(bytes.NewReader(bodyBytes))
+		return res, nil
+	}
+
+	logrus.Warnf("channel '%s' rejected the current secret, retry with the previous secret", client.channelID)
+
+	return client.doRetry(ctx, class, lim, build)
+}
+
+// url of the escaped path `endpoint`
 func (client *Client) url(endpoint string) string {
 	u := *client.apiEndpoint
-	u.Path = path.Join(u.Path, endpoint)
+	u.RawPath = path.Join(u.EscapedPath(), client.versioned(endpoint))
+	u.Path, _ = url.PathUnescape(u.RawPath)
 	return u.String()
 }
 
-func (client *Client) do(ctx context.Context, req *http.Request) (*http.Response, error) {
+// versioned maps a v3 endpoint to the API version of the client, the v2 endpoints are the v3 ones under `/v2`.
+// `/v3/payments/requests/{transactionId}/check` has no v2 equivalent, PaymentsStatus refuses a v2 client before.
+func (client *Client) versioned(endpoint string) string {
+	if client.apiVersion == APIVersion2 && strings.HasPrefix(endpoint, "/v3/") {
+		return "/v2/" + strings.TrimPrefix(endpoint, "/v3/")
+	}
+	return endpoint
+}
+
+func (client *Client) roundTrip(ctx context.Context, req *http.Request) (*http.Response, error) {
 
 	req.Header.Set("User-Agent", "line-pay-sdk-go")
 
@@ -128,3 +303,51 @@ func (client *Client) do(ctx context.Context, req *http.Request) (*http.Response
 	return res, err
 
 }
+
+// releasingBody calls `release` once, when the body is closed
+type releasingBody struct {
+	io.ReadCloser
+	release func()
+	once    sync.Once
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
+
+// credentialsRejected tells whether LINE Pay refused the channel id or signature of the request
+func credentialsRejected(statusCode int, body []byte) bool {
+	if statusCode == http.StatusUnauthorized {
+		return true
+	}
+	return returnCodeOf(body) == ApiReturnCodeHeaderError
+}
+
+// channelSecrets holds the channel secret, and the previous one while a rotation is in progress
+type channelSecrets struct {
+	mu            sync.RWMutex
+	current       string
+	previous      string
+	previousUntil time.Time
+}
+
+func (s *channelSecrets) get(now time.Time) (current, previous string) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if now.Before(s.previousUntil) {
+		previous = s.previous
+	}
+	return s.current, previous
+}
+
+func (s *channelSecrets) rotate(channelSecret string, previousUntil time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.previous = s.current
+	s.previousUntil = previousUntil
+	s.current = channelSecret
+}
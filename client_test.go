@@ -4,16 +4,18 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
 	"testing"
+
+	"github.com/chy168/line-pay-sdk-go/cassette"
 )
 
 func TestClient_PaymentsRequest(t *testing.T) {
 
-	client, err := NewClient(ChannelID, ChannelSecret, &Signer{ChannelId: ChannelID}, &ClientOpts{})
-	if err != nil {
-		t.Errorf("New() error = %v", err.Error())
-		return
-	}
+	client := newTestClient(t)
 
 	data := PaymentsRequest{
 		Amount:   100,
@@ -53,12 +55,7 @@ func TestClient_PaymentsRequestAndConfirm(t *testing.T) {
 
 	t.Parallel()
 
-	client, err := NewClient(ChannelID, ChannelSecret, &Signer{ChannelId: ChannelID}, &ClientOpts{})
-	// client, err := NewClient(ChannelID, ChannelSecret, APIHostSandbox, "http://localhost:9988")
-	if err != nil {
-		t.Errorf("New() error = %v", err.Error())
-		return
-	}
+	client := newTestClient(t)
 
 	data := PaymentsRequest{
 		Amount:   100,
@@ -109,11 +106,7 @@ func TestClient_PaymentsConfirm(t *testing.T) {
 
 	t.Parallel()
 
-	client, err := NewClient(ChannelID, ChannelSecret, &Signer{ChannelId: ChannelID}, &ClientOpts{})
-	if err != nil {
-		t.Errorf("New() error = %v", err.Error())
-		return
-	}
+	client := newTestClient(t)
 
 	data2 := PaymentsConfirmRequest{
 		Amount:   100,
@@ -131,14 +124,10 @@ func TestClient_PaymentsConfirm(t *testing.T) {
 
 func TestClient_PaymentsDetails(t *testing.T) {
 
-	client, err := NewClient(ChannelID, ChannelSecret, &Signer{ChannelId: ChannelID}, &ClientOpts{})
-	if err != nil {
-		t.Errorf("New() error = %v", err.Error())
-		return
-	}
+	client := newTestClient(t)
 
 	data := PaymentsDetailsRequest{
-		TransactionIDs: []int64{2020011300254002010, 2020010900231782310, 2020010900229878210},
+		TransactionIDs: []TransactionID{2020011300254002010, 2020010900231782310, 2020010900229878210},
 		OrderIDs:       []string{"order_0be9807d-88cf-42fe-bf69-75a51f1ad83f", "order_9583d466-6c47-488b-813f-894c0a26d7e8", "order_d776f2dd-eb7a-4611-b8cc-53242b9d7e71"},
 		Fields:         PaymentsDetailsFieldsDefault,
 	}
@@ -154,11 +143,7 @@ func TestClient_PaymentsDetails(t *testing.T) {
 
 func TestClient_PaymentsCapture_1_Request(t *testing.T) {
 
-	client, err := getClient()
-	if err != nil {
-		t.Errorf("New() error = %v", err.Error())
-		return
-	}
+	client := newTestClient(t)
 
 	data := PaymentsRequest{
 		Amount:   100,
@@ -204,11 +189,7 @@ func TestClient_PaymentsCapture_1_Request(t *testing.T) {
 
 func TestClient_PaymentsCapture_2_Capture(t *testing.T) {
 
-	client, err := NewClient(ChannelID, ChannelSecret, &Signer{ChannelId: ChannelID}, &ClientOpts{})
-	if err != nil {
-		t.Errorf("New() error = %v", err.Error())
-		return
-	}
+	client := newTestClient(t)
 
 	data2 := PaymentsCaptureRequest{
 		Amount:   100,
@@ -224,8 +205,83 @@ func TestClient_PaymentsCapture_2_Capture(t *testing.T) {
 
 }
 
-func getClient() (client *Client, err error) {
-	return NewClient(ChannelID, ChannelSecret, &Signer{ChannelId: ChannelID}, &ClientOpts{})
+func TestClient_PaymentsPreapproved(t *testing.T) {
+
+	type signed struct {
+		method, path, rawPath, body string
+	}
+	var got []signed
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		body := string(b)
+		if r.Method == http.MethodGet {
+			body = r.URL.RawQuery
+		}
+		nonce := r.Header.Get("X-LINE-Authorization-Nonce")
+		if r.Header.Get("X-LINE-Authorization") != calculate("secret", "secret"+r.URL.Path+body+nonce) {
+			t.Errorf("%s %s signature does not match", r.Method, r.URL.Path)
+		}
+		got = append(got, signed{r.Method, r.URL.Path, r.URL.EscapedPath(), body})
+		fmt.Fprint(w, `{"returnCode":"0000","returnMessage":"Success."}`)
+	}))
+	defer ts.Close()
+
+	client, _ := NewClient("1001", "secret", nil, &ClientOpts{APIEndpoint: ts.URL})
+	ctx := context.Background()
+
+	// the regKey is escaped, it can not change the path
+	regKey := "RK 9A%2E7"
+	if _, err := client.PaymentsPreapproved(ctx, regKey, &PaymentsPreapprovedRequest{ProductName: "plan", Amount: 100, Currency: "TWD", OrderID: "order-1"}); err != nil {
+		t.Fatalf("PaymentsPreapproved() error = %v", err)
+	}
+	if _, err := client.PaymentsCheckRegKey(ctx, regKey, &PaymentsCheckRegKeyRequest{CreditCardAuth: true}); err != nil {
+		t.Fatalf("PaymentsCheckRegKey() error = %v", err)
+	}
+	if _, err := client.PaymentsExpireRegKey(ctx, regKey); err != nil {
+		t.Fatalf("PaymentsExpireRegKey() error = %v", err)
+	}
+
+	want := []signed{
+		{http.MethodPost, "/v3/payments/preapprovedPay/RK 9A%2E7/payment", "/v3/payments/preapprovedPay/RK%209A%252E7/payment",
+			`{"productName":"plan","amount":100,"currency":"TWD","orderId":"order-1","capture":false}`},
+		{http.MethodGet, "/v3/payments/preapprovedPay/RK 9A%2E7/check", "/v3/payments/preapprovedPay/RK%209A%252E7/check", "creditCardAuth=true"},
+		{http.MethodPost, "/v3/payments/preapprovedPay/RK 9A%2E7/expire", "/v3/payments/preapprovedPay/RK%209A%252E7/expire", ""},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("requests = %+v", got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("request %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+// newTestClient replays the sandbox interactions of the test saved in `testdata/cassettes`.
+// Run with LINEPAY_CASSETTE_MODE=record and the channel of `data_test.go` to record them again,
+// or LINEPAY_CASSETTE_MODE=passthrough to call the sandbox without recording.
+func newTestClient(t *testing.T) *Client {
+
+	mode, err := cassette.ModeFromEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	recorder, err := cassette.New(filepath.Join("testdata", "cassettes", t.Name()+".json"), mode, cassette.WithSecrets(ChannelSecret))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := recorder.Save(); err != nil {
+			t.Errorf("save cassette error = %v", err)
+		}
+	})
+
+	client, err := NewClient(ChannelID, ChannelSecret, &Signer{ChannelId: ChannelID}, &ClientOpts{Transport: recorder})
+	if err != nil {
+		t.Fatalf("New() error = %v", err.Error())
+	}
+	return client
 }
 
 func printRequestInfo(res *PaymentsResponse, dumpBody bool) {
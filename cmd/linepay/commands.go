@@ -0,0 +1,378 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	linepay "github.com/chy168/line-pay-sdk-go"
+)
+
+func runRequest(c *cli, fs *flag.FlagSet, args []string) error {
+
+	amount := fs.Int("amount", 0, "amount (required)")
+	currency := fs.String("currency", "TWD", "currency: USD, JPY, TWD, THB")
+	orderID := fs.String("order-id", "", "order id (required)")
+	productName := fs.String("product-name", "", "product name (required)")
+	confirmURL := fs.String("confirm-url", "", "confirm url (required)")
+	cancelURL := fs.String("cancel-url", "", "cancel url, defaults to confirm url")
+	authorizeOnly := fs.Bool("authorize-only", false, "do not capture on confirm, call capture later")
+	preapproved := fs.Bool("preapproved", false, "PREAPPROVED payment, confirm returns a regKey")
+	qr := fs.Bool("qr", false, "print the Web URL as a QR code")
+
+	required := func() error {
+		if *amount <= 0 || *orderID == "" || *productName == "" || *confirmURL == "" {
+			return errors.New("--amount, --order-id, --product-name and --confirm-url are required")
+		}
+		return nil
+	}
+	if err := c.setup(fs, args, "request a payment", required); err != nil {
+		return err
+	}
+	if *cancelURL == "" {
+		*cancelURL = *confirmURL
+	}
+
+	request := &linepay.PaymentsRequest{
+		Amount:   *amount,
+		Currency: *currency,
+		OrderID:  *orderID,
+		Packages: []linepay.PaymentsPackageRequest{
+			{
+				ID:     *orderID,
+				Amount: *amount,
+				Name:   *productName,
+				Products: []linepay.PaymentsPackageProductRequest{
+					{Name: *productName, Quantity: 1, Price: *amount},
+				},
+			},
+		},
+		RedirectUrls: linepay.PaymentsRedirectUrlsRequest{
+			ConfirmURL: *confirmURL,
+			CancelURL:  *cancelURL,
+		},
+	}
+	request.Options.Payment.Capture = !*authorizeOnly
+	if *preapproved {
+		request.Options.Payment.PayType = "PREAPPROVED"
+	}
+
+	res, err := c.client.PaymentsRequest(context.Background(), request)
+	if err != nil {
+		return err
+	}
+
+	if err := c.print(res, [][2]string{
+		{"Return Code", res.ReturnCode},
+		{"Return Message", res.ReturnMessage},
+		{"Transaction ID", res.Info.TransactionID.String()},
+		{"Web URL", res.Info.PaymentURL.Web},
+		{"App URL", res.Info.PaymentURL.App},
+	}); err != nil {
+		return err
+	}
+
+	if *qr && !*c.json && res.Info.PaymentURL.Web != "" {
+		code, err := res.Info.PaymentURL.QRCode()
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(c.stdout, "\n"+code.Unicode(2, true))
+	}
+	return result(res.ReturnCode, res.ReturnMessage)
+}
+
+func runConfirm(c *cli, fs *flag.FlagSet, args []string) error {
+
+	transactionID := fs.Int64("transaction-id", 0, "transaction id (required)")
+	amount := fs.Int("amount", 0, "amount (required)")
+	currency := fs.String("currency", "TWD", "currency: USD, JPY, TWD, THB")
+	showRegKey := fs.Bool("show-reg-key", false, "print the regKey of a PREAPPROVED payment, redacted otherwise")
+
+	required := func() error {
+		if *transactionID == 0 || *amount <= 0 {
+			return errors.New("--transaction-id and --amount are required")
+		}
+		return nil
+	}
+	if err := c.setup(fs, args, "confirm a payment", required); err != nil {
+		return err
+	}
+
+	res, err := c.client.PaymentsConfirm(context.Background(), linepay.TransactionID(*transactionID), &linepay.PaymentsConfirmRequest{Amount: *amount, Currency: *currency})
+	if err != nil {
+		return err
+	}
+
+	rows := [][2]string{
+		{"Return Code", res.ReturnCode},
+		{"Return Message", res.ReturnMessage},
+		{"Transaction ID", res.Info.TransactionID.String()},
+		{"Order ID", res.Info.OrderID},
+	}
+	if !res.Info.AuthorizationExpireDate.IsZero() {
+		rows = append(rows, [2]string{"Authorization Expire", res.Info.AuthorizationExpireDate.String()})
+	}
+	rows = append(rows, payInfoRows(res.Info.PayInfo)...)
+
+	var response interface{} = res
+	if *showRegKey && res.Info.RegKey != "" {
+		rows = append(rows, [2]string{"Reg Key", res.Info.RegKey.Reveal()})
+		// `info.regKey` stays redacted, the plain regKey is added at the top level of the JSON
+		response = struct {
+			*linepay.PaymentsConfirmResponse
+			RegKey string `json:"regKey"`
+		}{res, res.Info.RegKey.Reveal()}
+	}
+
+	if err := c.print(response, rows); err != nil {
+		return err
+	}
+	return result(res.ReturnCode, res.ReturnMessage)
+}
+
+func payInfoRows(payInfo []linepay.PaymentsConfirmInfoPayInfoResponse) (rows [][2]string) {
+	for _, p := range payInfo {
+		v := strconv.Itoa(p.Amount)
+		if p.MaskedCreditCardNumber != "" {
+			v += " " + p.MaskedCreditCardNumber
+		}
+		rows = append(rows, [2]string{"Paid by " + p.Method, v})
+	}
+	return
+}
+
+func runCapture(c *cli, fs *flag.FlagSet, args []string) error {
+
+	transactionID := fs.Int64("transaction-id", 0, "transaction id (required)")
+	amount := fs.Int("amount", 0, "amount (required)")
+	currency := fs.String("currency", "TWD", "currency: USD, JPY, TWD, THB")
+
+	required := func() error {
+		if *transactionID == 0 || *amount <= 0 {
+			return errors.New("--transaction-id and --amount are required")
+		}
+		return nil
+	}
+	if err := c.setup(fs, args, "capture a payment", required); err != nil {
+		return err
+	}
+
+	res, err := c.client.PaymentsCapture(context.Background(), linepay.TransactionID(*transactionID), &linepay.PaymentsCaptureRequest{Amount: *amount, Currency: *currency})
+	if err != nil {
+		return err
+	}
+
+	rows := [][2]string{
+		{"Return Code", res.ReturnCode},
+		{"Return Message", res.ReturnMessage},
+		{"Transaction ID", res.Info.TransactionID.String()},
+		{"Order ID", res.Info.OrderID},
+	}
+	for _, p := range res.Info.PayInfo {
+		rows = append(rows, [2]string{"Paid by " + p.Method, strconv.Itoa(p.Amount)})
+	}
+
+	if err := c.print(res, rows); err != nil {
+		return err
+	}
+	return result(res.ReturnCode, res.ReturnMessage)
+}
+
+func runVoid(c *cli, fs *flag.FlagSet, args []string) error {
+
+	transactionID := fs.Int64("transaction-id", 0, "transaction id (required)")
+
+	required := func() error {
+		if *transactionID == 0 {
+			return errors.New("--transaction-id is required")
+		}
+		return nil
+	}
+	if err := c.setup(fs, args, "void a payment", required); err != nil {
+		return err
+	}
+
+	res, err := c.client.PaymentsVoid(context.Background(), linepay.TransactionID(*transactionID))
+	if err != nil {
+		return err
+	}
+
+	if err := c.print(res, [][2]string{
+		{"Return Code", res.ReturnCode},
+		{"Return Message", res.ReturnMessage},
+	}); err != nil {
+		return err
+	}
+	return result(res.ReturnCode, res.ReturnMessage)
+}
+
+func runRefund(c *cli, fs *flag.FlagSet, args []string) error {
+
+	transactionID := fs.Int64("transaction-id", 0, "transaction id (required)")
+	amount := fs.Int("amount", 0, "refund amount, the full amount when omitted")
+
+	required := func() error {
+		if *transactionID == 0 {
+			return errors.New("--transaction-id is required")
+		}
+		return nil
+	}
+	if err := c.setup(fs, args, "refund a payment", required); err != nil {
+		return err
+	}
+
+	res, err := c.client.PaymentsRefund(context.Background(), linepay.TransactionID(*transactionID), &linepay.PaymentsRefundRequest{RefundAmount: *amount})
+	if err != nil {
+		return err
+	}
+
+	if err := c.print(res, [][2]string{
+		{"Return Code", res.ReturnCode},
+		{"Return Message", res.ReturnMessage},
+		{"Refund Transaction ID", res.Info.RefundTransactionID.String()},
+		{"Refund Date", res.Info.RefundTransactionDate.String()},
+	}); err != nil {
+		return err
+	}
+	return result(res.ReturnCode, res.ReturnMessage)
+}
+
+func runDetails(c *cli, fs *flag.FlagSet, args []string) error {
+
+	transactionIDs := fs.String("transaction-id", "", "comma separated transaction ids")
+	orderIDs := fs.String("order-id", "", "comma separated order ids")
+
+	required := func() error {
+		if *transactionIDs == "" && *orderIDs == "" {
+			return errors.New("--transaction-id or --order-id is required")
+		}
+		return nil
+	}
+	if err := c.setup(fs, args, "", required); err != nil {
+		return err
+	}
+
+	request := &linepay.PaymentsDetailsRequest{}
+	for _, s := range splitList(*transactionIDs) {
+		id, err := linepay.ParseTransactionID(s)
+		if err != nil {
+			return err
+		}
+		request.TransactionIDs = append(request.TransactionIDs, id)
+	}
+	request.OrderIDs = splitList(*orderIDs)
+
+	res, err := c.client.PaymentsDetails(context.Background(), request)
+	if err != nil {
+		return err
+	}
+
+	if *c.json {
+		if err := c.print(res, nil); err != nil {
+			return err
+		}
+		return result(res.ReturnCode, res.ReturnMessage)
+	}
+
+	if err := result(res.ReturnCode, res.ReturnMessage); err != nil {
+		return err
+	}
+
+	rows := [][2]string{{"TRANSACTION ID", "DATE\tTYPE\tSTATUS\tCURRENCY\tAMOUNT\tREFUNDED\tPRODUCT"}}
+	for _, info := range res.Info {
+		amount, refunded := 0, 0
+		for _, p := range info.PayInfo {
+			amount += p.Amount
+		}
+		for _, r := range info.RefundList {
+			refunded += r.RefundAmount
+		}
+		rows = append(rows, [2]string{
+			info.TransactionID.String(),
+			strings.Join([]string{
+				info.TransactionDate.Format("2006-01-02 15:04:05"),
+				info.TransactionType,
+				info.PayStatus,
+				info.Currency,
+				strconv.Itoa(amount),
+				strconv.Itoa(refunded),
+				info.ProductName,
+			}, "\t"),
+		})
+	}
+	return c.print(res, rows)
+}
+
+func runStatus(c *cli, fs *flag.FlagSet, args []string) error {
+
+	transactionID := fs.Int64("transaction-id", 0, "transaction id (required)")
+
+	required := func() error {
+		if *transactionID == 0 {
+			return errors.New("--transaction-id is required")
+		}
+		return nil
+	}
+	if err := c.setup(fs, args, "", required); err != nil {
+		return err
+	}
+
+	res, err := c.client.PaymentsStatus(context.Background(), linepay.TransactionID(*transactionID))
+	if err != nil {
+		return err
+	}
+
+	status := map[string]string{
+		linepay.PaymentsStatusPending:    "PENDING (waiting for the user)",
+		linepay.PaymentsStatusAuthorized: "AUTHORIZED (confirm it)",
+		linepay.PaymentsStatusCanceled:   "CANCELED",
+		linepay.PaymentsStatusFailed:     "FAILED",
+		linepay.PaymentsStatusCompleted:  "COMPLETED",
+	}[res.ReturnCode]
+	if status == "" {
+		status = "UNKNOWN"
+	}
+
+	if err := c.print(res, [][2]string{
+		{"Return Code", res.ReturnCode},
+		{"Return Message", res.ReturnMessage},
+		{"Status", status},
+	}); err != nil {
+		return err
+	}
+	return result(res.ReturnCode, res.ReturnMessage,
+		linepay.PaymentsStatusPending, linepay.PaymentsStatusAuthorized, linepay.PaymentsStatusCanceled,
+		linepay.PaymentsStatusFailed, linepay.PaymentsStatusCompleted)
+}
+
+func splitList(s string) (list []string) {
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			list = append(list, v)
+		}
+	}
+	return
+}
+
+func runPing(c *cli, fs *flag.FlagSet, args []string) error {
+
+	if err := c.setup(fs, args, "", nil); err != nil {
+		return err
+	}
+
+	latency, err := c.client.Ping(context.Background())
+	if err != nil {
+		return err
+	}
+
+	return c.print(map[string]interface{}{"status": "ok", "channelId": c.client.ChannelID(), "latencyMs": latency.Milliseconds()}, [][2]string{
+		{"Channel ID", c.client.ChannelID()},
+		{"Status", "OK"},
+		{"Latency", latency.Round(time.Millisecond).String()},
+	})
+}
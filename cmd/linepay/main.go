@@ -0,0 +1,205 @@
+// Command linepay operates LINE Pay payments from the terminal.
+//
+//	linepay <command> [flags]
+//
+// Credentials are read by `linepay.LoadConfig`: `--linepay-config` file, `LINEPAY_*` environment variables or flags.
+// State-changing commands ask for confirmation on the production environment, unless `--yes` is given.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	linepay "github.com/chy168/line-pay-sdk-go"
+)
+
+type command struct {
+	usage    string
+	changing bool // moves money or changes the payment, confirmed on production
+	run      func(c *cli, fs *flag.FlagSet, args []string) error
+}
+
+var commands = map[string]command{
+	"request": {"create a payment request and print the payment URL", true, runRequest},
+	"confirm": {"confirm a payment authorized by the user", true, runConfirm},
+	"capture": {"capture an authorized payment", true, runCapture},
+	"void":    {"void an authorized payment", true, runVoid},
+	"refund":  {"refund a captured payment", true, runRefund},
+	"details": {"show payments by transaction or order id", false, runDetails},
+	"status":  {"check the status of a payment request", false, runStatus},
+	"ping":    {"check LINE Pay accepts the channel id and secret", false, runPing},
+}
+
+// cli holds the flags shared by every command
+type cli struct {
+	stdin  *bufio.Reader
+	stdout io.Writer
+	stderr io.Writer
+
+	json       *bool
+	sandbox    *bool
+	production *bool
+	yes        *bool
+
+	changing bool
+
+	config *linepay.Config
+	client *linepay.Client
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		usage(stderr)
+		return 2
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "unknown command '%s'\n\n", args[0])
+		usage(stderr)
+		return 2
+	}
+
+	c := &cli{stdin: bufio.NewReader(stdin), stdout: stdout, stderr: stderr, changing: cmd.changing}
+
+	fs := flag.NewFlagSet("linepay "+args[0], flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	linepay.RegisterConfigFlags(fs)
+	c.json = fs.Bool("json", false, "print the raw response as JSON")
+	c.sandbox = fs.Bool("sandbox", false, "use the sandbox environment")
+	c.production = fs.Bool("production", false, "use the production environment")
+	c.yes = fs.Bool("yes", false, "do not ask for confirmation on production")
+
+	if err := cmd.run(c, fs, args[1:]); err != nil {
+		if err != flag.ErrHelp {
+			fmt.Fprintf(stderr, "linepay %s: %s\n", args[0], err.Error())
+		}
+		return 1
+	}
+	return 0
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "usage: linepay <command> [flags]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "commands:")
+
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for _, name := range names {
+		fmt.Fprintf(tw, "  %s\t%s\n", name, commands[name].usage)
+	}
+	tw.Flush()
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "run 'linepay <command> -h' for the flags of a command")
+}
+
+// setup parses the flags, checks them with `required` (may be nil), loads the config and asks for confirmation of a
+// state-changing command on production, last so a confirmed command does not fail on its flags
+func (c *cli) setup(fs *flag.FlagSet, args []string, action string, required func() error) error {
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if required != nil {
+		if err := required(); err != nil {
+			return err
+		}
+	}
+
+	if *c.sandbox && *c.production {
+		return errors.New("--sandbox and --production are exclusive")
+	}
+
+	config, err := linepay.LoadConfig(fs)
+	if err != nil {
+		return err
+	}
+	if *c.sandbox {
+		config.Environment = linepay.EnvironmentSandbox
+	}
+	if *c.production {
+		config.Environment = linepay.EnvironmentProduction
+	}
+
+	if c.changing && production(config) && !*c.yes {
+		fmt.Fprintf(c.stderr, "About to %s on PRODUCTION channel %s. Type 'yes' to continue: ", action, config.ChannelID)
+		answer, _ := c.stdin.ReadString('\n')
+		if strings.TrimSpace(answer) != "yes" {
+			return errors.New("aborted")
+		}
+	}
+
+	client, err := linepay.NewClientFromConfig(config)
+	if err != nil {
+		return err
+	}
+
+	c.config = config
+	c.client = client
+	return nil
+}
+
+// production tells if the client of `config` calls the production host, set by the environment or by `APIEndpoint`
+func production(config *linepay.Config) bool {
+
+	endpoint := config.APIEndpoint
+	if endpoint == "" {
+		return config.Environment == linepay.EnvironmentProduction
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return false
+	}
+	p, _ := url.Parse(linepay.APIHostProduction)
+	return strings.EqualFold(u.Hostname(), p.Hostname())
+}
+
+// print writes the response as JSON with `--json`, otherwise as a table of `rows`
+func (c *cli) print(response interface{}, rows [][2]string) error {
+
+	if *c.json {
+		enc := json.NewEncoder(c.stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(response)
+	}
+
+	tw := tabwriter.NewWriter(c.stdout, 0, 4, 2, ' ', 0)
+	for _, r := range rows {
+		fmt.Fprintf(tw, "%s\t%s\n", r[0], r[1])
+	}
+	return tw.Flush()
+}
+
+// result fails the command when LINE Pay did not return `okCodes` (success by default)
+func result(returnCode, returnMessage string, okCodes ...string) error {
+	if len(okCodes) == 0 {
+		okCodes = []string{linepay.ApiReturnCodeSuccess}
+	}
+	for _, code := range okCodes {
+		if returnCode == code {
+			return nil
+		}
+	}
+	return fmt.Errorf("LINE Pay returned %s: %s", returnCode, returnMessage)
+}
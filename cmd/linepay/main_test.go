@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v3/payments/2020011300254002010/refund":
+			fmt.Fprint(w, `{"returnCode":"0000","returnMessage":"Success.","info":{"refundTransactionId":2020011300254002011}}`)
+		case "/v3/payments/2020011300254002012/confirm":
+			fmt.Fprint(w, `{"returnCode":"0000","returnMessage":"Success.","info":{"transactionId":2020011300254002012,"orderId":"order-1","regKey":"RK9A22E7ZSUQ6BA"}}`)
+		case "/v3/payments":
+			fmt.Fprint(w, `{"returnCode":"0000","returnMessage":"Success.","info":[{"transactionId":2020011300254002010,"payStatus":"CAPTURE","currency":"TWD","payInfo":[{"method":"BALANCE","amount":100}]}]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	t.Setenv("LINEPAY_CHANNEL_ID", "1001")
+	t.Setenv("LINEPAY_CHANNEL_SECRET", "secret")
+	t.Setenv("LINEPAY_API_ENDPOINT", ts.URL)
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"refund", "--transaction-id=2020011300254002010", "--amount=50"}, strings.NewReader(""), &stdout, &stderr); code != 0 {
+		t.Fatalf("refund exit %d: %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "2020011300254002011") {
+		t.Errorf("refund output: %s", stdout.String())
+	}
+
+	stdout.Reset()
+	if code := run([]string{"details", "--json", "--transaction-id=2020011300254002010"}, strings.NewReader(""), &stdout, &stderr); code != 0 {
+		t.Fatalf("details exit %d: %s", code, stderr.String())
+	}
+	out := struct {
+		Info []struct {
+			PayStatus string `json:"payStatus"`
+		} `json:"info"`
+	}{}
+	if err := json.Unmarshal(stdout.Bytes(), &out); err != nil || len(out.Info) != 1 || out.Info[0].PayStatus != "CAPTURE" {
+		t.Errorf("details --json output: %s", stdout.String())
+	}
+
+	for _, show := range []bool{false, true} {
+		stdout.Reset()
+		args := []string{"confirm", "--json", "--transaction-id=2020011300254002012", "--amount=100", fmt.Sprintf("--show-reg-key=%v", show)}
+		if code := run(args, strings.NewReader(""), &stdout, &stderr); code != 0 {
+			t.Fatalf("confirm exit %d: %s", code, stderr.String())
+		}
+		if strings.Contains(stdout.String(), "RK9A22E7ZSUQ6BA") != show {
+			t.Errorf("confirm --show-reg-key=%v output: %s", show, stdout.String())
+		}
+	}
+	stdout.Reset()
+	if code := run([]string{"confirm", "--show-reg-key", "--transaction-id=2020011300254002012", "--amount=100"}, strings.NewReader(""), &stdout, &stderr); code != 0 || !strings.Contains(stdout.String(), "RK9A22E7ZSUQ6BA") {
+		t.Errorf("confirm --show-reg-key exit %d: %s", code, stdout.String())
+	}
+
+	stdout.Reset()
+	if code := run([]string{"ping"}, strings.NewReader(""), &stdout, &stderr); code != 0 || !strings.Contains(stdout.String(), "OK") {
+		t.Errorf("ping exit %d: %s %s", code, stdout.String(), stderr.String())
+	}
+}
+
+func TestRun_ProductionPrompt(t *testing.T) {
+
+	t.Setenv("LINEPAY_CHANNEL_ID", "1001")
+	t.Setenv("LINEPAY_CHANNEL_SECRET", "secret")
+	t.Setenv("LINEPAY_API_ENDPOINT", "")
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"void", "--production", "--transaction-id=1"}, strings.NewReader("no\n"), &stdout, &stderr)
+	if code != 1 || !strings.Contains(stderr.String(), "PRODUCTION") || !strings.Contains(stderr.String(), "aborted") {
+		t.Errorf("exit %d, stderr: %s", code, stderr.String())
+	}
+
+	// the flags are checked before asking
+	stderr.Reset()
+	code = run([]string{"void", "--production"}, strings.NewReader("yes\n"), &stdout, &stderr)
+	if code != 1 || strings.Contains(stderr.String(), "PRODUCTION") || !strings.Contains(stderr.String(), "--transaction-id is required") {
+		t.Errorf("missing flag on production exit %d, stderr: %s", code, stderr.String())
+	}
+
+	// the production host given as the API endpoint
+	stderr.Reset()
+	t.Setenv("LINEPAY_API_ENDPOINT", "https://api-pay.line.me/")
+	code = run([]string{"void", "--transaction-id=1"}, strings.NewReader("no\n"), &stdout, &stderr)
+	if code != 1 || !strings.Contains(stderr.String(), "PRODUCTION") || !strings.Contains(stderr.String(), "aborted") {
+		t.Errorf("API endpoint of production exit %d, stderr: %s", code, stderr.String())
+	}
+}
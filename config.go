@@ -0,0 +1,284 @@
+package linepay
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	EnvironmentSandbox    string = "sandbox"
+	EnvironmentProduction string = "production"
+)
+
+// Config of a Client, loaded by LoadConfig.
+// `Environment` sandbox (default) or production, ignored when `APIEndpoint` is set
+// `APIVersion` 3 (default) or 2 for a channel on the legacy v2 API
+// `LogLevel` a logrus level: debug, info, warn, error...
+type Config struct {
+	ChannelID     string   `json:"channelId" yaml:"channelId"`
+	ChannelSecret string   `json:"channelSecret" yaml:"channelSecret"`
+	Environment   string   `json:"environment" yaml:"environment"`
+	APIEndpoint   string   `json:"apiEndpoint" yaml:"apiEndpoint"`
+	APIVersion    int      `json:"apiVersion" yaml:"apiVersion"`
+	Timeout       Duration `json:"timeout" yaml:"timeout"`
+	RetryMax      int      `json:"retryMax" yaml:"retryMax"`
+	RetryBackoff  Duration `json:"retryBackoff" yaml:"retryBackoff"`
+	LogLevel      string   `json:"logLevel" yaml:"logLevel"`
+}
+
+// Duration is a time.Duration written as "10s", "1m30s" in config files
+type Duration time.Duration
+
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return fmt.Errorf("duration must be a string like \"10s\": %s", string(b))
+	}
+	return d.parse(s)
+}
+
+func (d *Duration) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	return d.parse(s)
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *Duration) parse(s string) error {
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// configKey is one setting of Config and its name in every source
+type configKey struct {
+	field string // name in config files
+	env   string
+	flag  string
+	usage string
+	set   func(c *Config, v string) error
+}
+
+var configKeys = []configKey{
+	{"channelId", "LINEPAY_CHANNEL_ID", "channel-id", "LINE Pay channel id", func(c *Config, v string) error { c.ChannelID = v; return nil }},
+	{"channelSecret", "LINEPAY_CHANNEL_SECRET", "channel-secret", "LINE Pay channel secret", func(c *Config, v string) error { c.ChannelSecret = v; return nil }},
+	{"environment", "LINEPAY_ENVIRONMENT", "environment", "sandbox or production", func(c *Config, v string) error { c.Environment = v; return nil }},
+	{"apiEndpoint", "LINEPAY_API_ENDPOINT", "api-endpoint", "custom API URL, overrides environment", func(c *Config, v string) error { c.APIEndpoint = v; return nil }},
+	{"apiVersion", "LINEPAY_API_VERSION", "api-version", "LINE Pay API version: 3 or 2", func(c *Config, v string) (err error) { c.APIVersion, err = strconv.Atoi(v); return }},
+	{"timeout", "LINEPAY_TIMEOUT", "timeout", "HTTP timeout, e.g. 10s", func(c *Config, v string) error { return c.Timeout.parse(v) }},
+	{"retryMax", "LINEPAY_RETRY_MAX", "retry-max", "max retries of a call", func(c *Config, v string) (err error) { c.RetryMax, err = strconv.Atoi(v); return }},
+	{"retryBackoff", "LINEPAY_RETRY_BACKOFF", "retry-backoff", "delay before the first retry, e.g. 500ms", func(c *Config, v string) error { return c.RetryBackoff.parse(v) }},
+	{"logLevel", "LINEPAY_LOG_LEVEL", "log-level", "log level: debug, info, warn, error", func(c *Config, v string) error { c.LogLevel = v; return nil }},
+}
+
+const (
+	envConfigFile  = "LINEPAY_CONFIG_FILE"
+	flagConfigFile = "linepay-config"
+)
+
+// RegisterConfigFlags defines the flags read by LoadConfig on `fs`:
+// --channel-id, --channel-secret, --environment, --api-endpoint, --api-version, --timeout, --retry-max, --retry-backoff, --log-level, --linepay-config
+func RegisterConfigFlags(fs *flag.FlagSet) {
+	for _, k := range configKeys {
+		fs.String(k.flag, "", k.usage+" (env "+k.env+")")
+	}
+	fs.String(flagConfigFile, "", "LINE Pay JSON or YAML config file (env "+envConfigFile+")")
+}
+
+// ConfigError lists every missing or invalid setting
+type ConfigError struct {
+	Missing []string
+	Invalid []string
+}
+
+func (e *ConfigError) Error() string {
+	var msg []string
+	if len(e.Missing) > 0 {
+		msg = append(msg, "missing "+strings.Join(e.Missing, ", "))
+	}
+	if len(e.Invalid) > 0 {
+		msg = append(msg, "invalid "+strings.Join(e.Invalid, ", "))
+	}
+	return "linepay config: " + strings.Join(msg, "; ")
+}
+
+// LoadConfig loads the config from, lowest priority first:
+// the config file (`--linepay-config` or `LINEPAY_CONFIG_FILE`, JSON or YAML by extension),
+// environment variables (`LINEPAY_CHANNEL_ID`...), then the flags of `fs` set on the command line.
+// `fs` may be nil, otherwise its flags must be defined by RegisterConfigFlags and parsed.
+// The result is validated, a *ConfigError tells which keys are missing or invalid.
+func LoadConfig(fs *flag.FlagSet) (*Config, error) {
+
+	cfg := &Config{}
+	flags := map[string]string{}
+	if fs != nil {
+		fs.Visit(func(f *flag.Flag) {
+			flags[f.Name] = f.Value.String()
+		})
+	}
+
+	file := os.Getenv(envConfigFile)
+	if v, ok := flags[flagConfigFile]; ok {
+		file = v
+	}
+	if file != "" {
+		if err := cfg.loadFile(file); err != nil {
+			return nil, err
+		}
+	}
+
+	cerr := &ConfigError{}
+	for _, k := range configKeys {
+		if v, ok := os.LookupEnv(k.env); ok {
+			if err := k.set(cfg, v); err != nil {
+				cerr.Invalid = append(cerr.Invalid, fmt.Sprintf("%s (%s)", k.env, err.Error()))
+			}
+		}
+		if v, ok := flags[k.flag]; ok {
+			if err := k.set(cfg, v); err != nil {
+				cerr.Invalid = append(cerr.Invalid, fmt.Sprintf("--%s (%s)", k.flag, err.Error()))
+			}
+		}
+	}
+	if len(cerr.Invalid) > 0 {
+		return nil, cerr
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func (cfg *Config) loadFile(file string) error {
+
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("linepay config: %s", err.Error())
+	}
+
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".json":
+		dec := json.NewDecoder(bytes.NewReader(b))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(cfg)
+	case ".yaml", ".yml":
+		err = yaml.UnmarshalStrict(b, cfg)
+	default:
+		return fmt.Errorf("linepay config: unsupported file type '%s', use .json, .yaml or .yml", file)
+	}
+
+	if err != nil {
+		return fmt.Errorf("linepay config: parse '%s': %s", file, err.Error())
+	}
+	return nil
+}
+
+// Validate checks the required keys and the values
+func (cfg *Config) Validate() error {
+
+	cerr := &ConfigError{}
+	key := func(field string) string {
+		for _, k := range configKeys {
+			if k.field == field {
+				return fmt.Sprintf("%s (%s, --%s)", k.env, k.field, k.flag)
+			}
+		}
+		return field
+	}
+
+	if cfg.ChannelID == "" {
+		cerr.Missing = append(cerr.Missing, key("channelId"))
+	}
+	if cfg.ChannelSecret == "" {
+		cerr.Missing = append(cerr.Missing, key("channelSecret"))
+	}
+
+	switch cfg.Environment {
+	case "", EnvironmentSandbox, EnvironmentProduction:
+	default:
+		cerr.Invalid = append(cerr.Invalid, key("environment")+": must be sandbox or production")
+	}
+	if cfg.APIEndpoint != "" {
+		if u, err := url.ParseRequestURI(cfg.APIEndpoint); err != nil || u.Host == "" {
+			cerr.Invalid = append(cerr.Invalid, key("apiEndpoint")+": must be an absolute URL")
+		}
+	}
+	switch cfg.APIVersion {
+	case 0, APIVersion2, APIVersion3:
+	default:
+		cerr.Invalid = append(cerr.Invalid, key("apiVersion")+": must be 3 or 2")
+	}
+	if cfg.Timeout < 0 {
+		cerr.Invalid = append(cerr.Invalid, key("timeout")+": must not be negative")
+	}
+	if cfg.RetryMax < 0 {
+		cerr.Invalid = append(cerr.Invalid, key("retryMax")+": must not be negative")
+	}
+	if cfg.RetryBackoff < 0 {
+		cerr.Invalid = append(cerr.Invalid, key("retryBackoff")+": must not be negative")
+	}
+	if cfg.LogLevel != "" {
+		if _, err := logrus.ParseLevel(cfg.LogLevel); err != nil {
+			cerr.Invalid = append(cerr.Invalid, key("logLevel")+": "+err.Error())
+		}
+	}
+
+	if len(cerr.Missing) > 0 || len(cerr.Invalid) > 0 {
+		return cerr
+	}
+	return nil
+}
+
+// ClientOpts converts the config, `Vault` and other code only options are left empty
+func (cfg *Config) ClientOpts() *ClientOpts {
+	return &ClientOpts{
+		ProductionEnabled: cfg.Environment == EnvironmentProduction,
+		APIEndpoint:       cfg.APIEndpoint,
+		APIVersion:        cfg.APIVersion,
+		Timeout:           time.Duration(cfg.Timeout),
+		Retry: RetryPolicy{
+			MaxRetries: cfg.RetryMax,
+			Backoff:    time.Duration(cfg.RetryBackoff),
+		},
+	}
+}
+
+// NewClientFromConfig validates the config and creates the client.
+// `LogLevel` is applied to the standard logrus logger used by the SDK.
+func NewClientFromConfig(cfg *Config) (*Client, error) {
+	if cfg == nil {
+		return nil, errors.New("linepay config: nil config")
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	if cfg.LogLevel != "" {
+		level, _ := logrus.ParseLevel(cfg.LogLevel)
+		logrus.SetLevel(level)
+	}
+
+	return NewClient(cfg.ChannelID, cfg.ChannelSecret, nil, cfg.ClientOpts())
+}
@@ -0,0 +1,80 @@
+package linepay
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoadConfig(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "linepay-config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "linepay.yaml")
+	ioutil.WriteFile(file, []byte("channelId: \"1001\"\nchannelSecret: from-file\nenvironment: production\ntimeout: 5s\nretryMax: 2\n"), 0600)
+
+	t.Setenv("LINEPAY_CONFIG_FILE", file)
+	t.Setenv("LINEPAY_CHANNEL_SECRET", "from-env")
+	t.Setenv("LINEPAY_RETRY_BACKOFF", "200ms")
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	RegisterConfigFlags(fs)
+	fs.Parse([]string{"--environment=sandbox", "--log-level=warn"})
+
+	cfg, err := LoadConfig(fs)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	want := Config{
+		ChannelID:     "1001",
+		ChannelSecret: "from-env",
+		Environment:   EnvironmentSandbox,
+		Timeout:       Duration(5 * time.Second),
+		RetryMax:      2,
+		RetryBackoff:  Duration(200 * time.Millisecond),
+		LogLevel:      "warn",
+	}
+	if *cfg != want {
+		t.Errorf("LoadConfig() = %+v, want %+v", *cfg, want)
+	}
+
+	client, err := NewClientFromConfig(cfg)
+	if err != nil {
+		t.Fatalf("NewClientFromConfig() error = %v", err)
+	}
+	if client.apiEndpoint.String() != APIHostSandbox || client.retry.MaxRetries != 2 || client.httpClient.Timeout != 5*time.Second {
+		t.Errorf("client not configured: %s %+v %s", client.apiEndpoint, client.retry, client.httpClient.Timeout)
+	}
+}
+
+func TestLoadConfig_Missing(t *testing.T) {
+
+	t.Setenv("LINEPAY_CONFIG_FILE", "")
+	t.Setenv("LINEPAY_CHANNEL_ID", "")
+	os.Unsetenv("LINEPAY_CHANNEL_ID")
+	t.Setenv("LINEPAY_CHANNEL_SECRET", "")
+	os.Unsetenv("LINEPAY_CHANNEL_SECRET")
+	t.Setenv("LINEPAY_ENVIRONMENT", "staging")
+
+	_, err := LoadConfig(nil)
+	cerr, ok := err.(*ConfigError)
+	if !ok {
+		t.Fatalf("LoadConfig() error = %v, want *ConfigError", err)
+	}
+
+	if len(cerr.Missing) != 2 || !strings.Contains(cerr.Missing[0], "LINEPAY_CHANNEL_ID") || !strings.Contains(cerr.Missing[1], "LINEPAY_CHANNEL_SECRET") {
+		t.Errorf("missing = %v", cerr.Missing)
+	}
+	if len(cerr.Invalid) != 1 || !strings.Contains(cerr.Invalid[0], "LINEPAY_ENVIRONMENT") {
+		t.Errorf("invalid = %v", cerr.Invalid)
+	}
+}
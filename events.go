@@ -0,0 +1,126 @@
+package linepay
+
+import (
+	"context"
+	"time"
+)
+
+// EventType of the payment events
+type EventType string
+
+const (
+	EventPaymentRequested EventType = "payment.requested"
+	EventPaymentConfirmed EventType = "payment.confirmed" // paid, or authorized when `Captured` is false
+	EventPaymentCaptured  EventType = "payment.captured"
+	EventPaymentVoided    EventType = "payment.voided"
+	EventPaymentRefunded  EventType = "payment.refunded"
+	EventPaymentExpired   EventType = "payment.expired" // authorization expired, seen by PaymentsDetails
+)
+
+// Event is a payment event published by the Client, one of the `Payment*` event structs.
+// The response of the call is kept in a field ignored by `json.Marshal`, e.g. `PaymentCaptured.Response`,
+// package `webhook` sends it to other services.
+type Event interface {
+	Header() *EventHeader
+}
+
+// EventHeader is common to every event.
+// `ID` is derived from the transaction, e.g. `payment.captured:<transactionId>`, the same movement seen twice
+// (e.g. an expiry seen by several PaymentsDetails) has the same ID so subscribers can ignore duplicates.
+type EventHeader struct {
+	ID         string    `json:"id"`
+	Type       EventType `json:"type"`
+	ChannelID  string    `json:"channelId"`
+	OccurredAt time.Time `json:"occurredAt"`
+}
+
+func (h *EventHeader) Header() *EventHeader {
+	return h
+}
+
+// Publisher receives the events of the Client, see package `events`.
+// Publish is called after the API call succeeded, it must not fail the call.
+type Publisher interface {
+	Publish(ctx context.Context, event Event)
+}
+
+type PaymentRequested struct {
+	EventHeader
+	OrderID       string        `json:"orderId"`
+	TransactionID TransactionID `json:"transactionId"`
+	Amount        int           `json:"amount"`
+	Currency      string        `json:"currency"`
+}
+
+type EventPayInfo struct {
+	Method string `json:"method"`
+	Amount int    `json:"amount"`
+}
+
+// PaymentConfirmed by `Confirm API` or a preapproved payment, `AuthorizationExpireDate` is set when not `Captured`.
+// `Response` is nil for a preapproved payment.
+type PaymentConfirmed struct {
+	EventHeader
+	OrderID                 string         `json:"orderId"`
+	TransactionID           TransactionID  `json:"transactionId"`
+	Amount                  int            `json:"amount"`
+	Currency                string         `json:"currency"`
+	Captured                bool           `json:"captured"`
+	AuthorizationExpireDate time.Time      `json:"authorizationExpireDate"`
+	PayInfo                 []EventPayInfo `json:"payInfo,omitempty"`
+
+	Response *PaymentsConfirmResponse `json:"-"`
+}
+
+type PaymentCaptured struct {
+	EventHeader
+	OrderID       string        `json:"orderId"`
+	TransactionID TransactionID `json:"transactionId"`
+	Amount        int           `json:"amount"`
+	Currency      string        `json:"currency"`
+
+	Response *PaymentsCaptureResponse `json:"-"`
+}
+
+type PaymentVoided struct {
+	EventHeader
+	TransactionID TransactionID `json:"transactionId"`
+}
+
+// PaymentRefunded `RefundAmount` 0 is a refund of the full amount
+type PaymentRefunded struct {
+	EventHeader
+	TransactionID       TransactionID `json:"transactionId"`
+	RefundTransactionID TransactionID `json:"refundTransactionId"`
+	RefundAmount        int           `json:"refundAmount"`
+
+	Response *PaymentsRefundResponse `json:"-"`
+}
+
+// PaymentExpired `Info` is the expired transaction in the PaymentsDetails response
+type PaymentExpired struct {
+	EventHeader
+	OrderID                 string        `json:"orderId"`
+	TransactionID           TransactionID `json:"transactionId"`
+	AuthorizationExpireDate time.Time     `json:"authorizationExpireDate"`
+
+	Info *PaymentsDetailsInfoResponse `json:"-"`
+}
+
+// publish sends the event when the call succeeded and a Publisher is set
+func (client *Client) publish(ctx context.Context, returnCode string, event Event) {
+
+	if client.events == nil || returnCode != ApiReturnCodeSuccess {
+		return
+	}
+
+	h := event.Header()
+	h.ChannelID = client.channelID
+	h.OccurredAt = time.Now()
+
+	client.events.Publish(ctx, event)
+}
+
+func header(t EventType, id TransactionID) EventHeader {
+	return EventHeader{ID: string(t) + ":" + id.String(), Type: t}
+}
@@ -0,0 +1,226 @@
+package events
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	linepay "github.com/chy168/line-pay-sdk-go"
+	"github.com/sirupsen/logrus"
+)
+
+// Handler processes an event, an error makes the Bus deliver the event again
+type Handler interface {
+	Handle(ctx context.Context, event linepay.Event) error
+}
+
+// HandlerFunc adapts a function to Handler
+type HandlerFunc func(ctx context.Context, event linepay.Event) error
+
+func (f HandlerFunc) Handle(ctx context.Context, event linepay.Event) error {
+	return f(ctx, event)
+}
+
+// DefaultQueueSize is the number of events an async subscriber buffers when `SubscribeOptions.QueueSize` is 0
+const DefaultQueueSize = 100
+
+// DefaultTimeout limits an attempt of an async delivery when `SubscribeOptions.Timeout` is 0
+const DefaultTimeout = 30 * time.Second
+
+// DefaultBackoff is the delay before the second attempt when `SubscribeOptions.Backoff` is 0
+const DefaultBackoff = time.Second
+
+// SubscribeOptions of a subscriber.
+// `Types` optional, the events delivered to the subscriber, every event when empty
+// `Async` delivers from a goroutine of the subscriber, Publish does not wait for the handler. The context of an async
+// delivery is not the one of the API call, which may be over.
+// `QueueSize` optional, the async buffer, Publish blocks when it is full
+// `MaxAttempts` optional, deliveries of an event until the handler succeeds, 1 when 0
+// `Backoff` optional, the delay before the second attempt, doubled for every attempt, DefaultBackoff when 0
+// `Timeout` optional, limits every attempt, DefaultTimeout for an async delivery when 0
+// `DeadLetter` optional, receives the event and the last error when every attempt failed
+type SubscribeOptions struct {
+	Types       []linepay.EventType
+	Async       bool
+	QueueSize   int
+	MaxAttempts int
+	Backoff     time.Duration
+	Timeout     time.Duration
+	DeadLetter  func(event linepay.Event, err error)
+}
+
+type subscriber struct {
+	handler Handler
+	opts    SubscribeOptions
+	types   map[linepay.EventType]bool
+	queue   chan linepay.Event
+}
+
+func (s *subscriber) wants(t linepay.EventType) bool {
+	return len(s.types) == 0 || s.types[t]
+}
+
+// deliver calls the handler until it succeeds, at least once
+func (s *subscriber) deliver(ctx context.Context, event linepay.Event) {
+
+	backoff := s.opts.Backoff
+	for attempt := 1; ; attempt++ {
+		err := s.handle(ctx, event)
+		if err == nil {
+			return
+		}
+
+		if attempt >= s.opts.MaxAttempts {
+			logrus.Warnf("event '%s' delivery failed after %d attempts: %s", event.Header().ID, attempt, err.Error())
+			if s.opts.DeadLetter != nil {
+				s.opts.DeadLetter(event, err)
+			}
+			return
+		}
+
+		t := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			if s.opts.DeadLetter != nil {
+				s.opts.DeadLetter(event, ctx.Err())
+			}
+			return
+		case <-t.C:
+		}
+		backoff *= 2
+	}
+}
+
+// handle is an attempt, limited by the timeout of the subscriber
+func (s *subscriber) handle(ctx context.Context, event linepay.Event) error {
+	if s.opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.opts.Timeout)
+		defer cancel()
+	}
+	return s.handler.Handle(ctx, event)
+}
+
+// Bus is an in-process linepay.Publisher delivering the events to its subscribers,
+// set it as `ClientOpts.Events`
+type Bus struct {
+	mu          sync.RWMutex
+	subscribers []*subscriber
+	wg          sync.WaitGroup
+	closed      bool
+
+	// publishing counts the Publish calls in progress, the queues are closed after them.
+	// done is closed by Close, it unblocks the Publish calls waiting for a full queue.
+	publishing sync.WaitGroup
+	done       chan struct{}
+}
+
+var _ linepay.Publisher = (*Bus)(nil)
+
+func New() *Bus {
+	return &Bus{}
+}
+
+// Subscribe adds a subscriber, `opts` may be nil for a synchronous delivery without retry
+func (b *Bus) Subscribe(handler Handler, opts *SubscribeOptions) {
+
+	if opts == nil {
+		opts = &SubscribeOptions{}
+	}
+
+	s := &subscriber{handler: handler, opts: *opts, types: map[linepay.EventType]bool{}}
+	if s.opts.MaxAttempts < 1 {
+		s.opts.MaxAttempts = 1
+	}
+	if s.opts.Backoff <= 0 {
+		s.opts.Backoff = DefaultBackoff
+	}
+	for _, t := range opts.Types {
+		s.types[t] = true
+	}
+
+	if s.opts.Async {
+		if s.opts.Timeout <= 0 {
+			s.opts.Timeout = DefaultTimeout
+		}
+		size := s.opts.QueueSize
+		if size <= 0 {
+			size = DefaultQueueSize
+		}
+		s.queue = make(chan linepay.Event, size)
+
+		b.wg.Add(1)
+		go func() {
+			defer b.wg.Done()
+			for event := range s.queue {
+				s.deliver(context.Background(), event)
+			}
+		}()
+	}
+
+	b.mu.Lock()
+	if b.done == nil {
+		b.done = make(chan struct{})
+	}
+	b.subscribers = append(b.subscribers, s)
+	b.mu.Unlock()
+}
+
+// Publish delivers the event to the synchronous subscribers in order, then queues it for the async ones.
+// Events published after Close, or waiting for a full queue when Close is called, are dropped.
+func (b *Bus) Publish(ctx context.Context, event linepay.Event) {
+
+	b.mu.RLock()
+	if b.closed {
+		b.mu.RUnlock()
+		logrus.Warnf("event '%s' published on a closed bus", event.Header().ID)
+		return
+	}
+	b.publishing.Add(1)
+	subscribers, done := b.subscribers, b.done
+	b.mu.RUnlock()
+	defer b.publishing.Done()
+
+	// not holding the lock, a full queue must not block Close
+	for _, s := range subscribers {
+		if !s.wants(event.Header().Type) {
+			continue
+		}
+		if s.queue == nil {
+			s.deliver(ctx, event)
+			continue
+		}
+		select {
+		case s.queue <- event:
+		case <-done:
+			logrus.Warnf("event '%s' dropped, the bus is closed", event.Header().ID)
+		}
+	}
+}
+
+// Close waits until the async subscribers processed their queued events
+func (b *Bus) Close() {
+
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return
+	}
+	b.closed = true
+	if b.done == nil {
+		b.done = make(chan struct{})
+	}
+	close(b.done)
+	b.mu.Unlock()
+
+	// no Publish sends to the queues anymore
+	b.publishing.Wait()
+	for _, s := range b.subscribers {
+		if s.queue != nil {
+			close(s.queue)
+		}
+	}
+
+	b.wg.Wait()
+}
@@ -0,0 +1,179 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	linepay "github.com/chy168/line-pay-sdk-go"
+	"github.com/chy168/line-pay-sdk-go/webhook"
+)
+
+func captured(id linepay.TransactionID) *linepay.PaymentCaptured {
+	return &linepay.PaymentCaptured{
+		EventHeader:   linepay.EventHeader{ID: "payment.captured:" + id.String(), Type: linepay.EventPaymentCaptured},
+		TransactionID: id,
+		Amount:        100,
+		Currency:      "TWD",
+	}
+}
+
+func TestBus(t *testing.T) {
+
+	ctx := context.Background()
+	bus := New()
+
+	var sync1 []string
+	bus.Subscribe(HandlerFunc(func(ctx context.Context, event linepay.Event) error {
+		sync1 = append(sync1, event.Header().ID)
+		return nil
+	}), &SubscribeOptions{Types: []linepay.EventType{linepay.EventPaymentCaptured}})
+
+	// async, fails twice for every event then succeeds, the third event is given up
+	var mu sync.Mutex
+	attempts := map[string]int{}
+	var dead []string
+	bus.Subscribe(HandlerFunc(func(ctx context.Context, event linepay.Event) error {
+		mu.Lock()
+		defer mu.Unlock()
+		attempts[event.Header().ID]++
+		if event.Header().ID == "payment.voided:3" || attempts[event.Header().ID] < 3 {
+			return errors.New("crm unavailable")
+		}
+		return nil
+	}), &SubscribeOptions{Async: true, MaxAttempts: 3, Backoff: time.Millisecond, DeadLetter: func(event linepay.Event, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		dead = append(dead, event.Header().ID)
+	}})
+
+	bus.Publish(ctx, captured(1))
+	bus.Publish(ctx, captured(2))
+	bus.Publish(ctx, &linepay.PaymentVoided{EventHeader: linepay.EventHeader{ID: "payment.voided:3", Type: linepay.EventPaymentVoided}, TransactionID: 3})
+	bus.Close()
+	bus.Publish(ctx, captured(4)) // dropped
+
+	if strings.Join(sync1, ",") != "payment.captured:1,payment.captured:2" {
+		t.Errorf("sync subscriber got %v", sync1)
+	}
+	if attempts["payment.captured:1"] != 3 || attempts["payment.voided:3"] != 3 || len(attempts) != 3 {
+		t.Errorf("async attempts = %v", attempts)
+	}
+	if len(dead) != 1 || dead[0] != "payment.voided:3" {
+		t.Errorf("dead letters = %v", dead)
+	}
+}
+
+func TestWebhook(t *testing.T) {
+
+	var calls int
+	var got *linepay.PaymentCaptured
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		payload, err := webhook.Verify(r, "hook-secret", 0)
+		if err != nil || r.Header.Get(webhook.HeaderEventType) != "payment.captured" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		event, _ := payload.DecodeEvent()
+		got, _ = event.(*linepay.PaymentCaptured)
+	}))
+	defer ts.Close()
+
+	bus := New()
+	bus.Subscribe(&Webhook{URL: ts.URL, Secret: "hook-secret"}, &SubscribeOptions{MaxAttempts: 2})
+	bus.Publish(context.Background(), captured(2019049910005496810))
+
+	if calls != 2 || got == nil || got.ID != "payment.captured:2019049910005496810" || got.TransactionID != 2019049910005496810 || got.Amount != 100 {
+		t.Errorf("webhook got %+v after %d calls", got, calls)
+	}
+
+	if err := (&Webhook{URL: ts.URL, Secret: "other"}).Handle(context.Background(), captured(1)); err == nil {
+		t.Errorf("Handle() with a wrong secret no error")
+	}
+}
+
+func TestAsyncTimeout(t *testing.T) {
+
+	dead := make(chan error, 1)
+	bus := New()
+	bus.Subscribe(HandlerFunc(func(ctx context.Context, event linepay.Event) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}), &SubscribeOptions{Async: true, Timeout: 20 * time.Millisecond, DeadLetter: func(event linepay.Event, err error) { dead <- err }})
+	bus.Publish(context.Background(), captured(1))
+
+	select {
+	case err := <-dead:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("dead letter error = %v, want DeadlineExceeded", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("a hanging handler blocked the async delivery")
+	}
+	bus.Close()
+}
+
+// a Publish waiting for a full queue does not block Close
+func TestCloseFullQueue(t *testing.T) {
+
+	release := make(chan struct{})
+	bus := New()
+	bus.Subscribe(HandlerFunc(func(ctx context.Context, event linepay.Event) error {
+		<-release
+		return nil
+	}), &SubscribeOptions{Async: true, QueueSize: 1})
+	if bus.subscribers[0].opts.Backoff != DefaultBackoff {
+		t.Errorf("backoff = %v, want DefaultBackoff", bus.subscribers[0].opts.Backoff)
+	}
+
+	// the first event is handled, the second queued, the third waits
+	bus.Publish(context.Background(), captured(1))
+	bus.Publish(context.Background(), captured(2))
+	published := make(chan struct{})
+	go func() {
+		bus.Publish(context.Background(), captured(3))
+		close(published)
+	}()
+
+	closed := make(chan struct{})
+	go func() {
+		bus.Close()
+		close(closed)
+	}()
+	select {
+	case <-published:
+	case <-time.After(time.Second):
+		t.Fatal("Publish on a full queue blocked Close")
+	}
+
+	close(release)
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("Close did not return")
+	}
+}
+
+func TestJSONLines(t *testing.T) {
+
+	var b bytes.Buffer
+	sink := NewJSONLines(&b)
+	sink.Handle(context.Background(), captured(1))
+	sink.Handle(context.Background(), captured(2))
+
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], `{"id":"payment.captured:1","type":"payment.captured",`) {
+		t.Errorf("JSONLines wrote %s", b.String())
+	}
+}
@@ -0,0 +1,85 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+
+	linepay "github.com/chy168/line-pay-sdk-go"
+	"github.com/chy168/line-pay-sdk-go/webhook"
+)
+
+// Webhook posts every event to `URL` as a `webhook.Payload`, signed with `Secret` (your own secret, not the channel
+// secret) like the deliveries of `webhook.Dispatcher`: the receiver checks it with `webhook.Verify`.
+// A response other than 2xx is an error, so the event is delivered again.
+// `Client` optional, a client with `webhook.DefaultTimeout` when nil
+type Webhook struct {
+	URL    string
+	Secret string
+	Client *http.Client
+}
+
+func (w *Webhook) Handle(ctx context.Context, event linepay.Event) error {
+
+	payload, err := webhook.NewPayload(event)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("webhook marshal payload error: %s", err.Error())
+	}
+
+	return webhook.Post(ctx, w.Client, w.URL, w.Secret, &webhook.Delivery{
+		ID:        payload.ID,
+		EventID:   payload.ID,
+		EventType: payload.Type,
+		Body:      body,
+	})
+}
+
+// JSONLines writes every event as a line of JSON
+type JSONLines struct {
+	mu     sync.Mutex
+	w      io.Writer
+	closer io.Closer
+}
+
+func NewJSONLines(w io.Writer) *JSONLines {
+	return &JSONLines{w: w}
+}
+
+// OpenJSONLines appends the events to the file at `path`, created when it does not exist
+func OpenJSONLines(path string) (*JSONLines, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, err
+	}
+	return &JSONLines{w: f, closer: f}, nil
+}
+
+func (j *JSONLines) Handle(ctx context.Context, event linepay.Event) error {
+
+	line, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	_, err = j.w.Write(append(line, '\n'))
+	return err
+}
+
+// Close closes the file opened by OpenJSONLines
+func (j *JSONLines) Close() error {
+	if j.closer == nil {
+		return nil
+	}
+	return j.closer.Close()
+}
@@ -0,0 +1,89 @@
+package linepay
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type recordingPublisher struct {
+	events []Event
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, event Event) {
+	p.events = append(p.events, event)
+}
+
+func TestClient_Events(t *testing.T) {
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/confirm"):
+			fmt.Fprint(w, `{"returnCode":"0000","info":{"orderId":"o1","transactionId":2019049910005496810,"payInfo":[{"method":"BALANCE","amount":100}]}}`)
+		case strings.HasSuffix(r.URL.Path, "/refund"):
+			fmt.Fprint(w, `{"returnCode":"0000","info":{"refundTransactionId":2019049910005496811}}`)
+		default:
+			fmt.Fprint(w, `{"returnCode":"1150","returnMessage":"Transaction record not found."}`)
+		}
+	}))
+	defer ts.Close()
+
+	events := &recordingPublisher{}
+	client, _ := NewClient("1001", "secret", nil, &ClientOpts{APIEndpoint: ts.URL, Events: events})
+	ctx := context.Background()
+
+	confirm, _ := client.PaymentsConfirm(ctx, 2019049910005496810, &PaymentsConfirmRequest{Amount: 100, Currency: "TWD"})
+	client.PaymentsRefund(ctx, 2019049910005496810, &PaymentsRefundRequest{RefundAmount: 40})
+	client.PaymentsVoid(ctx, 2019049910005496810) // failed, no event
+
+	if len(events.events) != 2 {
+		t.Fatalf("events = %+v", events.events)
+	}
+
+	confirmed, ok := events.events[0].(*PaymentConfirmed)
+	if !ok || confirmed.ID != "payment.confirmed:2019049910005496810" || confirmed.ChannelID != "1001" || confirmed.OccurredAt.IsZero() ||
+		confirmed.OrderID != "o1" || !confirmed.Captured || confirmed.Amount != 100 || len(confirmed.PayInfo) != 1 || confirmed.Response != confirm {
+		t.Errorf("events[0] = %+v", events.events[0])
+	}
+
+	refunded, ok := events.events[1].(*PaymentRefunded)
+	if !ok || refunded.Type != EventPaymentRefunded || refunded.RefundTransactionID != 2019049910005496811 || refunded.RefundAmount != 40 {
+		t.Errorf("events[1] = %+v", events.events[1])
+	}
+}
+
+// a nil request is sent without body, its event has no amount
+func TestClient_EventsNilRequest(t *testing.T) {
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"returnCode":"0000","info":{"orderId":"o1","transactionId":2019049910005496810}}`)
+	}))
+	defer ts.Close()
+
+	events := &recordingPublisher{}
+	client, _ := NewClient("1001", "secret", nil, &ClientOpts{APIEndpoint: ts.URL, Events: events})
+	ctx := context.Background()
+
+	if _, err := client.PaymentsRequest(ctx, nil); err != nil {
+		t.Errorf("PaymentsRequest(nil) error = %v", err)
+	}
+	if _, err := client.PaymentsConfirm(ctx, 2019049910005496810, nil); err != nil {
+		t.Errorf("PaymentsConfirm(nil) error = %v", err)
+	}
+	if _, err := client.PaymentsCapture(ctx, 2019049910005496810, nil); err != nil {
+		t.Errorf("PaymentsCapture(nil) error = %v", err)
+	}
+	if _, err := client.PaymentsPreapproved(ctx, "RK9A7B6C5D4E3F2", nil); err != nil {
+		t.Errorf("PaymentsPreapproved(nil) error = %v", err)
+	}
+
+	if len(events.events) != 4 {
+		t.Fatalf("events = %+v", events.events)
+	}
+	if captured, ok := events.events[2].(*PaymentCaptured); !ok || captured.OrderID != "o1" || captured.Amount != 0 {
+		t.Errorf("events[2] = %+v", events.events[2])
+	}
+}
@@ -7,26 +7,26 @@ import (
 	"html"
 	"log"
 	"net/http"
-	"strconv"
 
 	linepay "github.com/chy168/line-pay-sdk-go"
 	"github.com/sirupsen/logrus"
 )
 
-var (
-	ChannelID     *string = flag.String("channel-id", "", "Channel ID")
-	ChannelSecret *string = flag.String("channel-secret", "", "Channel ID")
-)
-
 func main() {
 
+	linepay.RegisterConfigFlags(flag.CommandLine)
 	flag.Parse()
 
+	config, err := linepay.LoadConfig(flag.CommandLine)
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	http.HandleFunc("/confirm", func(w http.ResponseWriter, r *http.Request) {
 		fmt.Fprintf(w, "Hello: %q", html.EscapeString(r.URL.Path))
 
 		transactionStringID := r.URL.Query().Get("transactionId")
-		transactionID, err := strconv.ParseInt(transactionStringID, 10, 64)
+		transactionID, err := linepay.ParseTransactionID(transactionStringID)
 		if err != nil {
 			logrus.Errorf("transactionId is nil, err: %s", err.Error())
 			return
@@ -34,9 +34,9 @@ func main() {
 
 		orderID := r.URL.Query().Get("orderId")
 
-		logrus.Infof("txid: '%d', orderid: '%s'", transactionID, orderID)
+		logrus.Infof("txid: '%s', orderid: '%s'", transactionID, orderID)
 
-		client, err := linepay.NewClient(*ChannelID, *ChannelSecret, &linepay.Signer{ChannelId: *ChannelID}, &linepay.ClientOpts{})
+		client, err := linepay.NewClientFromConfig(config)
 		if err != nil {
 			logrus.Errorf("init linepay client error: %s", err.Error())
 			return
@@ -45,7 +45,7 @@ func main() {
 		// Get Detail
 
 		dataDetail1 := linepay.PaymentsDetailsRequest{
-			TransactionIDs: []int64{transactionID},
+			TransactionIDs: []linepay.TransactionID{transactionID},
 			// OrderIDs:       []string{"order_0be9807d-88cf-42fe-bf69-75a51f1ad83f", "order_9583d466-6c47-488b-813f-894c0a26d7e8", "order_d776f2dd-eb7a-4611-b8cc-53242b9d7e71"},
 			Fields: linepay.PaymentsDetailsFieldsTransaction,
 		}
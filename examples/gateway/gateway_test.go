@@ -0,0 +1,355 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	linepay "github.com/chy168/line-pay-sdk-go"
+	"gopkg.in/yaml.v2"
+)
+
+// fakeLinePay keeps the payments of the v3 API in memory
+type fakeLinePay struct {
+	mu       sync.Mutex
+	next     linepay.TransactionID
+	orders   map[string]linepay.TransactionID
+	payments map[linepay.TransactionID]*fakePayment
+	requests int
+}
+
+type fakePayment struct {
+	orderID  string
+	amount   int
+	capture  bool
+	status   string // AUTHORIZATION, CAPTURE, VOIDED_AUTHORIZATION, or empty before the confirm
+	refunded int
+}
+
+func newFakeLinePay() *fakeLinePay {
+	return &fakeLinePay{next: 2019049910005496810, orders: map[string]linepay.TransactionID{}, payments: map[linepay.TransactionID]*fakePayment{}}
+}
+
+func (f *fakeLinePay) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.Header.Get("X-LINE-Authorization") == "" {
+		fmt.Fprint(w, `{"returnCode":"1106","returnMessage":"Header information error."}`)
+		return
+	}
+
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/v3/payments"), "/")
+	var payment *fakePayment
+	var id linepay.TransactionID
+	for _, part := range parts {
+		if tx, err := linepay.ParseTransactionID(part); err == nil {
+			id, payment = tx, f.payments[tx]
+		}
+	}
+
+	switch {
+	case r.URL.Path == "/v3/payments/request":
+		f.requests++
+		req := linepay.PaymentsRequest{}
+		json.NewDecoder(r.Body).Decode(&req)
+		if _, ok := f.orders[req.OrderID]; ok {
+			fmt.Fprint(w, `{"returnCode":"1172","returnMessage":"Existing same orderId."}`)
+			return
+		}
+		f.next++
+		f.orders[req.OrderID] = f.next
+		f.payments[f.next] = &fakePayment{orderID: req.OrderID, amount: req.Amount, capture: req.Options.Payment.Capture}
+		fmt.Fprintf(w, `{"returnCode":"0000","info":{"transactionId":%s,"paymentAccessToken":"187568751124","paymentUrl":{"web":"https://sandbox-web-pay.line.me/web/payment/wait?transactionReserveId=%s"}}}`, f.next, f.next)
+
+	case r.URL.Path == "/v3/payments" && r.Method == http.MethodGet:
+		tx, ok := f.orders[r.URL.Query().Get("orderId")]
+		if !ok || f.payments[tx].status == "" {
+			fmt.Fprint(w, `{"returnCode":"1150","returnMessage":"Transaction record not found."}`)
+			return
+		}
+		p := f.payments[tx]
+		fmt.Fprintf(w, `{"returnCode":"0000","info":[{"transactionId":%s,"orderId":"%s","transactionType":"PAYMENT","payStatus":"%s","payInfo":[{"method":"BALANCE","amount":%d}]}]}`,
+			tx, p.orderID, p.status, p.amount-p.refunded)
+
+	case payment == nil:
+		fmt.Fprint(w, `{"returnCode":"1150","returnMessage":"Transaction record not found."}`)
+
+	case strings.HasSuffix(r.URL.Path, "/check"):
+		code := "0000"
+		if payment.status != "" {
+			code = "0123"
+		}
+		fmt.Fprintf(w, `{"returnCode":"%s","returnMessage":"ok"}`, code)
+
+	case strings.HasSuffix(r.URL.Path, "/confirm"):
+		req := linepay.PaymentsConfirmRequest{}
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Amount != payment.amount || payment.status != "" {
+			fmt.Fprint(w, `{"returnCode":"1124","returnMessage":"Amount info error."}`)
+			return
+		}
+		payment.status = "CAPTURE"
+		if !payment.capture {
+			payment.status = "AUTHORIZATION"
+		}
+		fmt.Fprintf(w, `{"returnCode":"0000","info":{"orderId":"%s","transactionId":%s,"payInfo":[{"method":"BALANCE","amount":%d}]}}`, payment.orderID, id, payment.amount)
+
+	case strings.HasSuffix(r.URL.Path, "/capture"), strings.HasSuffix(r.URL.Path, "/void"):
+		if payment.status != "AUTHORIZATION" {
+			fmt.Fprint(w, `{"returnCode":"1150","returnMessage":"Transaction record not found."}`)
+			return
+		}
+		payment.status = "CAPTURE"
+		if strings.HasSuffix(r.URL.Path, "/void") {
+			payment.status = "VOIDED_AUTHORIZATION"
+		}
+		fmt.Fprintf(w, `{"returnCode":"0000","info":{"orderId":"%s","transactionId":%s}}`, payment.orderID, id)
+
+	case strings.HasSuffix(r.URL.Path, "/refund"):
+		req := linepay.PaymentsRefundRequest{}
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.RefundAmount == 0 {
+			req.RefundAmount = payment.amount - payment.refunded
+		}
+		if payment.status != "CAPTURE" || payment.refunded+req.RefundAmount > payment.amount {
+			fmt.Fprint(w, `{"returnCode":"1165","returnMessage":"Transaction already refunded."}`)
+			return
+		}
+		payment.refunded += req.RefundAmount
+		fmt.Fprintf(w, `{"returnCode":"0000","info":{"refundTransactionId":%s}}`, id+1000)
+
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+type gateway struct {
+	t   *testing.T
+	url string
+}
+
+// do sends the request with the API key and an optional Idempotency-Key, and decodes the response into `out`
+func (g *gateway) do(method, path, idempotencyKey, body string, out interface{}) *http.Response {
+
+	req, _ := http.NewRequest(method, g.url+path, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer key-1")
+	if idempotencyKey != "" {
+		req.Header.Set("Idempotency-Key", idempotencyKey)
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		g.t.Fatalf("%s %s error: %s", method, path, err.Error())
+	}
+	defer res.Body.Close()
+
+	var b bytes.Buffer
+	b.ReadFrom(res.Body)
+	if out != nil {
+		if err := json.Unmarshal(b.Bytes(), out); err != nil {
+			g.t.Fatalf("%s %s response %s: %s", method, path, b.String(), err.Error())
+		}
+	}
+	return res
+}
+
+func TestGateway(t *testing.T) {
+
+	ctx := context.Background()
+	fake := newFakeLinePay()
+	linePayServer := httptest.NewServer(fake)
+	defer linePayServer.Close()
+
+	client, err := linepay.NewClient("1001", "secret", nil, &linepay.ClientOpts{APIEndpoint: linePayServer.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := openFileStore(filepath.Join(t.TempDir(), "gateway.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewServer(newServer(client, s, []string{"key-1", "key-2"}))
+	defer ts.Close()
+	g := &gateway{t: t, url: ts.URL}
+
+	create := `{"orderId":"order-1","amount":100,"currency":"TWD","productName":"coffee","confirmUrl":"https://shop.example.com/confirm","cancelUrl":"https://shop.example.com/cancel"}`
+
+	// authentication
+	res, err := http.Post(ts.URL+"/v1/payments", "application/json", strings.NewReader(create))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusUnauthorized {
+		t.Errorf("POST without API key = %d", res.StatusCode)
+	}
+
+	// create, replayed by its Idempotency-Key
+	var created, replayed order
+	if res := g.do(http.MethodPost, "/v1/payments", "create-1", create, &created); res.StatusCode != http.StatusCreated ||
+		created.Status != statusRequested || created.TransactionID != 2019049910005496811 || created.PaymentURL.Web == "" {
+		t.Fatalf("create = %d %+v", res.StatusCode, created)
+	}
+	if res := g.do(http.MethodPost, "/v1/payments", "create-1", create, &replayed); res.StatusCode != http.StatusCreated ||
+		res.Header.Get("Idempotent-Replayed") != "true" || replayed.TransactionID != created.TransactionID || fake.requests != 1 {
+		t.Errorf("create replay = %d %+v, LINE Pay requests %d", res.StatusCode, replayed, fake.requests)
+	}
+	var failed apiError
+	if res := g.do(http.MethodPost, "/v1/payments", "create-1", strings.Replace(create, "100", "200", 1), &failed); res.StatusCode != http.StatusUnprocessableEntity ||
+		failed.Error.Code != "idempotency_mismatch" {
+		t.Errorf("create with a reused key = %d %+v", res.StatusCode, failed)
+	}
+	if res := g.do(http.MethodPost, "/v1/payments", "", create, &failed); res.StatusCode != http.StatusConflict || failed.Error.Code != "duplicate_order" {
+		t.Errorf("create of an existing order = %d %+v", res.StatusCode, failed)
+	}
+
+	// status before and after the confirm, then a full refund in two parts
+	var status statusResponse
+	if g.do(http.MethodGet, "/v1/payments/order-1/status", "", "", &status); status.Status != "PENDING" {
+		t.Errorf("status = %+v", status)
+	}
+	var o order
+	if res := g.do(http.MethodPost, "/v1/payments/order-1/confirm", "confirm-1", "", &o); res.StatusCode != http.StatusOK || o.Status != statusCaptured {
+		t.Errorf("confirm = %d %+v", res.StatusCode, o)
+	}
+	if g.do(http.MethodGet, "/v1/payments/order-1/status", "", "", &status); status.Status != "COMPLETED" {
+		t.Errorf("status after confirm = %+v", status)
+	}
+	if res := g.do(http.MethodPost, "/v1/payments/order-1/void", "", "", &failed); res.StatusCode != http.StatusConflict || failed.Error.Code != "invalid_state" {
+		t.Errorf("void of a captured order = %d %+v", res.StatusCode, failed)
+	}
+	if res := g.do(http.MethodPost, "/v1/payments/order-1/refund", "refund-1", `{"amount":40}`, &o); res.StatusCode != http.StatusOK || o.Refunded != 40 || o.Status != statusCaptured {
+		t.Errorf("partial refund = %d %+v", res.StatusCode, o)
+	}
+	if res := g.do(http.MethodPost, "/v1/payments/order-1/refund", "", `{"amount":70}`, &failed); res.StatusCode != http.StatusConflict {
+		t.Errorf("refund over the amount = %d %+v", res.StatusCode, failed)
+	}
+	if res := g.do(http.MethodPost, "/v1/payments/order-1/refund", "", "", &o); res.StatusCode != http.StatusOK || o.Refunded != 100 || o.Status != statusRefunded {
+		t.Errorf("refund of the rest = %d %+v", res.StatusCode, o)
+	}
+	var details detailsResponse
+	if res := g.do(http.MethodGet, "/v1/payments/order-1", "", "", &details); res.StatusCode != http.StatusOK || details.Order.Status != statusRefunded ||
+		len(details.LinePay) != 1 || details.LinePay[0].PayStatus != "CAPTURE" {
+		t.Errorf("details = %d %+v", res.StatusCode, details)
+	}
+	var raw struct {
+		Order   map[string]interface{}   `json:"order"`
+		LinePay []map[string]interface{} `json:"linePay"`
+	}
+	g.do(http.MethodGet, "/v1/payments/order-1", "", "", &raw)
+	if raw.Order["transactionId"] != "2019049910005496811" || len(raw.LinePay) != 1 || raw.LinePay[0]["transactionId"] != "2019049910005496811" {
+		t.Errorf("transaction ids of the details = %v %v, want strings", raw.Order["transactionId"], raw.LinePay)
+	}
+
+	// authorize, capture; authorize, void
+	for _, flow := range []struct{ orderID, action string }{{"order-2", "capture"}, {"order-3", "void"}} {
+		body := strings.Replace(strings.Replace(create, "order-1", flow.orderID, 1), `"cancelUrl"`, `"capture":false,"cancelUrl"`, 1)
+		g.do(http.MethodPost, "/v1/payments", "", body, &o)
+		if g.do(http.MethodPost, "/v1/payments/"+flow.orderID+"/confirm", "", "", &o); o.Status != statusAuthorized {
+			t.Errorf("%s confirm = %+v", flow.orderID, o)
+		}
+		if res := g.do(http.MethodPost, "/v1/payments/"+flow.orderID+"/"+flow.action, "", "", &o); res.StatusCode != http.StatusOK {
+			t.Errorf("%s %s = %d", flow.orderID, flow.action, res.StatusCode)
+		}
+	}
+	if o, _ := s.getOrder(ctx, "order-2"); o.Status != statusCaptured {
+		t.Errorf("order-2 = %+v", o)
+	}
+	if o, _ := s.getOrder(ctx, "order-3"); o.Status != statusVoided {
+		t.Errorf("order-3 = %+v", o)
+	}
+
+	// LINE Pay errors
+	var rejected apiError
+	if res := g.do(http.MethodPost, "/v1/payments/order-3/refund", "", "", &rejected); res.StatusCode != http.StatusConflict {
+		t.Errorf("refund of a voided order = %d %+v", res.StatusCode, rejected)
+	}
+	// out of sync with LINE Pay, which confirmed the payment already
+	s.saveOrder(ctx, &order{OrderID: "order-1", TransactionID: 2019049910005496811, Amount: 100, Currency: "TWD", Status: statusRequested})
+	if res := g.do(http.MethodPost, "/v1/payments/order-1/confirm", "", "", &rejected); res.StatusCode != http.StatusUnprocessableEntity ||
+		rejected.Error.Code != "linepay_error" || rejected.Error.ReturnCode != "1124" {
+		t.Errorf("confirm rejected by LINE Pay = %d %+v", res.StatusCode, rejected)
+	}
+	if res := g.do(http.MethodGet, "/v1/payments/unknown", "", "", &rejected); res.StatusCode != http.StatusNotFound {
+		t.Errorf("details of an unknown order = %d", res.StatusCode)
+	}
+
+	// the orders are kept in the file
+	reopened, err := openFileStore(s.path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o, err := reopened.getOrder(ctx, "order-2"); err != nil || o.Status != statusCaptured || o.TransactionID != 2019049910005496812 {
+		t.Errorf("reopened order-2 = %+v, %v", o, err)
+	}
+	if _, err := reopened.getResponse(ctx, "create-1"); err == nil {
+		t.Errorf("Idempotency-Key not scoped by the API key")
+	}
+}
+
+func TestGatewayUnavailable(t *testing.T) {
+
+	linePayServer := httptest.NewServer(newFakeLinePay())
+	linePayServer.Close()
+
+	client, _ := linepay.NewClient("1001", "secret", nil, &linepay.ClientOpts{APIEndpoint: linePayServer.URL})
+	s, err := openFileStore(filepath.Join(t.TempDir(), "gateway.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewServer(newServer(client, s, []string{"key-1"}))
+	defer ts.Close()
+	g := &gateway{t: t, url: ts.URL}
+
+	// the SDK error, with the LINE Pay URL, is logged and not answered
+	var failed apiError
+	create := `{"orderId":"order-1","amount":100,"currency":"TWD","productName":"coffee","confirmUrl":"https://shop.example.com/confirm","cancelUrl":"https://shop.example.com/cancel"}`
+	if res := g.do(http.MethodPost, "/v1/payments", "create-1", create, &failed); res.StatusCode != http.StatusBadGateway ||
+		failed.Error.Code != "linepay_unavailable" || strings.Contains(failed.Error.Message, strings.TrimPrefix(linePayServer.URL, "http://")) {
+		t.Errorf("create with LINE Pay down = %d %+v", res.StatusCode, failed)
+	}
+}
+
+func TestOpenAPI(t *testing.T) {
+
+	ts := httptest.NewServer(newServer(nil, newMemoryStore(), []string{"key-1"}))
+	defer ts.Close()
+
+	res, err := http.Get(ts.URL + "/openapi.yaml")
+	if err != nil || res.StatusCode != http.StatusOK {
+		t.Fatalf("GET /openapi.yaml = %v, %v", res, err)
+	}
+	defer res.Body.Close()
+
+	spec := struct {
+		Paths map[string]map[string]interface{} `yaml:"paths"`
+	}{}
+	if err := yaml.NewDecoder(res.Body).Decode(&spec); err != nil {
+		t.Fatal(err)
+	}
+
+	operations := map[string]bool{}
+	for path, methods := range spec.Paths {
+		for method := range methods {
+			operations[strings.ToUpper(method)+" "+path] = true
+		}
+	}
+	for _, want := range []string{
+		"POST /v1/payments", "GET /v1/payments/{orderId}", "GET /v1/payments/{orderId}/status", "POST /v1/payments/{orderId}/confirm",
+		"POST /v1/payments/{orderId}/capture", "POST /v1/payments/{orderId}/void", "POST /v1/payments/{orderId}/refund",
+	} {
+		if !operations[want] {
+			t.Errorf("openapi.yaml misses %s", want)
+		}
+	}
+	if len(operations) != 7 {
+		t.Errorf("openapi.yaml operations = %v", operations)
+	}
+}
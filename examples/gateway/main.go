@@ -0,0 +1,57 @@
+// Command gateway is a JSON REST service over the SDK, for services written in other languages.
+//
+//	go run ./examples/gateway --listen=:8080 --store=/var/lib/linepay/gateway.json
+//
+// The channel is configured like `linepay.LoadConfig` (`LINEPAY_*` environment variables, flags or a config file).
+// Clients send one of the API keys of `LINEPAY_GATEWAY_API_KEYS` (comma separated) as `Authorization: Bearer <key>`
+// or `X-API-Key`. A POST sent with an `Idempotency-Key` header is processed once, the response is replayed for
+// 24 hours. The API is described by openapi.yaml, served at `/openapi.yaml`.
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+
+	linepay "github.com/chy168/line-pay-sdk-go"
+	"github.com/sirupsen/logrus"
+)
+
+func main() {
+
+	listen := flag.String("listen", ":8080", "address to listen on")
+	storePath := flag.String("store", "", "JSON file keeping the orders, in memory when empty")
+	linepay.RegisterConfigFlags(flag.CommandLine)
+	flag.Parse()
+
+	config, err := linepay.LoadConfig(flag.CommandLine)
+	if err != nil {
+		log.Fatal(err)
+	}
+	client, err := linepay.NewClientFromConfig(config)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var keys []string
+	for _, key := range strings.Split(os.Getenv("LINEPAY_GATEWAY_API_KEYS"), ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		log.Fatal("LINEPAY_GATEWAY_API_KEYS is required")
+	}
+
+	var s store = newMemoryStore()
+	if *storePath != "" {
+		if s, err = openFileStore(*storePath); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	logrus.Infof("gateway listening on %s", *listen)
+	log.Fatal(http.ListenAndServe(*listen, newServer(client, s, keys)))
+}
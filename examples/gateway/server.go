@@ -0,0 +1,569 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	_ "embed"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	linepay "github.com/chy168/line-pay-sdk-go"
+	"github.com/sirupsen/logrus"
+)
+
+//go:embed openapi.yaml
+var openapi []byte
+
+// idempotencyTTL is how long the response of an Idempotency-Key is replayed
+const idempotencyTTL = 24 * time.Hour
+
+const maxBody = 1 << 20
+
+// apiError is the body of every error response
+type apiError struct {
+	Error struct {
+		Code          string `json:"code"`
+		Message       string `json:"message"`
+		ReturnCode    string `json:"returnCode,omitempty"`
+		ReturnMessage string `json:"returnMessage,omitempty"`
+	} `json:"error"`
+}
+
+// server is the JSON REST gateway, see openapi.yaml
+type server struct {
+	client linepay.PaymentsAPI
+	store  store
+	keys   [][]byte // sha256 of the API keys
+
+	mu       sync.Mutex
+	inflight map[string]bool       // Idempotency-Keys being processed
+	orders   map[string]*orderLock // orders being changed
+}
+
+// handlerFunc handles a request of the order (empty for a create), it returns the status code and the JSON body
+type handlerFunc func(ctx context.Context, orderID string, body []byte) (int, interface{})
+
+type orderLock struct {
+	sync.Mutex
+	refs int
+}
+
+func newServer(client linepay.PaymentsAPI, s store, apiKeys []string) *server {
+
+	srv := &server{client: client, store: s, inflight: map[string]bool{}, orders: map[string]*orderLock{}}
+	for _, key := range apiKeys {
+		sum := sha256.Sum256([]byte(key))
+		srv.keys = append(srv.keys, sum[:])
+	}
+	return srv
+}
+
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	if r.URL.Path == "/openapi.yaml" && r.Method == http.MethodGet {
+		w.Header().Set("Content-Type", "application/yaml")
+		w.Write(openapi)
+		return
+	}
+
+	key, ok := s.authenticate(r)
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "unauthorized", "missing or invalid API key")
+		return
+	}
+
+	// /v1/payments[/{orderId}[/{action}]]
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) < 2 || len(parts) > 4 || parts[0] != "v1" || parts[1] != "payments" {
+		writeError(w, http.StatusNotFound, "not_found", "unknown path")
+		return
+	}
+	var orderID, action string
+	if len(parts) > 2 {
+		orderID = parts[2]
+	}
+	if len(parts) > 3 {
+		action = parts[3]
+	}
+
+	var handler handlerFunc
+	switch {
+	case r.Method == http.MethodPost && orderID == "":
+		handler = s.create
+	case r.Method == http.MethodGet && orderID != "" && action == "":
+		handler = s.details
+	case r.Method == http.MethodGet && action == "status":
+		handler = s.status
+	case r.Method == http.MethodPost && action == "confirm":
+		handler = s.confirm
+	case r.Method == http.MethodPost && action == "capture":
+		handler = s.capture
+	case r.Method == http.MethodPost && action == "void":
+		handler = s.void
+	case r.Method == http.MethodPost && action == "refund":
+		handler = s.refund
+	default:
+		writeError(w, http.StatusNotFound, "not_found", "unknown path or method")
+		return
+	}
+
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxBody))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", "request body too large")
+		return
+	}
+
+	if r.Method == http.MethodPost && r.Header.Get("Idempotency-Key") != "" {
+		s.idempotent(w, r, key+":"+r.Header.Get("Idempotency-Key"), orderID, body, handler)
+		return
+	}
+
+	status, res := handler(r.Context(), orderID, body)
+	writeJSON(w, status, res)
+}
+
+// authenticate accepts `Authorization: Bearer <key>` or `X-API-Key: <key>`, it returns a hash of the key
+// which scopes the Idempotency-Keys
+func (s *server) authenticate(r *http.Request) (string, bool) {
+
+	key := r.Header.Get("X-API-Key")
+	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		key = strings.TrimPrefix(auth, "Bearer ")
+	}
+	if key == "" {
+		return "", false
+	}
+
+	sum := sha256.Sum256([]byte(key))
+	found := 0
+	for _, k := range s.keys {
+		found |= subtle.ConstantTimeCompare(sum[:], k)
+	}
+	return hex.EncodeToString(sum[:8]), found == 1
+}
+
+// idempotent replays the response saved for the key, or runs the handler and saves its response.
+// A key reused for another request is rejected, a 5xx response is not saved so the request can be retried.
+func (s *server) idempotent(w http.ResponseWriter, r *http.Request, key, orderID string, body []byte, handler handlerFunc) {
+
+	sum := sha256.Sum256(append([]byte(r.Method+" "+r.URL.Path+"\n"), body...))
+	fingerprint := hex.EncodeToString(sum[:])
+
+	s.mu.Lock()
+	if s.inflight[key] {
+		s.mu.Unlock()
+		writeError(w, http.StatusConflict, "idempotency_in_progress", "a request with this Idempotency-Key is in progress")
+		return
+	}
+	s.inflight[key] = true
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		delete(s.inflight, key)
+		s.mu.Unlock()
+	}()
+
+	saved, err := s.store.getResponse(r.Context(), key)
+	if err == nil && time.Since(saved.CreatedAt) <= idempotencyTTL {
+		if saved.Fingerprint != fingerprint {
+			writeError(w, http.StatusUnprocessableEntity, "idempotency_mismatch", "the Idempotency-Key was used for another request")
+			return
+		}
+		w.Header().Set("Idempotent-Replayed", "true")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(saved.StatusCode)
+		w.Write(saved.Body)
+		return
+	}
+
+	status, res := handler(r.Context(), orderID, body)
+	out, err := marshal(res)
+	if err != nil {
+		logrus.Errorf("gateway marshal response error: %s", err.Error())
+		writeError(w, http.StatusInternalServerError, "internal", "internal error")
+		return
+	}
+
+	if status < 500 {
+		err := s.store.saveResponse(r.Context(), key, &savedResponse{Fingerprint: fingerprint, StatusCode: status, Body: out, CreatedAt: time.Now()})
+		if err != nil {
+			logrus.Errorf("gateway save idempotent response error: %s", err.Error())
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(out)
+}
+
+// lock serializes the changes of an order, the returned function unlocks it
+func (s *server) lock(orderID string) func() {
+
+	s.mu.Lock()
+	l := s.orders[orderID]
+	if l == nil {
+		l = &orderLock{}
+		s.orders[orderID] = l
+	}
+	l.refs++
+	s.mu.Unlock()
+
+	l.Lock()
+	return func() {
+		l.Unlock()
+		s.mu.Lock()
+		if l.refs--; l.refs == 0 {
+			delete(s.orders, orderID)
+		}
+		s.mu.Unlock()
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	out, err := marshal(body)
+	if err != nil {
+		logrus.Errorf("gateway marshal response error: %s", err.Error())
+		status, out = http.StatusInternalServerError, []byte(`{"error":{"code":"internal","message":"internal error"}}`)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(out)
+}
+
+// marshal encodes `body` with the transaction ids as JSON strings, 19 digit numbers do not fit in a JavaScript number
+func marshal(body interface{}) ([]byte, error) {
+
+	b, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	var v interface{}
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	if err := d.Decode(&v); err != nil {
+		return nil, err
+	}
+
+	var walk func(v interface{})
+	walk = func(v interface{}) {
+		switch v := v.(type) {
+		case map[string]interface{}:
+			for k, e := range v {
+				if n, ok := e.(json.Number); ok && strings.HasSuffix(strings.ToLower(k), "transactionid") {
+					v[k] = n.String()
+				} else {
+					walk(e)
+				}
+			}
+		case []interface{}:
+			for _, e := range v {
+				walk(e)
+			}
+		}
+	}
+	walk(v)
+	return json.Marshal(v)
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, failure(code, message))
+}
+
+func failure(code, message string) *apiError {
+	e := &apiError{}
+	e.Error.Code = code
+	e.Error.Message = message
+	return e
+}
+
+// unavailable maps an error of the SDK, LINE Pay was not reached or did not answer.
+// The error is only logged, it may hold the LINE Pay URL or the internals of the SDK.
+func unavailable(err error) (int, interface{}) {
+	logrus.Errorf("gateway LINE Pay error: %s", err.Error())
+	return http.StatusBadGateway, failure("linepay_unavailable", "LINE Pay is unavailable")
+}
+
+// rejected maps a LINE Pay `returnCode` other than success
+func rejected(returnCode, returnMessage string) (int, interface{}) {
+	e := failure("linepay_error", fmt.Sprintf("LINE Pay returned %s", returnCode))
+	e.Error.ReturnCode = returnCode
+	e.Error.ReturnMessage = returnMessage
+	return http.StatusUnprocessableEntity, e
+}
+
+// loadOrder returns the order or the error response
+func (s *server) loadOrder(ctx context.Context, orderID string, statuses ...orderStatus) (*order, int, interface{}) {
+
+	o, err := s.store.getOrder(ctx, orderID)
+	if err == errNotFound {
+		return nil, http.StatusNotFound, failure("not_found", fmt.Sprintf("order '%s' not found", orderID))
+	}
+	if err != nil {
+		logrus.Errorf("gateway load order '%s' error: %s", orderID, err.Error())
+		return nil, http.StatusInternalServerError, failure("internal", "internal error")
+	}
+
+	for _, status := range statuses {
+		if o.Status == status {
+			return o, 0, nil
+		}
+	}
+	return nil, http.StatusConflict, failure("invalid_state", fmt.Sprintf("order '%s' is %s", orderID, o.Status))
+}
+
+func (s *server) saveOrder(ctx context.Context, o *order) (int, interface{}) {
+	o.UpdatedAt = time.Now()
+	if err := s.store.saveOrder(ctx, o); err != nil {
+		logrus.Errorf("gateway save order '%s' error: %s", o.OrderID, err.Error())
+		return http.StatusInternalServerError, failure("internal", "LINE Pay succeeded but the order was not saved")
+	}
+	return http.StatusOK, o
+}
+
+type createRequest struct {
+	OrderID     string `json:"orderId"`
+	Amount      int    `json:"amount"`
+	Currency    string `json:"currency"`
+	ProductName string `json:"productName"`
+	ConfirmURL  string `json:"confirmUrl"`
+	CancelURL   string `json:"cancelUrl"`
+	Capture     *bool  `json:"capture"` // true when omitted
+}
+
+func (s *server) create(ctx context.Context, _ string, body []byte) (int, interface{}) {
+
+	req := createRequest{}
+	if err := json.Unmarshal(body, &req); err != nil {
+		return http.StatusBadRequest, failure("invalid_request", "invalid JSON: "+err.Error())
+	}
+	if req.OrderID == "" || strings.Contains(req.OrderID, "/") || req.Amount <= 0 || req.Currency == "" || req.ProductName == "" ||
+		req.ConfirmURL == "" || req.CancelURL == "" {
+		return http.StatusBadRequest, failure("invalid_request", "orderId (without '/'), amount, currency, productName, confirmUrl and cancelUrl are required")
+	}
+
+	o := &order{
+		OrderID:     req.OrderID,
+		Amount:      req.Amount,
+		Currency:    req.Currency,
+		ProductName: req.ProductName,
+		Capture:     req.Capture == nil || *req.Capture,
+		Status:      statusRequested,
+		CreatedAt:   time.Now(),
+	}
+	o.UpdatedAt = o.CreatedAt
+
+	// the order is saved once LINE Pay accepted it, a failed request can be sent again
+	defer s.lock(o.OrderID)()
+	if _, err := s.store.getOrder(ctx, o.OrderID); err == nil {
+		return http.StatusConflict, failure("duplicate_order", fmt.Sprintf("order '%s' exists", o.OrderID))
+	}
+
+	request := &linepay.PaymentsRequest{
+		Amount:   o.Amount,
+		Currency: o.Currency,
+		OrderID:  o.OrderID,
+		Packages: []linepay.PaymentsPackageRequest{{
+			ID:       o.OrderID,
+			Amount:   o.Amount,
+			Name:     o.ProductName,
+			Products: []linepay.PaymentsPackageProductRequest{{Name: o.ProductName, Quantity: 1, Price: o.Amount}},
+		}},
+		RedirectUrls: linepay.PaymentsRedirectUrlsRequest{ConfirmURL: req.ConfirmURL, CancelURL: req.CancelURL},
+		Options:      linepay.PaymentsOptionsRequest{Payment: linepay.PaymentsOptionsPaymentRequest{Capture: o.Capture}},
+	}
+
+	res, err := s.client.PaymentsRequest(ctx, request)
+	if err != nil {
+		return unavailable(err)
+	}
+	if res.ReturnCode != linepay.ApiReturnCodeSuccess {
+		return rejected(res.ReturnCode, res.ReturnMessage)
+	}
+
+	o.TransactionID = res.Info.TransactionID
+	o.PaymentURL = res.Info.PaymentURL
+	if err := s.store.createOrder(ctx, o); err != nil {
+		logrus.Errorf("gateway save order '%s' error: %s", o.OrderID, err.Error())
+		return http.StatusInternalServerError, failure("internal", "LINE Pay succeeded but the order was not saved")
+	}
+	return http.StatusCreated, o
+}
+
+func (s *server) confirm(ctx context.Context, orderID string, _ []byte) (int, interface{}) {
+
+	defer s.lock(orderID)()
+	o, status, failed := s.loadOrder(ctx, orderID, statusRequested)
+	if o == nil {
+		return status, failed
+	}
+
+	res, err := s.client.PaymentsConfirm(ctx, o.TransactionID, &linepay.PaymentsConfirmRequest{Amount: o.Amount, Currency: o.Currency})
+	if err != nil {
+		return unavailable(err)
+	}
+	if res.ReturnCode != linepay.ApiReturnCodeSuccess {
+		return rejected(res.ReturnCode, res.ReturnMessage)
+	}
+
+	o.Status = statusCaptured
+	if !o.Capture {
+		o.Status = statusAuthorized
+	}
+	return s.saveOrder(ctx, o)
+}
+
+func (s *server) capture(ctx context.Context, orderID string, _ []byte) (int, interface{}) {
+
+	defer s.lock(orderID)()
+	o, status, failed := s.loadOrder(ctx, orderID, statusAuthorized)
+	if o == nil {
+		return status, failed
+	}
+
+	res, err := s.client.PaymentsCapture(ctx, o.TransactionID, &linepay.PaymentsCaptureRequest{Amount: o.Amount, Currency: o.Currency})
+	if err != nil {
+		return unavailable(err)
+	}
+	if res.ReturnCode != linepay.ApiReturnCodeSuccess {
+		return rejected(res.ReturnCode, res.ReturnMessage)
+	}
+
+	o.Status = statusCaptured
+	return s.saveOrder(ctx, o)
+}
+
+func (s *server) void(ctx context.Context, orderID string, _ []byte) (int, interface{}) {
+
+	defer s.lock(orderID)()
+	o, status, failed := s.loadOrder(ctx, orderID, statusAuthorized)
+	if o == nil {
+		return status, failed
+	}
+
+	res, err := s.client.PaymentsVoid(ctx, o.TransactionID)
+	if err != nil {
+		return unavailable(err)
+	}
+	if res.ReturnCode != linepay.ApiReturnCodeSuccess {
+		return rejected(res.ReturnCode, res.ReturnMessage)
+	}
+
+	o.Status = statusVoided
+	return s.saveOrder(ctx, o)
+}
+
+type refundRequest struct {
+	Amount int `json:"amount"` // the amount not refunded yet when 0
+}
+
+func (s *server) refund(ctx context.Context, orderID string, body []byte) (int, interface{}) {
+
+	req := refundRequest{}
+	if len(bytes.TrimSpace(body)) > 0 {
+		if err := json.Unmarshal(body, &req); err != nil {
+			return http.StatusBadRequest, failure("invalid_request", "invalid JSON: "+err.Error())
+		}
+	}
+	if req.Amount < 0 {
+		return http.StatusBadRequest, failure("invalid_request", "amount must be positive")
+	}
+
+	defer s.lock(orderID)()
+	o, status, failed := s.loadOrder(ctx, orderID, statusCaptured)
+	if o == nil {
+		return status, failed
+	}
+
+	left := o.Amount - o.Refunded
+	if req.Amount == 0 {
+		req.Amount = left
+	}
+	if req.Amount > left {
+		return http.StatusConflict, failure("invalid_state", fmt.Sprintf("order '%s' has %d left to refund", orderID, left))
+	}
+
+	// LINE Pay refunds the full amount when refundAmount is not sent
+	request := &linepay.PaymentsRefundRequest{}
+	if o.Refunded > 0 || req.Amount < o.Amount {
+		request.RefundAmount = req.Amount
+	}
+	res, err := s.client.PaymentsRefund(ctx, o.TransactionID, request)
+	if err != nil {
+		return unavailable(err)
+	}
+	if res.ReturnCode != linepay.ApiReturnCodeSuccess {
+		return rejected(res.ReturnCode, res.ReturnMessage)
+	}
+
+	o.Refunded += req.Amount
+	if o.Refunded == o.Amount {
+		o.Status = statusRefunded
+	}
+	return s.saveOrder(ctx, o)
+}
+
+type detailsResponse struct {
+	Order   *order                                `json:"order"`
+	LinePay []linepay.PaymentsDetailsInfoResponse `json:"linePay"`
+}
+
+func (s *server) details(ctx context.Context, orderID string, _ []byte) (int, interface{}) {
+
+	o, status, failed := s.loadOrder(ctx, orderID, statusRequested, statusAuthorized, statusCaptured, statusVoided, statusRefunded)
+	if o == nil {
+		return status, failed
+	}
+
+	res, err := s.client.PaymentsDetails(ctx, &linepay.PaymentsDetailsRequest{OrderIDs: []string{orderID}})
+	if err != nil {
+		return unavailable(err)
+	}
+	// a payment never confirmed may be unknown to PaymentsDetails
+	if res.ReturnCode != linepay.ApiReturnCodeSuccess && res.ReturnCode != linepay.ApiReturnCodeTransactionNotFound {
+		return rejected(res.ReturnCode, res.ReturnMessage)
+	}
+	return http.StatusOK, &detailsResponse{Order: o, LinePay: res.Info}
+}
+
+type statusResponse struct {
+	OrderID       string `json:"orderId"`
+	Status        string `json:"status"`
+	ReturnCode    string `json:"returnCode"`
+	ReturnMessage string `json:"returnMessage"`
+}
+
+var paymentStatuses = map[string]string{
+	linepay.PaymentsStatusPending:    "PENDING",
+	linepay.PaymentsStatusAuthorized: "AUTHORIZED",
+	linepay.PaymentsStatusCanceled:   "CANCELED",
+	linepay.PaymentsStatusFailed:     "FAILED",
+	linepay.PaymentsStatusCompleted:  "COMPLETED",
+}
+
+func (s *server) status(ctx context.Context, orderID string, _ []byte) (int, interface{}) {
+
+	o, status, failed := s.loadOrder(ctx, orderID, statusRequested, statusAuthorized, statusCaptured, statusVoided, statusRefunded)
+	if o == nil {
+		return status, failed
+	}
+
+	res, err := s.client.PaymentsStatus(ctx, o.TransactionID)
+	if err != nil {
+		return unavailable(err)
+	}
+	paymentStatus, ok := paymentStatuses[res.ReturnCode]
+	if !ok {
+		return rejected(res.ReturnCode, res.ReturnMessage)
+	}
+	return http.StatusOK, &statusResponse{OrderID: orderID, Status: paymentStatus, ReturnCode: res.ReturnCode, ReturnMessage: res.ReturnMessage}
+}
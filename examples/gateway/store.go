@@ -0,0 +1,203 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	linepay "github.com/chy168/line-pay-sdk-go"
+)
+
+var (
+	errDuplicate = errors.New("duplicate")
+	errNotFound  = errors.New("not found")
+)
+
+// orderStatus follows the payment: requested, then authorized (captured later) or captured by the confirm
+type orderStatus string
+
+const (
+	statusRequested  orderStatus = "REQUESTED"
+	statusAuthorized orderStatus = "AUTHORIZED"
+	statusCaptured   orderStatus = "CAPTURED"
+	statusVoided     orderStatus = "VOIDED"
+	statusRefunded   orderStatus = "REFUNDED" // fully refunded, a partial refund stays CAPTURED
+)
+
+type order struct {
+	OrderID       string                                 `json:"orderId"`
+	TransactionID linepay.TransactionID                  `json:"transactionId"`
+	Amount        int                                    `json:"amount"`
+	Currency      string                                 `json:"currency"`
+	ProductName   string                                 `json:"productName"`
+	Capture       bool                                   `json:"capture"`
+	Status        orderStatus                            `json:"status"`
+	Refunded      int                                    `json:"refunded"`
+	PaymentURL    linepay.PaymentsInfoPaymentURLResponse `json:"paymentUrl"`
+	CreatedAt     time.Time                              `json:"createdAt"`
+	UpdatedAt     time.Time                              `json:"updatedAt"`
+}
+
+// savedResponse is the response of a POST sent with an Idempotency-Key
+type savedResponse struct {
+	Fingerprint string          `json:"fingerprint"` // hash of the request
+	StatusCode  int             `json:"statusCode"`
+	Body        json.RawMessage `json:"body"`
+	CreatedAt   time.Time       `json:"createdAt"`
+}
+
+// store persists the orders and the idempotent responses, implementations must be safe for concurrent use
+type store interface {
+	createOrder(ctx context.Context, o *order) error // errDuplicate when the order id exists
+	getOrder(ctx context.Context, orderID string) (*order, error)
+	saveOrder(ctx context.Context, o *order) error
+	getResponse(ctx context.Context, key string) (*savedResponse, error)
+	saveResponse(ctx context.Context, key string, res *savedResponse) error
+}
+
+// memoryStore loses everything on restart, use it for tests and demos
+type memoryStore struct {
+	mu        sync.RWMutex
+	Orders    map[string]order         `json:"orders"`
+	Responses map[string]savedResponse `json:"responses"`
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{Orders: map[string]order{}, Responses: map[string]savedResponse{}}
+}
+
+func (m *memoryStore) createOrder(ctx context.Context, o *order) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.Orders[o.OrderID]; ok {
+		return errDuplicate
+	}
+	m.Orders[o.OrderID] = *o
+	return nil
+}
+
+func (m *memoryStore) getOrder(ctx context.Context, orderID string) (*order, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	o, ok := m.Orders[orderID]
+	if !ok {
+		return nil, errNotFound
+	}
+	return &o, nil
+}
+
+func (m *memoryStore) saveOrder(ctx context.Context, o *order) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.Orders[o.OrderID] = *o
+	return nil
+}
+
+func (m *memoryStore) getResponse(ctx context.Context, key string) (*savedResponse, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	res, ok := m.Responses[key]
+	if !ok {
+		return nil, errNotFound
+	}
+	return &res, nil
+}
+
+// saveResponse also drops the responses older than idempotencyTTL
+func (m *memoryStore) saveResponse(ctx context.Context, key string, res *savedResponse) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for k, r := range m.Responses {
+		if time.Since(r.CreatedAt) > idempotencyTTL {
+			delete(m.Responses, k)
+		}
+	}
+	m.Responses[key] = *res
+	return nil
+}
+
+// fileStore is a memoryStore written to a JSON file after every change, enough for a single instance gateway
+type fileStore struct {
+	*memoryStore
+	path string
+	mu   sync.Mutex // serializes the writes of the file
+}
+
+func openFileStore(path string) (*fileStore, error) {
+
+	f := &fileStore{memoryStore: newMemoryStore(), path: path}
+
+	body, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return f, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(body, f.memoryStore); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *fileStore) write() error {
+
+	f.memoryStore.mu.RLock()
+	body, err := json.Marshal(f.memoryStore)
+	f.memoryStore.mu.RUnlock()
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(f.path), ".gateway-")
+	if err != nil {
+		return err
+	}
+	if _, err = tmp.Write(body); err == nil {
+		err = tmp.Sync()
+	}
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), f.path)
+}
+
+func (f *fileStore) createOrder(ctx context.Context, o *order) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.memoryStore.createOrder(ctx, o); err != nil {
+		return err
+	}
+	return f.write()
+}
+
+func (f *fileStore) saveOrder(ctx context.Context, o *order) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.memoryStore.saveOrder(ctx, o)
+	return f.write()
+}
+
+func (f *fileStore) saveResponse(ctx context.Context, key string, res *savedResponse) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.memoryStore.saveResponse(ctx, key, res)
+	return f.write()
+}
@@ -1,9 +1,14 @@
 module github.com/chy168/line-pay-sdk-go
 
-go 1.13
+go 1.18
 
 require (
-	github.com/gin-gonic/gin v1.5.0
 	github.com/google/uuid v1.1.1
 	github.com/sirupsen/logrus v1.4.2
+	gopkg.in/yaml.v2 v2.2.2
+)
+
+require (
+	github.com/konsorten/go-windows-terminal-sequences v1.0.1 // indirect
+	golang.org/x/sys v0.0.0-20190422165155-953cdadca894 // indirect
 )
@@ -0,0 +1,223 @@
+package grpcpay
+
+import (
+	"time"
+
+	linepay "github.com/chy168/line-pay-sdk-go"
+	"github.com/chy168/line-pay-sdk-go/grpcpay/linepaypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// timestamp is nil for the zero time, a date absent of the LINE Pay response
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+func paymentsRequest(req *linepaypb.PaymentsRequest) *linepay.PaymentsRequest {
+
+	request := &linepay.PaymentsRequest{
+		Amount:   int(req.GetAmount()),
+		Currency: req.GetCurrency(),
+		OrderID:  req.GetOrderId(),
+		RedirectUrls: linepay.PaymentsRedirectUrlsRequest{
+			AppPackageName: req.GetRedirectUrls().GetAppPackageName(),
+			ConfirmURL:     req.GetRedirectUrls().GetConfirmUrl(),
+			ConfirmURLType: req.GetRedirectUrls().GetConfirmUrlType(),
+			CancelURL:      req.GetRedirectUrls().GetCancelUrl(),
+		},
+	}
+
+	for _, p := range req.GetPackages() {
+		pkg := linepay.PaymentsPackageRequest{ID: p.GetId(), Amount: int(p.GetAmount()), UserFee: int(p.GetUserFee()), Name: p.GetName()}
+		for _, product := range p.GetProducts() {
+			pkg.Products = append(pkg.Products, linepay.PaymentsPackageProductRequest{
+				ID:            product.GetId(),
+				Name:          product.GetName(),
+				ImageURL:      product.GetImageUrl(),
+				Quantity:      int(product.GetQuantity()),
+				Price:         int(product.GetPrice()),
+				OriginalPrice: int(product.GetOriginalPrice()),
+			})
+		}
+		request.Packages = append(request.Packages, pkg)
+	}
+
+	options := req.GetOptions()
+	request.Options.Payment = linepay.PaymentsOptionsPaymentRequest{Capture: options.GetPayment().GetCapture(), PayType: options.GetPayment().GetPayType()}
+	request.Options.Display = linepay.PaymentsOptionsDisplayRequest{
+		Locale:                 options.GetDisplay().GetLocale(),
+		CheckConfirmURLBrowser: options.GetDisplay().GetCheckConfirmUrlBrowser(),
+	}
+
+	shipping := options.GetShipping()
+	address := shipping.GetAddress()
+	recipient := address.GetRecipient()
+	request.Options.Shipping = linepay.PaymentsOptionsShippingRequest{
+		ShippintType:   shipping.GetType(),
+		FeeAmount:      shipping.GetFeeAmount(),
+		FeeInquiryURL:  shipping.GetFeeInquiryUrl(),
+		FeeInquiryType: shipping.GetFeeInquiryType(),
+		Address: linepay.PaymentsOptionsShippingAddressRequest{
+			Country:    address.GetCountry(),
+			PostalCode: address.GetPostalCode(),
+			State:      address.GetState(),
+			City:       address.GetCity(),
+			Detail:     address.GetDetail(),
+			Optional:   address.GetOptional(),
+			Recipient: linepay.PaymentsOptionsShippingAddressRecipientRequest{
+				FirstName:         recipient.GetFirstName(),
+				LastName:          recipient.GetLastName(),
+				FirstNameOptional: recipient.GetFirstNameOptional(),
+				LastNameOptional:  recipient.GetLastNameOptional(),
+				Email:             recipient.GetEmail(),
+				PhoneNo:           recipient.GetPhoneNo(),
+			},
+		},
+	}
+
+	for _, f := range options.GetFamilyService().GetAddFriends() {
+		request.Options.FamilyService.AddFriends = append(request.Options.FamilyService.AddFriends,
+			linepay.PaymentsOptionsFamilyServiceAddFriendsRequest{AddType: f.GetType(), IDs: f.GetIds()})
+	}
+	request.Options.Extra = linepay.PaymentsOptionsExtraRequest{BranchName: options.GetExtra().GetBranchName(), BranchID: options.GetExtra().GetBranchId()}
+
+	return request
+}
+
+func paymentsResponse(res *linepay.PaymentsResponse) *linepaypb.PaymentsResponse {
+	return &linepaypb.PaymentsResponse{
+		ReturnCode:    res.ReturnCode,
+		ReturnMessage: res.ReturnMessage,
+		Info: &linepaypb.PaymentsInfo{
+			TransactionId:      int64(res.Info.TransactionID),
+			PaymentAccessToken: res.Info.PaymentAccessToken.Reveal(),
+			PaymentUrl:         &linepaypb.PaymentUrl{Web: res.Info.PaymentURL.Web, App: res.Info.PaymentURL.App},
+		},
+	}
+}
+
+func address(country, postalCode, state, city, detail, optional string, recipient *linepaypb.Recipient) *linepaypb.Address {
+	return &linepaypb.Address{Country: country, PostalCode: postalCode, State: state, City: city, Detail: detail, Optional: optional, Recipient: recipient}
+}
+
+func confirmResponse(res *linepay.PaymentsConfirmResponse) *linepaypb.PaymentsConfirmResponse {
+
+	info := res.Info
+	a := info.Shipping.Address
+	r := a.Recipient
+	out := &linepaypb.ConfirmInfo{
+		OrderId:                 info.OrderID,
+		TransactionId:           int64(info.TransactionID),
+		AuthorizationExpireDate: timestamp(info.AuthorizationExpireDate),
+		RegKey:                  info.RegKey.Reveal(),
+		Shipping: &linepaypb.Shipping{
+			MethodId:  info.Shipping.MethodID,
+			FeeAmount: int64(info.Shipping.FeeAmount),
+			Address: address(a.Country, a.PostalCode, a.State, a.City, a.Detail, a.Optional, &linepaypb.Recipient{
+				FirstName: r.FirstName, LastName: r.LastName, FirstNameOptional: r.FirstNameOptional,
+				LastNameOptional: r.LastNameOptional, Email: r.Email, PhoneNo: r.PhoneNo,
+			}),
+		},
+	}
+	for _, p := range info.PayInfo {
+		out.PayInfo = append(out.PayInfo, &linepaypb.PayInfo{
+			Method:                 p.Method,
+			Amount:                 int64(p.Amount),
+			CreditCardNickname:     p.CreditCardNickname,
+			CreditCardBrand:        p.CreditCardBrand,
+			MaskedCreditCardNumber: p.MaskedCreditCardNumber,
+		})
+	}
+	for _, p := range info.Packages {
+		out.Packages = append(out.Packages, &linepaypb.PackageAmount{Id: p.ID, Amount: int64(p.Amount), UserFeeAmount: int64(p.UserFeeAmount)})
+	}
+
+	return &linepaypb.PaymentsConfirmResponse{ReturnCode: res.ReturnCode, ReturnMessage: res.ReturnMessage, Info: out}
+}
+
+func captureResponse(res *linepay.PaymentsCaptureResponse) *linepaypb.PaymentsCaptureResponse {
+
+	info := &linepaypb.CaptureInfo{TransactionId: int64(res.Info.TransactionID), OrderId: res.Info.OrderID}
+	for _, p := range res.Info.PayInfo {
+		info.PayInfo = append(info.PayInfo, &linepaypb.PayInfo{Method: p.Method, Amount: int64(p.Amount)})
+	}
+	return &linepaypb.PaymentsCaptureResponse{ReturnCode: res.ReturnCode, ReturnMessage: res.ReturnMessage, Info: info}
+}
+
+func refundResponse(res *linepay.PaymentsRefundResponse) *linepaypb.PaymentsRefundResponse {
+	return &linepaypb.PaymentsRefundResponse{
+		ReturnCode:    res.ReturnCode,
+		ReturnMessage: res.ReturnMessage,
+		Info: &linepaypb.RefundInfo{
+			RefundTransactionId:   int64(res.Info.RefundTransactionID),
+			RefundTransactionDate: timestamp(res.Info.RefundTransactionDate),
+		},
+	}
+}
+
+func detailsResponse(res *linepay.PaymentsDetailsResponse) *linepaypb.PaymentsDetailsResponse {
+
+	out := &linepaypb.PaymentsDetailsResponse{ReturnCode: res.ReturnCode, ReturnMessage: res.ReturnMessage}
+	for _, info := range res.Info {
+		a := info.Shipping.Address
+		r := a.Recipient
+		d := &linepaypb.DetailsInfo{
+			TransactionId:           int64(info.TransactionID),
+			OrderId:                 info.OrderID,
+			TransactionDate:         timestamp(info.TransactionDate),
+			TransactionType:         info.TransactionType,
+			PayStatus:               info.PayStatus,
+			ProductName:             info.ProductName,
+			MerchantName:            info.MerchantName,
+			Currency:                info.Currency,
+			AuthorizationExpireDate: timestamp(info.AuthorizationExpireDate),
+			OriginalTransactionId:   int64(info.OriginalTransactionID),
+			Shipping: &linepaypb.Shipping{
+				MethodId:  info.Shipping.MethodID,
+				FeeAmount: int64(info.Shipping.FeeAmount),
+				Address: address(a.Country, a.PostalCode, a.State, a.City, a.Detail, a.Optional, &linepaypb.Recipient{
+					FirstName: r.FirstName, LastName: r.LastName, FirstNameOptional: r.FirstNameOptional,
+					LastNameOptional: r.LastNameOptional, Email: r.Email, PhoneNo: r.PhoneNo,
+				}),
+			},
+		}
+		for _, p := range info.PayInfo {
+			d.PayInfo = append(d.PayInfo, &linepaypb.PayInfo{Method: p.Method, Amount: int64(p.Amount)})
+		}
+		for _, refund := range info.RefundList {
+			d.RefundList = append(d.RefundList, &linepaypb.RefundListItem{
+				RefundTransactionId:   int64(refund.RefundTransactionID),
+				TransactionType:       refund.TransactionType,
+				RefundAmount:          int64(refund.RefundAmount),
+				RefundTransactionDate: timestamp(refund.RefundTransactionDate),
+			})
+		}
+		for _, p := range info.Packages {
+			pkg := &linepaypb.Package{Id: p.ID, Amount: int64(p.Amount), UserFee: int64(p.UserFeeAmount), Name: p.Name}
+			for _, product := range p.Products {
+				pkg.Products = append(pkg.Products, &linepaypb.Product{
+					Id:            product.ID,
+					Name:          product.Name,
+					ImageUrl:      product.ImageURL,
+					Quantity:      int64(product.Quantity),
+					Price:         int64(product.Price),
+					OriginalPrice: int64(product.OriginalPrice),
+				})
+			}
+			d.Packages = append(d.Packages, pkg)
+		}
+		out.Info = append(out.Info, d)
+	}
+	return out
+}
+
+var paymentStatus = map[string]linepaypb.PaymentStatus{
+	linepay.PaymentsStatusPending:    linepaypb.PaymentStatus_PAYMENT_STATUS_PENDING,
+	linepay.PaymentsStatusAuthorized: linepaypb.PaymentStatus_PAYMENT_STATUS_AUTHORIZED,
+	linepay.PaymentsStatusCanceled:   linepaypb.PaymentStatus_PAYMENT_STATUS_CANCELED,
+	linepay.PaymentsStatusFailed:     linepaypb.PaymentStatus_PAYMENT_STATUS_FAILED,
+	linepay.PaymentsStatusCompleted:  linepaypb.PaymentStatus_PAYMENT_STATUS_COMPLETED,
+}
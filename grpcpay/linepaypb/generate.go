@@ -0,0 +1,5 @@
+// Package linepaypb holds the protobuf messages and the gRPC service of linepay.proto, generated by protoc-gen-go
+// and protoc-gen-go-grpc.
+package linepaypb
+
+//go:generate protoc -I../.. --go_out=../.. --go_opt=paths=source_relative --go-grpc_out=../.. --go-grpc_opt=paths=source_relative grpcpay/linepaypb/linepay.proto
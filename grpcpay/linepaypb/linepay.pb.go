@@ -0,0 +1,3534 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v5.27.1
+// source: grpcpay/linepaypb/linepay.proto
+
+package linepaypb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type PaymentStatus int32
+
+const (
+	PaymentStatus_PAYMENT_STATUS_UNSPECIFIED PaymentStatus = 0
+	PaymentStatus_PAYMENT_STATUS_PENDING     PaymentStatus = 1 // waiting for the user to authorize the payment
+	PaymentStatus_PAYMENT_STATUS_AUTHORIZED  PaymentStatus = 2 // authorized by the user, call Confirm
+	PaymentStatus_PAYMENT_STATUS_CANCELED    PaymentStatus = 3 // canceled by the user or timeout
+	PaymentStatus_PAYMENT_STATUS_FAILED      PaymentStatus = 4
+	PaymentStatus_PAYMENT_STATUS_COMPLETED   PaymentStatus = 5 // completed by Confirm
+)
+
+// Enum value maps for PaymentStatus.
+var (
+	PaymentStatus_name = map[int32]string{
+		0: "PAYMENT_STATUS_UNSPECIFIED",
+		1: "PAYMENT_STATUS_PENDING",
+		2: "PAYMENT_STATUS_AUTHORIZED",
+		3: "PAYMENT_STATUS_CANCELED",
+		4: "PAYMENT_STATUS_FAILED",
+		5: "PAYMENT_STATUS_COMPLETED",
+	}
+	PaymentStatus_value = map[string]int32{
+		"PAYMENT_STATUS_UNSPECIFIED": 0,
+		"PAYMENT_STATUS_PENDING":     1,
+		"PAYMENT_STATUS_AUTHORIZED":  2,
+		"PAYMENT_STATUS_CANCELED":    3,
+		"PAYMENT_STATUS_FAILED":      4,
+		"PAYMENT_STATUS_COMPLETED":   5,
+	}
+)
+
+func (x PaymentStatus) Enum() *PaymentStatus {
+	p := new(PaymentStatus)
+	*p = x
+	return p
+}
+
+func (x PaymentStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PaymentStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_grpcpay_linepaypb_linepay_proto_enumTypes[0].Descriptor()
+}
+
+func (PaymentStatus) Type() protoreflect.EnumType {
+	return &file_grpcpay_linepaypb_linepay_proto_enumTypes[0]
+}
+
+func (x PaymentStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PaymentStatus.Descriptor instead.
+func (PaymentStatus) EnumDescriptor() ([]byte, []int) {
+	return file_grpcpay_linepaypb_linepay_proto_rawDescGZIP(), []int{0}
+}
+
+// amount must be the sum of the packages amount and user fees, plus the shipping fee
+type PaymentsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Amount       int64         `protobuf:"varint,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency     string        `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"` // ISO 4217: USD, JPY, TWD, THB
+	OrderId      string        `protobuf:"bytes,3,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Packages     []*Package    `protobuf:"bytes,4,rep,name=packages,proto3" json:"packages,omitempty"`
+	RedirectUrls *RedirectUrls `protobuf:"bytes,5,opt,name=redirect_urls,json=redirectUrls,proto3" json:"redirect_urls,omitempty"`
+	Options      *Options      `protobuf:"bytes,6,opt,name=options,proto3" json:"options,omitempty"`
+}
+
+func (x *PaymentsRequest) Reset() {
+	*x = PaymentsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PaymentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentsRequest) ProtoMessage() {}
+
+func (x *PaymentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaymentsRequest.ProtoReflect.Descriptor instead.
+func (*PaymentsRequest) Descriptor() ([]byte, []int) {
+	return file_grpcpay_linepaypb_linepay_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PaymentsRequest) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *PaymentsRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *PaymentsRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *PaymentsRequest) GetPackages() []*Package {
+	if x != nil {
+		return x.Packages
+	}
+	return nil
+}
+
+func (x *PaymentsRequest) GetRedirectUrls() *RedirectUrls {
+	if x != nil {
+		return x.RedirectUrls
+	}
+	return nil
+}
+
+func (x *PaymentsRequest) GetOptions() *Options {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+type Package struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id       string     `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Amount   int64      `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"` // sum of the products quantity * price
+	UserFee  int64      `protobuf:"varint,3,opt,name=user_fee,json=userFee,proto3" json:"user_fee,omitempty"`
+	Name     string     `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	Products []*Product `protobuf:"bytes,5,rep,name=products,proto3" json:"products,omitempty"`
+}
+
+func (x *Package) Reset() {
+	*x = Package{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Package) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Package) ProtoMessage() {}
+
+func (x *Package) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Package.ProtoReflect.Descriptor instead.
+func (*Package) Descriptor() ([]byte, []int) {
+	return file_grpcpay_linepaypb_linepay_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Package) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Package) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Package) GetUserFee() int64 {
+	if x != nil {
+		return x.UserFee
+	}
+	return 0
+}
+
+func (x *Package) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Package) GetProducts() []*Product {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+type Product struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id            string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	ImageUrl      string `protobuf:"bytes,3,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
+	Quantity      int64  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Price         int64  `protobuf:"varint,5,opt,name=price,proto3" json:"price,omitempty"`
+	OriginalPrice int64  `protobuf:"varint,6,opt,name=original_price,json=originalPrice,proto3" json:"original_price,omitempty"`
+}
+
+func (x *Product) Reset() {
+	*x = Product{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Product) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Product) ProtoMessage() {}
+
+func (x *Product) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Product.ProtoReflect.Descriptor instead.
+func (*Product) Descriptor() ([]byte, []int) {
+	return file_grpcpay_linepaypb_linepay_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Product) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Product) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Product) GetImageUrl() string {
+	if x != nil {
+		return x.ImageUrl
+	}
+	return ""
+}
+
+func (x *Product) GetQuantity() int64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *Product) GetPrice() int64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *Product) GetOriginalPrice() int64 {
+	if x != nil {
+		return x.OriginalPrice
+	}
+	return 0
+}
+
+type RedirectUrls struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AppPackageName string `protobuf:"bytes,1,opt,name=app_package_name,json=appPackageName,proto3" json:"app_package_name,omitempty"`
+	ConfirmUrl     string `protobuf:"bytes,2,opt,name=confirm_url,json=confirmUrl,proto3" json:"confirm_url,omitempty"`
+	ConfirmUrlType string `protobuf:"bytes,3,opt,name=confirm_url_type,json=confirmUrlType,proto3" json:"confirm_url_type,omitempty"` // CLIENT, SERVER, NONE
+	CancelUrl      string `protobuf:"bytes,4,opt,name=cancel_url,json=cancelUrl,proto3" json:"cancel_url,omitempty"`
+}
+
+func (x *RedirectUrls) Reset() {
+	*x = RedirectUrls{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RedirectUrls) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedirectUrls) ProtoMessage() {}
+
+func (x *RedirectUrls) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RedirectUrls.ProtoReflect.Descriptor instead.
+func (*RedirectUrls) Descriptor() ([]byte, []int) {
+	return file_grpcpay_linepaypb_linepay_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RedirectUrls) GetAppPackageName() string {
+	if x != nil {
+		return x.AppPackageName
+	}
+	return ""
+}
+
+func (x *RedirectUrls) GetConfirmUrl() string {
+	if x != nil {
+		return x.ConfirmUrl
+	}
+	return ""
+}
+
+func (x *RedirectUrls) GetConfirmUrlType() string {
+	if x != nil {
+		return x.ConfirmUrlType
+	}
+	return ""
+}
+
+func (x *RedirectUrls) GetCancelUrl() string {
+	if x != nil {
+		return x.CancelUrl
+	}
+	return ""
+}
+
+type Options struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Payment       *PaymentOptions       `protobuf:"bytes,1,opt,name=payment,proto3" json:"payment,omitempty"`
+	Display       *DisplayOptions       `protobuf:"bytes,2,opt,name=display,proto3" json:"display,omitempty"`
+	Shipping      *ShippingOptions      `protobuf:"bytes,3,opt,name=shipping,proto3" json:"shipping,omitempty"`
+	FamilyService *FamilyServiceOptions `protobuf:"bytes,4,opt,name=family_service,json=familyService,proto3" json:"family_service,omitempty"`
+	Extra         *ExtraOptions         `protobuf:"bytes,5,opt,name=extra,proto3" json:"extra,omitempty"`
+}
+
+func (x *Options) Reset() {
+	*x = Options{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Options) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Options) ProtoMessage() {}
+
+func (x *Options) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Options.ProtoReflect.Descriptor instead.
+func (*Options) Descriptor() ([]byte, []int) {
+	return file_grpcpay_linepaypb_linepay_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Options) GetPayment() *PaymentOptions {
+	if x != nil {
+		return x.Payment
+	}
+	return nil
+}
+
+func (x *Options) GetDisplay() *DisplayOptions {
+	if x != nil {
+		return x.Display
+	}
+	return nil
+}
+
+func (x *Options) GetShipping() *ShippingOptions {
+	if x != nil {
+		return x.Shipping
+	}
+	return nil
+}
+
+func (x *Options) GetFamilyService() *FamilyServiceOptions {
+	if x != nil {
+		return x.FamilyService
+	}
+	return nil
+}
+
+func (x *Options) GetExtra() *ExtraOptions {
+	if x != nil {
+		return x.Extra
+	}
+	return nil
+}
+
+type PaymentOptions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Capture bool   `protobuf:"varint,1,opt,name=capture,proto3" json:"capture,omitempty"`               // false: authorize at the confirm, capture later
+	PayType string `protobuf:"bytes,2,opt,name=pay_type,json=payType,proto3" json:"pay_type,omitempty"` // NORMAL, PREAPPROVED
+}
+
+func (x *PaymentOptions) Reset() {
+	*x = PaymentOptions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PaymentOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentOptions) ProtoMessage() {}
+
+func (x *PaymentOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaymentOptions.ProtoReflect.Descriptor instead.
+func (*PaymentOptions) Descriptor() ([]byte, []int) {
+	return file_grpcpay_linepaypb_linepay_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *PaymentOptions) GetCapture() bool {
+	if x != nil {
+		return x.Capture
+	}
+	return false
+}
+
+func (x *PaymentOptions) GetPayType() string {
+	if x != nil {
+		return x.PayType
+	}
+	return ""
+}
+
+type DisplayOptions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Locale                 string `protobuf:"bytes,1,opt,name=locale,proto3" json:"locale,omitempty"` // en, ja, ko, th, zh_TW, zh_CN
+	CheckConfirmUrlBrowser bool   `protobuf:"varint,2,opt,name=check_confirm_url_browser,json=checkConfirmUrlBrowser,proto3" json:"check_confirm_url_browser,omitempty"`
+}
+
+func (x *DisplayOptions) Reset() {
+	*x = DisplayOptions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DisplayOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisplayOptions) ProtoMessage() {}
+
+func (x *DisplayOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisplayOptions.ProtoReflect.Descriptor instead.
+func (*DisplayOptions) Descriptor() ([]byte, []int) {
+	return file_grpcpay_linepaypb_linepay_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DisplayOptions) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *DisplayOptions) GetCheckConfirmUrlBrowser() bool {
+	if x != nil {
+		return x.CheckConfirmUrlBrowser
+	}
+	return false
+}
+
+type ShippingOptions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type           string   `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"` // NO_SHIPPING, FIXED_ADDRESS, SHIPPING
+	FeeAmount      string   `protobuf:"bytes,2,opt,name=fee_amount,json=feeAmount,proto3" json:"fee_amount,omitempty"`
+	FeeInquiryUrl  string   `protobuf:"bytes,3,opt,name=fee_inquiry_url,json=feeInquiryUrl,proto3" json:"fee_inquiry_url,omitempty"`
+	FeeInquiryType string   `protobuf:"bytes,4,opt,name=fee_inquiry_type,json=feeInquiryType,proto3" json:"fee_inquiry_type,omitempty"` // CONDITION, FIXED
+	Address        *Address `protobuf:"bytes,5,opt,name=address,proto3" json:"address,omitempty"`
+}
+
+func (x *ShippingOptions) Reset() {
+	*x = ShippingOptions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ShippingOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShippingOptions) ProtoMessage() {}
+
+func (x *ShippingOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShippingOptions.ProtoReflect.Descriptor instead.
+func (*ShippingOptions) Descriptor() ([]byte, []int) {
+	return file_grpcpay_linepaypb_linepay_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ShippingOptions) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ShippingOptions) GetFeeAmount() string {
+	if x != nil {
+		return x.FeeAmount
+	}
+	return ""
+}
+
+func (x *ShippingOptions) GetFeeInquiryUrl() string {
+	if x != nil {
+		return x.FeeInquiryUrl
+	}
+	return ""
+}
+
+func (x *ShippingOptions) GetFeeInquiryType() string {
+	if x != nil {
+		return x.FeeInquiryType
+	}
+	return ""
+}
+
+func (x *ShippingOptions) GetAddress() *Address {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+type Address struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Country    string     `protobuf:"bytes,1,opt,name=country,proto3" json:"country,omitempty"`
+	PostalCode string     `protobuf:"bytes,2,opt,name=postal_code,json=postalCode,proto3" json:"postal_code,omitempty"`
+	State      string     `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+	City       string     `protobuf:"bytes,4,opt,name=city,proto3" json:"city,omitempty"`
+	Detail     string     `protobuf:"bytes,5,opt,name=detail,proto3" json:"detail,omitempty"`
+	Optional   string     `protobuf:"bytes,6,opt,name=optional,proto3" json:"optional,omitempty"`
+	Recipient  *Recipient `protobuf:"bytes,7,opt,name=recipient,proto3" json:"recipient,omitempty"`
+}
+
+func (x *Address) Reset() {
+	*x = Address{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Address) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Address) ProtoMessage() {}
+
+func (x *Address) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Address.ProtoReflect.Descriptor instead.
+func (*Address) Descriptor() ([]byte, []int) {
+	return file_grpcpay_linepaypb_linepay_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Address) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *Address) GetPostalCode() string {
+	if x != nil {
+		return x.PostalCode
+	}
+	return ""
+}
+
+func (x *Address) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *Address) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *Address) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+func (x *Address) GetOptional() string {
+	if x != nil {
+		return x.Optional
+	}
+	return ""
+}
+
+func (x *Address) GetRecipient() *Recipient {
+	if x != nil {
+		return x.Recipient
+	}
+	return nil
+}
+
+type Recipient struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FirstName         string `protobuf:"bytes,1,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
+	LastName          string `protobuf:"bytes,2,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
+	FirstNameOptional string `protobuf:"bytes,3,opt,name=first_name_optional,json=firstNameOptional,proto3" json:"first_name_optional,omitempty"`
+	LastNameOptional  string `protobuf:"bytes,4,opt,name=last_name_optional,json=lastNameOptional,proto3" json:"last_name_optional,omitempty"`
+	Email             string `protobuf:"bytes,5,opt,name=email,proto3" json:"email,omitempty"`
+	PhoneNo           string `protobuf:"bytes,6,opt,name=phone_no,json=phoneNo,proto3" json:"phone_no,omitempty"`
+}
+
+func (x *Recipient) Reset() {
+	*x = Recipient{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Recipient) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Recipient) ProtoMessage() {}
+
+func (x *Recipient) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Recipient.ProtoReflect.Descriptor instead.
+func (*Recipient) Descriptor() ([]byte, []int) {
+	return file_grpcpay_linepaypb_linepay_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Recipient) GetFirstName() string {
+	if x != nil {
+		return x.FirstName
+	}
+	return ""
+}
+
+func (x *Recipient) GetLastName() string {
+	if x != nil {
+		return x.LastName
+	}
+	return ""
+}
+
+func (x *Recipient) GetFirstNameOptional() string {
+	if x != nil {
+		return x.FirstNameOptional
+	}
+	return ""
+}
+
+func (x *Recipient) GetLastNameOptional() string {
+	if x != nil {
+		return x.LastNameOptional
+	}
+	return ""
+}
+
+func (x *Recipient) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *Recipient) GetPhoneNo() string {
+	if x != nil {
+		return x.PhoneNo
+	}
+	return ""
+}
+
+type FamilyServiceOptions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AddFriends []*AddFriends `protobuf:"bytes,1,rep,name=add_friends,json=addFriends,proto3" json:"add_friends,omitempty"`
+}
+
+func (x *FamilyServiceOptions) Reset() {
+	*x = FamilyServiceOptions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FamilyServiceOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FamilyServiceOptions) ProtoMessage() {}
+
+func (x *FamilyServiceOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FamilyServiceOptions.ProtoReflect.Descriptor instead.
+func (*FamilyServiceOptions) Descriptor() ([]byte, []int) {
+	return file_grpcpay_linepaypb_linepay_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *FamilyServiceOptions) GetAddFriends() []*AddFriends {
+	if x != nil {
+		return x.AddFriends
+	}
+	return nil
+}
+
+type AddFriends struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type string   `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"` // line@
+	Ids  []string `protobuf:"bytes,2,rep,name=ids,proto3" json:"ids,omitempty"`
+}
+
+func (x *AddFriends) Reset() {
+	*x = AddFriends{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddFriends) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddFriends) ProtoMessage() {}
+
+func (x *AddFriends) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddFriends.ProtoReflect.Descriptor instead.
+func (*AddFriends) Descriptor() ([]byte, []int) {
+	return file_grpcpay_linepaypb_linepay_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *AddFriends) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *AddFriends) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type ExtraOptions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BranchName string `protobuf:"bytes,1,opt,name=branch_name,json=branchName,proto3" json:"branch_name,omitempty"`
+	BranchId   string `protobuf:"bytes,2,opt,name=branch_id,json=branchId,proto3" json:"branch_id,omitempty"`
+}
+
+func (x *ExtraOptions) Reset() {
+	*x = ExtraOptions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExtraOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExtraOptions) ProtoMessage() {}
+
+func (x *ExtraOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExtraOptions.ProtoReflect.Descriptor instead.
+func (*ExtraOptions) Descriptor() ([]byte, []int) {
+	return file_grpcpay_linepaypb_linepay_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ExtraOptions) GetBranchName() string {
+	if x != nil {
+		return x.BranchName
+	}
+	return ""
+}
+
+func (x *ExtraOptions) GetBranchId() string {
+	if x != nil {
+		return x.BranchId
+	}
+	return ""
+}
+
+type PaymentsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ReturnCode    string        `protobuf:"bytes,1,opt,name=return_code,json=returnCode,proto3" json:"return_code,omitempty"`
+	ReturnMessage string        `protobuf:"bytes,2,opt,name=return_message,json=returnMessage,proto3" json:"return_message,omitempty"`
+	Info          *PaymentsInfo `protobuf:"bytes,3,opt,name=info,proto3" json:"info,omitempty"`
+}
+
+func (x *PaymentsResponse) Reset() {
+	*x = PaymentsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PaymentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentsResponse) ProtoMessage() {}
+
+func (x *PaymentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaymentsResponse.ProtoReflect.Descriptor instead.
+func (*PaymentsResponse) Descriptor() ([]byte, []int) {
+	return file_grpcpay_linepaypb_linepay_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *PaymentsResponse) GetReturnCode() string {
+	if x != nil {
+		return x.ReturnCode
+	}
+	return ""
+}
+
+func (x *PaymentsResponse) GetReturnMessage() string {
+	if x != nil {
+		return x.ReturnMessage
+	}
+	return ""
+}
+
+func (x *PaymentsResponse) GetInfo() *PaymentsInfo {
+	if x != nil {
+		return x.Info
+	}
+	return nil
+}
+
+type PaymentsInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TransactionId      int64       `protobuf:"varint,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	PaymentAccessToken string      `protobuf:"bytes,2,opt,name=payment_access_token,json=paymentAccessToken,proto3" json:"payment_access_token,omitempty"`
+	PaymentUrl         *PaymentUrl `protobuf:"bytes,3,opt,name=payment_url,json=paymentUrl,proto3" json:"payment_url,omitempty"`
+}
+
+func (x *PaymentsInfo) Reset() {
+	*x = PaymentsInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PaymentsInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentsInfo) ProtoMessage() {}
+
+func (x *PaymentsInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaymentsInfo.ProtoReflect.Descriptor instead.
+func (*PaymentsInfo) Descriptor() ([]byte, []int) {
+	return file_grpcpay_linepaypb_linepay_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *PaymentsInfo) GetTransactionId() int64 {
+	if x != nil {
+		return x.TransactionId
+	}
+	return 0
+}
+
+func (x *PaymentsInfo) GetPaymentAccessToken() string {
+	if x != nil {
+		return x.PaymentAccessToken
+	}
+	return ""
+}
+
+func (x *PaymentsInfo) GetPaymentUrl() *PaymentUrl {
+	if x != nil {
+		return x.PaymentUrl
+	}
+	return nil
+}
+
+type PaymentUrl struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Web string `protobuf:"bytes,1,opt,name=web,proto3" json:"web,omitempty"`
+	App string `protobuf:"bytes,2,opt,name=app,proto3" json:"app,omitempty"`
+}
+
+func (x *PaymentUrl) Reset() {
+	*x = PaymentUrl{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PaymentUrl) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentUrl) ProtoMessage() {}
+
+func (x *PaymentUrl) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaymentUrl.ProtoReflect.Descriptor instead.
+func (*PaymentUrl) Descriptor() ([]byte, []int) {
+	return file_grpcpay_linepaypb_linepay_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *PaymentUrl) GetWeb() string {
+	if x != nil {
+		return x.Web
+	}
+	return ""
+}
+
+func (x *PaymentUrl) GetApp() string {
+	if x != nil {
+		return x.App
+	}
+	return ""
+}
+
+type PaymentsConfirmRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TransactionId int64  `protobuf:"varint,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	Amount        int64  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency      string `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+}
+
+func (x *PaymentsConfirmRequest) Reset() {
+	*x = PaymentsConfirmRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PaymentsConfirmRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentsConfirmRequest) ProtoMessage() {}
+
+func (x *PaymentsConfirmRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaymentsConfirmRequest.ProtoReflect.Descriptor instead.
+func (*PaymentsConfirmRequest) Descriptor() ([]byte, []int) {
+	return file_grpcpay_linepaypb_linepay_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *PaymentsConfirmRequest) GetTransactionId() int64 {
+	if x != nil {
+		return x.TransactionId
+	}
+	return 0
+}
+
+func (x *PaymentsConfirmRequest) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *PaymentsConfirmRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type PaymentsConfirmResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ReturnCode    string       `protobuf:"bytes,1,opt,name=return_code,json=returnCode,proto3" json:"return_code,omitempty"`
+	ReturnMessage string       `protobuf:"bytes,2,opt,name=return_message,json=returnMessage,proto3" json:"return_message,omitempty"`
+	Info          *ConfirmInfo `protobuf:"bytes,3,opt,name=info,proto3" json:"info,omitempty"`
+}
+
+func (x *PaymentsConfirmResponse) Reset() {
+	*x = PaymentsConfirmResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PaymentsConfirmResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentsConfirmResponse) ProtoMessage() {}
+
+func (x *PaymentsConfirmResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaymentsConfirmResponse.ProtoReflect.Descriptor instead.
+func (*PaymentsConfirmResponse) Descriptor() ([]byte, []int) {
+	return file_grpcpay_linepaypb_linepay_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *PaymentsConfirmResponse) GetReturnCode() string {
+	if x != nil {
+		return x.ReturnCode
+	}
+	return ""
+}
+
+func (x *PaymentsConfirmResponse) GetReturnMessage() string {
+	if x != nil {
+		return x.ReturnMessage
+	}
+	return ""
+}
+
+func (x *PaymentsConfirmResponse) GetInfo() *ConfirmInfo {
+	if x != nil {
+		return x.Info
+	}
+	return nil
+}
+
+type ConfirmInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OrderId                 string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	TransactionId           int64                  `protobuf:"varint,2,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	AuthorizationExpireDate *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=authorization_expire_date,json=authorizationExpireDate,proto3" json:"authorization_expire_date,omitempty"` // set when not captured
+	RegKey                  string                 `protobuf:"bytes,4,opt,name=reg_key,json=regKey,proto3" json:"reg_key,omitempty"`                                                      // preapproved payments only, a credential: do not log it
+	PayInfo                 []*PayInfo             `protobuf:"bytes,5,rep,name=pay_info,json=payInfo,proto3" json:"pay_info,omitempty"`
+	Packages                []*PackageAmount       `protobuf:"bytes,6,rep,name=packages,proto3" json:"packages,omitempty"`
+	Shipping                *Shipping              `protobuf:"bytes,7,opt,name=shipping,proto3" json:"shipping,omitempty"`
+}
+
+func (x *ConfirmInfo) Reset() {
+	*x = ConfirmInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConfirmInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmInfo) ProtoMessage() {}
+
+func (x *ConfirmInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmInfo.ProtoReflect.Descriptor instead.
+func (*ConfirmInfo) Descriptor() ([]byte, []int) {
+	return file_grpcpay_linepaypb_linepay_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ConfirmInfo) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *ConfirmInfo) GetTransactionId() int64 {
+	if x != nil {
+		return x.TransactionId
+	}
+	return 0
+}
+
+func (x *ConfirmInfo) GetAuthorizationExpireDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AuthorizationExpireDate
+	}
+	return nil
+}
+
+func (x *ConfirmInfo) GetRegKey() string {
+	if x != nil {
+		return x.RegKey
+	}
+	return ""
+}
+
+func (x *ConfirmInfo) GetPayInfo() []*PayInfo {
+	if x != nil {
+		return x.PayInfo
+	}
+	return nil
+}
+
+func (x *ConfirmInfo) GetPackages() []*PackageAmount {
+	if x != nil {
+		return x.Packages
+	}
+	return nil
+}
+
+func (x *ConfirmInfo) GetShipping() *Shipping {
+	if x != nil {
+		return x.Shipping
+	}
+	return nil
+}
+
+type PayInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Method                 string `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"` // CREDIT_CARD, BALANCE, DISCOUNT
+	Amount                 int64  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	CreditCardNickname     string `protobuf:"bytes,3,opt,name=credit_card_nickname,json=creditCardNickname,proto3" json:"credit_card_nickname,omitempty"`
+	CreditCardBrand        string `protobuf:"bytes,4,opt,name=credit_card_brand,json=creditCardBrand,proto3" json:"credit_card_brand,omitempty"` // VISA, MASTER, AMEX, DINERS, JCB
+	MaskedCreditCardNumber string `protobuf:"bytes,5,opt,name=masked_credit_card_number,json=maskedCreditCardNumber,proto3" json:"masked_credit_card_number,omitempty"`
+}
+
+func (x *PayInfo) Reset() {
+	*x = PayInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PayInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PayInfo) ProtoMessage() {}
+
+func (x *PayInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PayInfo.ProtoReflect.Descriptor instead.
+func (*PayInfo) Descriptor() ([]byte, []int) {
+	return file_grpcpay_linepaypb_linepay_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *PayInfo) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *PayInfo) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *PayInfo) GetCreditCardNickname() string {
+	if x != nil {
+		return x.CreditCardNickname
+	}
+	return ""
+}
+
+func (x *PayInfo) GetCreditCardBrand() string {
+	if x != nil {
+		return x.CreditCardBrand
+	}
+	return ""
+}
+
+func (x *PayInfo) GetMaskedCreditCardNumber() string {
+	if x != nil {
+		return x.MaskedCreditCardNumber
+	}
+	return ""
+}
+
+type PackageAmount struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id            string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Amount        int64  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	UserFeeAmount int64  `protobuf:"varint,3,opt,name=user_fee_amount,json=userFeeAmount,proto3" json:"user_fee_amount,omitempty"`
+}
+
+func (x *PackageAmount) Reset() {
+	*x = PackageAmount{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PackageAmount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PackageAmount) ProtoMessage() {}
+
+func (x *PackageAmount) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PackageAmount.ProtoReflect.Descriptor instead.
+func (*PackageAmount) Descriptor() ([]byte, []int) {
+	return file_grpcpay_linepaypb_linepay_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *PackageAmount) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PackageAmount) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *PackageAmount) GetUserFeeAmount() int64 {
+	if x != nil {
+		return x.UserFeeAmount
+	}
+	return 0
+}
+
+type Shipping struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MethodId  string   `protobuf:"bytes,1,opt,name=method_id,json=methodId,proto3" json:"method_id,omitempty"`
+	FeeAmount int64    `protobuf:"varint,2,opt,name=fee_amount,json=feeAmount,proto3" json:"fee_amount,omitempty"`
+	Address   *Address `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+}
+
+func (x *Shipping) Reset() {
+	*x = Shipping{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Shipping) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Shipping) ProtoMessage() {}
+
+func (x *Shipping) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Shipping.ProtoReflect.Descriptor instead.
+func (*Shipping) Descriptor() ([]byte, []int) {
+	return file_grpcpay_linepaypb_linepay_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *Shipping) GetMethodId() string {
+	if x != nil {
+		return x.MethodId
+	}
+	return ""
+}
+
+func (x *Shipping) GetFeeAmount() int64 {
+	if x != nil {
+		return x.FeeAmount
+	}
+	return 0
+}
+
+func (x *Shipping) GetAddress() *Address {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+type PaymentsCaptureRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TransactionId int64  `protobuf:"varint,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	Amount        int64  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency      string `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+}
+
+func (x *PaymentsCaptureRequest) Reset() {
+	*x = PaymentsCaptureRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PaymentsCaptureRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentsCaptureRequest) ProtoMessage() {}
+
+func (x *PaymentsCaptureRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaymentsCaptureRequest.ProtoReflect.Descriptor instead.
+func (*PaymentsCaptureRequest) Descriptor() ([]byte, []int) {
+	return file_grpcpay_linepaypb_linepay_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *PaymentsCaptureRequest) GetTransactionId() int64 {
+	if x != nil {
+		return x.TransactionId
+	}
+	return 0
+}
+
+func (x *PaymentsCaptureRequest) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *PaymentsCaptureRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type PaymentsCaptureResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ReturnCode    string       `protobuf:"bytes,1,opt,name=return_code,json=returnCode,proto3" json:"return_code,omitempty"`
+	ReturnMessage string       `protobuf:"bytes,2,opt,name=return_message,json=returnMessage,proto3" json:"return_message,omitempty"`
+	Info          *CaptureInfo `protobuf:"bytes,3,opt,name=info,proto3" json:"info,omitempty"`
+}
+
+func (x *PaymentsCaptureResponse) Reset() {
+	*x = PaymentsCaptureResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PaymentsCaptureResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentsCaptureResponse) ProtoMessage() {}
+
+func (x *PaymentsCaptureResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaymentsCaptureResponse.ProtoReflect.Descriptor instead.
+func (*PaymentsCaptureResponse) Descriptor() ([]byte, []int) {
+	return file_grpcpay_linepaypb_linepay_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *PaymentsCaptureResponse) GetReturnCode() string {
+	if x != nil {
+		return x.ReturnCode
+	}
+	return ""
+}
+
+func (x *PaymentsCaptureResponse) GetReturnMessage() string {
+	if x != nil {
+		return x.ReturnMessage
+	}
+	return ""
+}
+
+func (x *PaymentsCaptureResponse) GetInfo() *CaptureInfo {
+	if x != nil {
+		return x.Info
+	}
+	return nil
+}
+
+type CaptureInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TransactionId int64      `protobuf:"varint,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	OrderId       string     `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	PayInfo       []*PayInfo `protobuf:"bytes,3,rep,name=pay_info,json=payInfo,proto3" json:"pay_info,omitempty"`
+}
+
+func (x *CaptureInfo) Reset() {
+	*x = CaptureInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CaptureInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CaptureInfo) ProtoMessage() {}
+
+func (x *CaptureInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CaptureInfo.ProtoReflect.Descriptor instead.
+func (*CaptureInfo) Descriptor() ([]byte, []int) {
+	return file_grpcpay_linepaypb_linepay_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *CaptureInfo) GetTransactionId() int64 {
+	if x != nil {
+		return x.TransactionId
+	}
+	return 0
+}
+
+func (x *CaptureInfo) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *CaptureInfo) GetPayInfo() []*PayInfo {
+	if x != nil {
+		return x.PayInfo
+	}
+	return nil
+}
+
+type PaymentsVoidRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TransactionId int64 `protobuf:"varint,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+}
+
+func (x *PaymentsVoidRequest) Reset() {
+	*x = PaymentsVoidRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PaymentsVoidRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentsVoidRequest) ProtoMessage() {}
+
+func (x *PaymentsVoidRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaymentsVoidRequest.ProtoReflect.Descriptor instead.
+func (*PaymentsVoidRequest) Descriptor() ([]byte, []int) {
+	return file_grpcpay_linepaypb_linepay_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *PaymentsVoidRequest) GetTransactionId() int64 {
+	if x != nil {
+		return x.TransactionId
+	}
+	return 0
+}
+
+type PaymentsVoidResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ReturnCode    string `protobuf:"bytes,1,opt,name=return_code,json=returnCode,proto3" json:"return_code,omitempty"`
+	ReturnMessage string `protobuf:"bytes,2,opt,name=return_message,json=returnMessage,proto3" json:"return_message,omitempty"`
+}
+
+func (x *PaymentsVoidResponse) Reset() {
+	*x = PaymentsVoidResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PaymentsVoidResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentsVoidResponse) ProtoMessage() {}
+
+func (x *PaymentsVoidResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaymentsVoidResponse.ProtoReflect.Descriptor instead.
+func (*PaymentsVoidResponse) Descriptor() ([]byte, []int) {
+	return file_grpcpay_linepaypb_linepay_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *PaymentsVoidResponse) GetReturnCode() string {
+	if x != nil {
+		return x.ReturnCode
+	}
+	return ""
+}
+
+func (x *PaymentsVoidResponse) GetReturnMessage() string {
+	if x != nil {
+		return x.ReturnMessage
+	}
+	return ""
+}
+
+type PaymentsRefundRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TransactionId int64 `protobuf:"varint,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	RefundAmount  int64 `protobuf:"varint,2,opt,name=refund_amount,json=refundAmount,proto3" json:"refund_amount,omitempty"` // the full amount when 0
+}
+
+func (x *PaymentsRefundRequest) Reset() {
+	*x = PaymentsRefundRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PaymentsRefundRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentsRefundRequest) ProtoMessage() {}
+
+func (x *PaymentsRefundRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaymentsRefundRequest.ProtoReflect.Descriptor instead.
+func (*PaymentsRefundRequest) Descriptor() ([]byte, []int) {
+	return file_grpcpay_linepaypb_linepay_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *PaymentsRefundRequest) GetTransactionId() int64 {
+	if x != nil {
+		return x.TransactionId
+	}
+	return 0
+}
+
+func (x *PaymentsRefundRequest) GetRefundAmount() int64 {
+	if x != nil {
+		return x.RefundAmount
+	}
+	return 0
+}
+
+type PaymentsRefundResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ReturnCode    string      `protobuf:"bytes,1,opt,name=return_code,json=returnCode,proto3" json:"return_code,omitempty"`
+	ReturnMessage string      `protobuf:"bytes,2,opt,name=return_message,json=returnMessage,proto3" json:"return_message,omitempty"`
+	Info          *RefundInfo `protobuf:"bytes,3,opt,name=info,proto3" json:"info,omitempty"`
+}
+
+func (x *PaymentsRefundResponse) Reset() {
+	*x = PaymentsRefundResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PaymentsRefundResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentsRefundResponse) ProtoMessage() {}
+
+func (x *PaymentsRefundResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaymentsRefundResponse.ProtoReflect.Descriptor instead.
+func (*PaymentsRefundResponse) Descriptor() ([]byte, []int) {
+	return file_grpcpay_linepaypb_linepay_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *PaymentsRefundResponse) GetReturnCode() string {
+	if x != nil {
+		return x.ReturnCode
+	}
+	return ""
+}
+
+func (x *PaymentsRefundResponse) GetReturnMessage() string {
+	if x != nil {
+		return x.ReturnMessage
+	}
+	return ""
+}
+
+func (x *PaymentsRefundResponse) GetInfo() *RefundInfo {
+	if x != nil {
+		return x.Info
+	}
+	return nil
+}
+
+type RefundInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RefundTransactionId   int64                  `protobuf:"varint,1,opt,name=refund_transaction_id,json=refundTransactionId,proto3" json:"refund_transaction_id,omitempty"`
+	RefundTransactionDate *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=refund_transaction_date,json=refundTransactionDate,proto3" json:"refund_transaction_date,omitempty"`
+}
+
+func (x *RefundInfo) Reset() {
+	*x = RefundInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RefundInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundInfo) ProtoMessage() {}
+
+func (x *RefundInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundInfo.ProtoReflect.Descriptor instead.
+func (*RefundInfo) Descriptor() ([]byte, []int) {
+	return file_grpcpay_linepaypb_linepay_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *RefundInfo) GetRefundTransactionId() int64 {
+	if x != nil {
+		return x.RefundTransactionId
+	}
+	return 0
+}
+
+func (x *RefundInfo) GetRefundTransactionDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RefundTransactionDate
+	}
+	return nil
+}
+
+type PaymentsDetailsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TransactionIds []int64  `protobuf:"varint,1,rep,packed,name=transaction_ids,json=transactionIds,proto3" json:"transaction_ids,omitempty"`
+	OrderIds       []string `protobuf:"bytes,2,rep,name=order_ids,json=orderIds,proto3" json:"order_ids,omitempty"`
+	Fields         string   `protobuf:"bytes,3,opt,name=fields,proto3" json:"fields,omitempty"` // TRANSACTION, ORDER, ALL when empty
+}
+
+func (x *PaymentsDetailsRequest) Reset() {
+	*x = PaymentsDetailsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PaymentsDetailsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentsDetailsRequest) ProtoMessage() {}
+
+func (x *PaymentsDetailsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaymentsDetailsRequest.ProtoReflect.Descriptor instead.
+func (*PaymentsDetailsRequest) Descriptor() ([]byte, []int) {
+	return file_grpcpay_linepaypb_linepay_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *PaymentsDetailsRequest) GetTransactionIds() []int64 {
+	if x != nil {
+		return x.TransactionIds
+	}
+	return nil
+}
+
+func (x *PaymentsDetailsRequest) GetOrderIds() []string {
+	if x != nil {
+		return x.OrderIds
+	}
+	return nil
+}
+
+func (x *PaymentsDetailsRequest) GetFields() string {
+	if x != nil {
+		return x.Fields
+	}
+	return ""
+}
+
+type PaymentsDetailsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ReturnCode    string         `protobuf:"bytes,1,opt,name=return_code,json=returnCode,proto3" json:"return_code,omitempty"`
+	ReturnMessage string         `protobuf:"bytes,2,opt,name=return_message,json=returnMessage,proto3" json:"return_message,omitempty"`
+	Info          []*DetailsInfo `protobuf:"bytes,3,rep,name=info,proto3" json:"info,omitempty"`
+}
+
+func (x *PaymentsDetailsResponse) Reset() {
+	*x = PaymentsDetailsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PaymentsDetailsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentsDetailsResponse) ProtoMessage() {}
+
+func (x *PaymentsDetailsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaymentsDetailsResponse.ProtoReflect.Descriptor instead.
+func (*PaymentsDetailsResponse) Descriptor() ([]byte, []int) {
+	return file_grpcpay_linepaypb_linepay_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *PaymentsDetailsResponse) GetReturnCode() string {
+	if x != nil {
+		return x.ReturnCode
+	}
+	return ""
+}
+
+func (x *PaymentsDetailsResponse) GetReturnMessage() string {
+	if x != nil {
+		return x.ReturnMessage
+	}
+	return ""
+}
+
+func (x *PaymentsDetailsResponse) GetInfo() []*DetailsInfo {
+	if x != nil {
+		return x.Info
+	}
+	return nil
+}
+
+type DetailsInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TransactionId           int64                  `protobuf:"varint,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	OrderId                 string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	TransactionDate         *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=transaction_date,json=transactionDate,proto3" json:"transaction_date,omitempty"`
+	TransactionType         string                 `protobuf:"bytes,4,opt,name=transaction_type,json=transactionType,proto3" json:"transaction_type,omitempty"` // PAYMENT, PAYMENT_REFUND, PARTIAL_REFUND
+	PayStatus               string                 `protobuf:"bytes,5,opt,name=pay_status,json=payStatus,proto3" json:"pay_status,omitempty"`                   // AUTHORIZATION, VOIDED_AUTHORIZATION, EXPIRED_AUTHORIZATION
+	ProductName             string                 `protobuf:"bytes,6,opt,name=product_name,json=productName,proto3" json:"product_name,omitempty"`
+	MerchantName            string                 `protobuf:"bytes,7,opt,name=merchant_name,json=merchantName,proto3" json:"merchant_name,omitempty"`
+	Currency                string                 `protobuf:"bytes,8,opt,name=currency,proto3" json:"currency,omitempty"`
+	AuthorizationExpireDate *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=authorization_expire_date,json=authorizationExpireDate,proto3" json:"authorization_expire_date,omitempty"`
+	PayInfo                 []*PayInfo             `protobuf:"bytes,10,rep,name=pay_info,json=payInfo,proto3" json:"pay_info,omitempty"`
+	RefundList              []*RefundListItem      `protobuf:"bytes,11,rep,name=refund_list,json=refundList,proto3" json:"refund_list,omitempty"`
+	OriginalTransactionId   int64                  `protobuf:"varint,12,opt,name=original_transaction_id,json=originalTransactionId,proto3" json:"original_transaction_id,omitempty"` // refund transactions only
+	Packages                []*Package             `protobuf:"bytes,13,rep,name=packages,proto3" json:"packages,omitempty"`
+	Shipping                *Shipping              `protobuf:"bytes,14,opt,name=shipping,proto3" json:"shipping,omitempty"`
+}
+
+func (x *DetailsInfo) Reset() {
+	*x = DetailsInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DetailsInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DetailsInfo) ProtoMessage() {}
+
+func (x *DetailsInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DetailsInfo.ProtoReflect.Descriptor instead.
+func (*DetailsInfo) Descriptor() ([]byte, []int) {
+	return file_grpcpay_linepaypb_linepay_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *DetailsInfo) GetTransactionId() int64 {
+	if x != nil {
+		return x.TransactionId
+	}
+	return 0
+}
+
+func (x *DetailsInfo) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *DetailsInfo) GetTransactionDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TransactionDate
+	}
+	return nil
+}
+
+func (x *DetailsInfo) GetTransactionType() string {
+	if x != nil {
+		return x.TransactionType
+	}
+	return ""
+}
+
+func (x *DetailsInfo) GetPayStatus() string {
+	if x != nil {
+		return x.PayStatus
+	}
+	return ""
+}
+
+func (x *DetailsInfo) GetProductName() string {
+	if x != nil {
+		return x.ProductName
+	}
+	return ""
+}
+
+func (x *DetailsInfo) GetMerchantName() string {
+	if x != nil {
+		return x.MerchantName
+	}
+	return ""
+}
+
+func (x *DetailsInfo) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *DetailsInfo) GetAuthorizationExpireDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AuthorizationExpireDate
+	}
+	return nil
+}
+
+func (x *DetailsInfo) GetPayInfo() []*PayInfo {
+	if x != nil {
+		return x.PayInfo
+	}
+	return nil
+}
+
+func (x *DetailsInfo) GetRefundList() []*RefundListItem {
+	if x != nil {
+		return x.RefundList
+	}
+	return nil
+}
+
+func (x *DetailsInfo) GetOriginalTransactionId() int64 {
+	if x != nil {
+		return x.OriginalTransactionId
+	}
+	return 0
+}
+
+func (x *DetailsInfo) GetPackages() []*Package {
+	if x != nil {
+		return x.Packages
+	}
+	return nil
+}
+
+func (x *DetailsInfo) GetShipping() *Shipping {
+	if x != nil {
+		return x.Shipping
+	}
+	return nil
+}
+
+type RefundListItem struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RefundTransactionId   int64                  `protobuf:"varint,1,opt,name=refund_transaction_id,json=refundTransactionId,proto3" json:"refund_transaction_id,omitempty"`
+	TransactionType       string                 `protobuf:"bytes,2,opt,name=transaction_type,json=transactionType,proto3" json:"transaction_type,omitempty"` // PAYMENT_REFUND, PARTIAL_REFUND
+	RefundAmount          int64                  `protobuf:"varint,3,opt,name=refund_amount,json=refundAmount,proto3" json:"refund_amount,omitempty"`
+	RefundTransactionDate *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=refund_transaction_date,json=refundTransactionDate,proto3" json:"refund_transaction_date,omitempty"`
+}
+
+func (x *RefundListItem) Reset() {
+	*x = RefundListItem{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RefundListItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundListItem) ProtoMessage() {}
+
+func (x *RefundListItem) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundListItem.ProtoReflect.Descriptor instead.
+func (*RefundListItem) Descriptor() ([]byte, []int) {
+	return file_grpcpay_linepaypb_linepay_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *RefundListItem) GetRefundTransactionId() int64 {
+	if x != nil {
+		return x.RefundTransactionId
+	}
+	return 0
+}
+
+func (x *RefundListItem) GetTransactionType() string {
+	if x != nil {
+		return x.TransactionType
+	}
+	return ""
+}
+
+func (x *RefundListItem) GetRefundAmount() int64 {
+	if x != nil {
+		return x.RefundAmount
+	}
+	return 0
+}
+
+func (x *RefundListItem) GetRefundTransactionDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RefundTransactionDate
+	}
+	return nil
+}
+
+type PaymentsStatusRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TransactionId int64 `protobuf:"varint,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+}
+
+func (x *PaymentsStatusRequest) Reset() {
+	*x = PaymentsStatusRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PaymentsStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentsStatusRequest) ProtoMessage() {}
+
+func (x *PaymentsStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaymentsStatusRequest.ProtoReflect.Descriptor instead.
+func (*PaymentsStatusRequest) Descriptor() ([]byte, []int) {
+	return file_grpcpay_linepaypb_linepay_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *PaymentsStatusRequest) GetTransactionId() int64 {
+	if x != nil {
+		return x.TransactionId
+	}
+	return 0
+}
+
+type PaymentsStatusResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ReturnCode    string        `protobuf:"bytes,1,opt,name=return_code,json=returnCode,proto3" json:"return_code,omitempty"`
+	ReturnMessage string        `protobuf:"bytes,2,opt,name=return_message,json=returnMessage,proto3" json:"return_message,omitempty"`
+	Status        PaymentStatus `protobuf:"varint,3,opt,name=status,proto3,enum=linepay.v1.PaymentStatus" json:"status,omitempty"`
+}
+
+func (x *PaymentsStatusResponse) Reset() {
+	*x = PaymentsStatusResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PaymentsStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentsStatusResponse) ProtoMessage() {}
+
+func (x *PaymentsStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_grpcpay_linepaypb_linepay_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaymentsStatusResponse.ProtoReflect.Descriptor instead.
+func (*PaymentsStatusResponse) Descriptor() ([]byte, []int) {
+	return file_grpcpay_linepaypb_linepay_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *PaymentsStatusResponse) GetReturnCode() string {
+	if x != nil {
+		return x.ReturnCode
+	}
+	return ""
+}
+
+func (x *PaymentsStatusResponse) GetReturnMessage() string {
+	if x != nil {
+		return x.ReturnMessage
+	}
+	return ""
+}
+
+func (x *PaymentsStatusResponse) GetStatus() PaymentStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PaymentStatus_PAYMENT_STATUS_UNSPECIFIED
+}
+
+var File_grpcpay_linepaypb_linepay_proto protoreflect.FileDescriptor
+
+var file_grpcpay_linepaypb_linepay_proto_rawDesc = []byte{
+	0x0a, 0x1f, 0x67, 0x72, 0x70, 0x63, 0x70, 0x61, 0x79, 0x2f, 0x6c, 0x69, 0x6e, 0x65, 0x70, 0x61,
+	0x79, 0x70, 0x62, 0x2f, 0x6c, 0x69, 0x6e, 0x65, 0x70, 0x61, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x0a, 0x6c, 0x69, 0x6e, 0x65, 0x70, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x1a, 0x1f, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xff,
+	0x01, 0x0a, 0x0f, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x75,
+	0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x75,
+	0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f,
+	0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49,
+	0x64, 0x12, 0x2f, 0x0a, 0x08, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x18, 0x04, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6c, 0x69, 0x6e, 0x65, 0x70, 0x61, 0x79, 0x2e, 0x76, 0x31,
+	0x2e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x08, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67,
+	0x65, 0x73, 0x12, 0x3d, 0x0a, 0x0d, 0x72, 0x65, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x5f, 0x75,
+	0x72, 0x6c, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6c, 0x69, 0x6e, 0x65,
+	0x70, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x55,
+	0x72, 0x6c, 0x73, 0x52, 0x0c, 0x72, 0x65, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x55, 0x72, 0x6c,
+	0x73, 0x12, 0x2d, 0x0a, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6c, 0x69, 0x6e, 0x65, 0x70, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e,
+	0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x22, 0x91, 0x01, 0x0a, 0x07, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06,
+	0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x61, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x66, 0x65, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x75, 0x73, 0x65, 0x72, 0x46, 0x65, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x2f, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x18,
+	0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6c, 0x69, 0x6e, 0x65, 0x70, 0x61, 0x79, 0x2e,
+	0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x64,
+	0x75, 0x63, 0x74, 0x73, 0x22, 0xa3, 0x01, 0x0a, 0x07, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x75, 0x72,
+	0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x55, 0x72,
+	0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x08, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x70, 0x72,
+	0x69, 0x63, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x6c, 0x5f,
+	0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x6f, 0x72, 0x69,
+	0x67, 0x69, 0x6e, 0x61, 0x6c, 0x50, 0x72, 0x69, 0x63, 0x65, 0x22, 0xa2, 0x01, 0x0a, 0x0c, 0x52,
+	0x65, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x55, 0x72, 0x6c, 0x73, 0x12, 0x28, 0x0a, 0x10, 0x61,
+	0x70, 0x70, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x61, 0x70, 0x70, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67,
+	0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d,
+	0x5f, 0x75, 0x72, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x66,
+	0x69, 0x72, 0x6d, 0x55, 0x72, 0x6c, 0x12, 0x28, 0x0a, 0x10, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72,
+	0x6d, 0x5f, 0x75, 0x72, 0x6c, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x55, 0x72, 0x6c, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x55, 0x72, 0x6c, 0x22,
+	0xa7, 0x02, 0x0a, 0x07, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x34, 0x0a, 0x07, 0x70,
+	0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6c,
+	0x69, 0x6e, 0x65, 0x70, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e,
+	0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x07, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e,
+	0x74, 0x12, 0x34, 0x0a, 0x07, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6c, 0x69, 0x6e, 0x65, 0x70, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e,
+	0x44, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x07,
+	0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x12, 0x37, 0x0a, 0x08, 0x73, 0x68, 0x69, 0x70, 0x70,
+	0x69, 0x6e, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6c, 0x69, 0x6e, 0x65,
+	0x70, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x68, 0x69, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x4f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x08, 0x73, 0x68, 0x69, 0x70, 0x70, 0x69, 0x6e, 0x67,
+	0x12, 0x47, 0x0a, 0x0e, 0x66, 0x61, 0x6d, 0x69, 0x6c, 0x79, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6c, 0x69, 0x6e, 0x65, 0x70,
+	0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x61, 0x6d, 0x69, 0x6c, 0x79, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x0d, 0x66, 0x61, 0x6d, 0x69,
+	0x6c, 0x79, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x2e, 0x0a, 0x05, 0x65, 0x78, 0x74,
+	0x72, 0x61, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6c, 0x69, 0x6e, 0x65, 0x70,
+	0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x74, 0x72, 0x61, 0x4f, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x52, 0x05, 0x65, 0x78, 0x74, 0x72, 0x61, 0x22, 0x45, 0x0a, 0x0e, 0x50, 0x61, 0x79,
+	0x6d, 0x65, 0x6e, 0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x63,
+	0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x63, 0x61,
+	0x70, 0x74, 0x75, 0x72, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x70, 0x61, 0x79, 0x5f, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x61, 0x79, 0x54, 0x79, 0x70, 0x65,
+	0x22, 0x63, 0x0a, 0x0e, 0x44, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x4f, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x65, 0x12, 0x39, 0x0a, 0x19, 0x63, 0x68,
+	0x65, 0x63, 0x6b, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x5f, 0x75, 0x72, 0x6c, 0x5f,
+	0x62, 0x72, 0x6f, 0x77, 0x73, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x16, 0x63,
+	0x68, 0x65, 0x63, 0x6b, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x55, 0x72, 0x6c, 0x42, 0x72,
+	0x6f, 0x77, 0x73, 0x65, 0x72, 0x22, 0xc5, 0x01, 0x0a, 0x0f, 0x53, 0x68, 0x69, 0x70, 0x70, 0x69,
+	0x6e, 0x67, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1d, 0x0a,
+	0x0a, 0x66, 0x65, 0x65, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x66, 0x65, 0x65, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x26, 0x0a, 0x0f,
+	0x66, 0x65, 0x65, 0x5f, 0x69, 0x6e, 0x71, 0x75, 0x69, 0x72, 0x79, 0x5f, 0x75, 0x72, 0x6c, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x66, 0x65, 0x65, 0x49, 0x6e, 0x71, 0x75, 0x69, 0x72,
+	0x79, 0x55, 0x72, 0x6c, 0x12, 0x28, 0x0a, 0x10, 0x66, 0x65, 0x65, 0x5f, 0x69, 0x6e, 0x71, 0x75,
+	0x69, 0x72, 0x79, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e,
+	0x66, 0x65, 0x65, 0x49, 0x6e, 0x71, 0x75, 0x69, 0x72, 0x79, 0x54, 0x79, 0x70, 0x65, 0x12, 0x2d,
+	0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x13, 0x2e, 0x6c, 0x69, 0x6e, 0x65, 0x70, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22, 0xd7, 0x01,
+	0x0a, 0x07, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x6f, 0x73, 0x74, 0x61, 0x6c, 0x5f, 0x63, 0x6f,
+	0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x6f, 0x73, 0x74, 0x61, 0x6c,
+	0x43, 0x6f, 0x64, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x69,
+	0x74, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x69, 0x74, 0x79, 0x12, 0x16,
+	0x0a, 0x06, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x61, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x61, 0x6c, 0x12, 0x33, 0x0a, 0x09, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6c, 0x69, 0x6e, 0x65, 0x70, 0x61, 0x79, 0x2e,
+	0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x52, 0x09, 0x72, 0x65,
+	0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x22, 0xd6, 0x01, 0x0a, 0x09, 0x52, 0x65, 0x63, 0x69,
+	0x70, 0x69, 0x65, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x69, 0x72, 0x73, 0x74, 0x5f, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x66, 0x69, 0x72, 0x73, 0x74,
+	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x61, 0x73, 0x74, 0x4e, 0x61, 0x6d,
+	0x65, 0x12, 0x2e, 0x0a, 0x13, 0x66, 0x69, 0x72, 0x73, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x5f,
+	0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11,
+	0x66, 0x69, 0x72, 0x73, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61,
+	0x6c, 0x12, 0x2c, 0x0a, 0x12, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x6f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x6c,
+	0x61, 0x73, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x12,
+	0x14, 0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x65, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x19, 0x0a, 0x08, 0x70, 0x68, 0x6f, 0x6e, 0x65, 0x5f, 0x6e,
+	0x6f, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x68, 0x6f, 0x6e, 0x65, 0x4e, 0x6f,
+	0x22, 0x4f, 0x0a, 0x14, 0x46, 0x61, 0x6d, 0x69, 0x6c, 0x79, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x37, 0x0a, 0x0b, 0x61, 0x64, 0x64, 0x5f,
+	0x66, 0x72, 0x69, 0x65, 0x6e, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e,
+	0x6c, 0x69, 0x6e, 0x65, 0x70, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x64, 0x46, 0x72,
+	0x69, 0x65, 0x6e, 0x64, 0x73, 0x52, 0x0a, 0x61, 0x64, 0x64, 0x46, 0x72, 0x69, 0x65, 0x6e, 0x64,
+	0x73, 0x22, 0x32, 0x0a, 0x0a, 0x41, 0x64, 0x64, 0x46, 0x72, 0x69, 0x65, 0x6e, 0x64, 0x73, 0x12,
+	0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x03, 0x69, 0x64, 0x73, 0x22, 0x4c, 0x0a, 0x0c, 0x45, 0x78, 0x74, 0x72, 0x61, 0x4f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x5f,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x62, 0x72, 0x61, 0x6e,
+	0x63, 0x68, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68,
+	0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x62, 0x72, 0x61, 0x6e, 0x63,
+	0x68, 0x49, 0x64, 0x22, 0x88, 0x01, 0x0a, 0x10, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x74, 0x75,
+	0x72, 0x6e, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72,
+	0x65, 0x74, 0x75, 0x72, 0x6e, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65, 0x74,
+	0x75, 0x72, 0x6e, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0d, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x12, 0x2c, 0x0a, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18,
+	0x2e, 0x6c, 0x69, 0x6e, 0x65, 0x70, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x79, 0x6d,
+	0x65, 0x6e, 0x74, 0x73, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x22, 0xa0,
+	0x01, 0x0a, 0x0c, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x49, 0x6e, 0x66, 0x6f, 0x12,
+	0x25, 0x0a, 0x0e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x30, 0x0a, 0x14, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e,
+	0x74, 0x5f, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x41, 0x63, 0x63,
+	0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x37, 0x0a, 0x0b, 0x70, 0x61, 0x79, 0x6d,
+	0x65, 0x6e, 0x74, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e,
+	0x6c, 0x69, 0x6e, 0x65, 0x70, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x79, 0x6d, 0x65,
+	0x6e, 0x74, 0x55, 0x72, 0x6c, 0x52, 0x0a, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x55, 0x72,
+	0x6c, 0x22, 0x30, 0x0a, 0x0a, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x55, 0x72, 0x6c, 0x12,
+	0x10, 0x0a, 0x03, 0x77, 0x65, 0x62, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x77, 0x65,
+	0x62, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x70, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x61, 0x70, 0x70, 0x22, 0x73, 0x0a, 0x16, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x25, 0x0a,
+	0x0e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08,
+	0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x22, 0x8e, 0x01, 0x0a, 0x17, 0x50, 0x61, 0x79,
+	0x6d, 0x65, 0x6e, 0x74, 0x73, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x5f, 0x63,
+	0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x74, 0x75, 0x72,
+	0x6e, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x5f,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x72,
+	0x65, 0x74, 0x75, 0x72, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x2b, 0x0a, 0x04,
+	0x69, 0x6e, 0x66, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6c, 0x69, 0x6e,
+	0x65, 0x70, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x49,
+	0x6e, 0x66, 0x6f, 0x52, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x22, 0xd9, 0x02, 0x0a, 0x0b, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x72, 0x6d, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64,
+	0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x72, 0x64,
+	0x65, 0x72, 0x49, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x74, 0x72,
+	0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x56, 0x0a, 0x19, 0x61,
+	0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x65, 0x78, 0x70,
+	0x69, 0x72, 0x65, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x17, 0x61, 0x75, 0x74, 0x68,
+	0x6f, 0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x44,
+	0x61, 0x74, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x72, 0x65, 0x67, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x67, 0x4b, 0x65, 0x79, 0x12, 0x2e, 0x0a, 0x08,
+	0x70, 0x61, 0x79, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13,
+	0x2e, 0x6c, 0x69, 0x6e, 0x65, 0x70, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x79, 0x49,
+	0x6e, 0x66, 0x6f, 0x52, 0x07, 0x70, 0x61, 0x79, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x35, 0x0a, 0x08,
+	0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19,
+	0x2e, 0x6c, 0x69, 0x6e, 0x65, 0x70, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x63, 0x6b,
+	0x61, 0x67, 0x65, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x08, 0x70, 0x61, 0x63, 0x6b, 0x61,
+	0x67, 0x65, 0x73, 0x12, 0x30, 0x0a, 0x08, 0x73, 0x68, 0x69, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6c, 0x69, 0x6e, 0x65, 0x70, 0x61, 0x79, 0x2e,
+	0x76, 0x31, 0x2e, 0x53, 0x68, 0x69, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x52, 0x08, 0x73, 0x68, 0x69,
+	0x70, 0x70, 0x69, 0x6e, 0x67, 0x22, 0xd2, 0x01, 0x0a, 0x07, 0x50, 0x61, 0x79, 0x49, 0x6e, 0x66,
+	0x6f, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f,
+	0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e,
+	0x74, 0x12, 0x30, 0x0a, 0x14, 0x63, 0x72, 0x65, 0x64, 0x69, 0x74, 0x5f, 0x63, 0x61, 0x72, 0x64,
+	0x5f, 0x6e, 0x69, 0x63, 0x6b, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x12, 0x63, 0x72, 0x65, 0x64, 0x69, 0x74, 0x43, 0x61, 0x72, 0x64, 0x4e, 0x69, 0x63, 0x6b, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x2a, 0x0a, 0x11, 0x63, 0x72, 0x65, 0x64, 0x69, 0x74, 0x5f, 0x63, 0x61,
+	0x72, 0x64, 0x5f, 0x62, 0x72, 0x61, 0x6e, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f,
+	0x63, 0x72, 0x65, 0x64, 0x69, 0x74, 0x43, 0x61, 0x72, 0x64, 0x42, 0x72, 0x61, 0x6e, 0x64, 0x12,
+	0x39, 0x0a, 0x19, 0x6d, 0x61, 0x73, 0x6b, 0x65, 0x64, 0x5f, 0x63, 0x72, 0x65, 0x64, 0x69, 0x74,
+	0x5f, 0x63, 0x61, 0x72, 0x64, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x16, 0x6d, 0x61, 0x73, 0x6b, 0x65, 0x64, 0x43, 0x72, 0x65, 0x64, 0x69, 0x74,
+	0x43, 0x61, 0x72, 0x64, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x22, 0x5f, 0x0a, 0x0d, 0x50, 0x61,
+	0x63, 0x6b, 0x61, 0x67, 0x65, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x61,
+	0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x61, 0x6d, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x26, 0x0a, 0x0f, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x66, 0x65, 0x65, 0x5f,
+	0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x75, 0x73,
+	0x65, 0x72, 0x46, 0x65, 0x65, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x75, 0x0a, 0x08, 0x53,
+	0x68, 0x69, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x65, 0x74, 0x68, 0x6f,
+	0x64, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x68,
+	0x6f, 0x64, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x65, 0x65, 0x5f, 0x61, 0x6d, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x66, 0x65, 0x65, 0x41, 0x6d, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x2d, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6c, 0x69, 0x6e, 0x65, 0x70, 0x61, 0x79, 0x2e, 0x76,
+	0x31, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x22, 0x73, 0x0a, 0x16, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x43, 0x61,
+	0x70, 0x74, 0x75, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x25, 0x0a, 0x0e,
+	0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x63,
+	0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63,
+	0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x22, 0x8e, 0x01, 0x0a, 0x17, 0x50, 0x61, 0x79, 0x6d,
+	0x65, 0x6e, 0x74, 0x73, 0x43, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x5f, 0x63, 0x6f,
+	0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e,
+	0x43, 0x6f, 0x64, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x5f, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x72, 0x65,
+	0x74, 0x75, 0x72, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x2b, 0x0a, 0x04, 0x69,
+	0x6e, 0x66, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6c, 0x69, 0x6e, 0x65,
+	0x70, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x49, 0x6e,
+	0x66, 0x6f, 0x52, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x22, 0x7f, 0x0a, 0x0b, 0x43, 0x61, 0x70, 0x74,
+	0x75, 0x72, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x25, 0x0a, 0x0e, 0x74, 0x72, 0x61, 0x6e, 0x73,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0d, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x19,
+	0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x2e, 0x0a, 0x08, 0x70, 0x61, 0x79,
+	0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6c, 0x69,
+	0x6e, 0x65, 0x70, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x79, 0x49, 0x6e, 0x66, 0x6f,
+	0x52, 0x07, 0x70, 0x61, 0x79, 0x49, 0x6e, 0x66, 0x6f, 0x22, 0x3c, 0x0a, 0x13, 0x50, 0x61, 0x79,
+	0x6d, 0x65, 0x6e, 0x74, 0x73, 0x56, 0x6f, 0x69, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x25, 0x0a, 0x0e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0x5e, 0x0a, 0x14, 0x50, 0x61, 0x79, 0x6d, 0x65,
+	0x6e, 0x74, 0x73, 0x56, 0x6f, 0x69, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x43, 0x6f, 0x64, 0x65,
+	0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x63, 0x0a, 0x15, 0x50, 0x61, 0x79, 0x6d, 0x65,
+	0x6e, 0x74, 0x73, 0x52, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x25, 0x0a, 0x0e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x66, 0x75, 0x6e,
+	0x64, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c,
+	0x72, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x8c, 0x01, 0x0a,
+	0x16, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x74, 0x75, 0x72,
+	0x6e, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65,
+	0x74, 0x75, 0x72, 0x6e, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65, 0x74, 0x75,
+	0x72, 0x6e, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0d, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12,
+	0x2a, 0x0a, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e,
+	0x6c, 0x69, 0x6e, 0x65, 0x70, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x66, 0x75, 0x6e,
+	0x64, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x22, 0x94, 0x01, 0x0a, 0x0a,
+	0x52, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x32, 0x0a, 0x15, 0x72, 0x65,
+	0x66, 0x75, 0x6e, 0x64, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x13, 0x72, 0x65, 0x66, 0x75, 0x6e,
+	0x64, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x52,
+	0x0a, 0x17, 0x72, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x15, 0x72, 0x65, 0x66,
+	0x75, 0x6e, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x61,
+	0x74, 0x65, 0x22, 0x76, 0x0a, 0x16, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x44, 0x65,
+	0x74, 0x61, 0x69, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x27, 0x0a, 0x0f,
+	0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x03, 0x52, 0x0e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x49, 0x64, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69,
+	0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49,
+	0x64, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x22, 0x8e, 0x01, 0x0a, 0x17, 0x50,
+	0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e,
+	0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x74,
+	0x75, 0x72, 0x6e, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65, 0x74, 0x75, 0x72,
+	0x6e, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0d, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x2b,
+	0x0a, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6c,
+	0x69, 0x6e, 0x65, 0x70, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c,
+	0x73, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x22, 0xa4, 0x05, 0x0a, 0x0b,
+	0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x25, 0x0a, 0x0e, 0x74,
+	0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0d, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x49, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x45, 0x0a,
+	0x10, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x64, 0x61, 0x74,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x0f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x44, 0x61, 0x74, 0x65, 0x12, 0x29, 0x0a, 0x10, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f,
+	0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12,
+	0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x79, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x79, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x21,
+	0x0a, 0x0c, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x4e, 0x61, 0x6d,
+	0x65, 0x12, 0x23, 0x0a, 0x0d, 0x6d, 0x65, 0x72, 0x63, 0x68, 0x61, 0x6e, 0x74, 0x5f, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6d, 0x65, 0x72, 0x63, 0x68, 0x61,
+	0x6e, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e,
+	0x63, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e,
+	0x63, 0x79, 0x12, 0x56, 0x0a, 0x19, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x17, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x44, 0x61, 0x74, 0x65, 0x12, 0x2e, 0x0a, 0x08, 0x70, 0x61,
+	0x79, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6c,
+	0x69, 0x6e, 0x65, 0x70, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x79, 0x49, 0x6e, 0x66,
+	0x6f, 0x52, 0x07, 0x70, 0x61, 0x79, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x3b, 0x0a, 0x0b, 0x72, 0x65,
+	0x66, 0x75, 0x6e, 0x64, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x6c, 0x69, 0x6e, 0x65, 0x70, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x66,
+	0x75, 0x6e, 0x64, 0x4c, 0x69, 0x73, 0x74, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x0a, 0x72, 0x65, 0x66,
+	0x75, 0x6e, 0x64, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x36, 0x0a, 0x17, 0x6f, 0x72, 0x69, 0x67, 0x69,
+	0x6e, 0x61, 0x6c, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x69, 0x64, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x03, 0x52, 0x15, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e,
+	0x61, 0x6c, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12,
+	0x2f, 0x0a, 0x08, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x13, 0x2e, 0x6c, 0x69, 0x6e, 0x65, 0x70, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x50,
+	0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x52, 0x08, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73,
+	0x12, 0x30, 0x0a, 0x08, 0x73, 0x68, 0x69, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x18, 0x0e, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6c, 0x69, 0x6e, 0x65, 0x70, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e,
+	0x53, 0x68, 0x69, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x52, 0x08, 0x73, 0x68, 0x69, 0x70, 0x70, 0x69,
+	0x6e, 0x67, 0x22, 0xe8, 0x01, 0x0a, 0x0e, 0x52, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x4c, 0x69, 0x73,
+	0x74, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x32, 0x0a, 0x15, 0x72, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x5f,
+	0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x13, 0x72, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x29, 0x0a, 0x10, 0x74, 0x72, 0x61,
+	0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x5f, 0x61,
+	0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x72, 0x65, 0x66,
+	0x75, 0x6e, 0x64, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x52, 0x0a, 0x17, 0x72, 0x65, 0x66,
+	0x75, 0x6e, 0x64, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x64, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x15, 0x72, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x54, 0x72,
+	0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x61, 0x74, 0x65, 0x22, 0x3e, 0x0a,
+	0x15, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d,
+	0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0x93, 0x01,
+	0x0a, 0x16, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x74, 0x75,
+	0x72, 0x6e, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72,
+	0x65, 0x74, 0x75, 0x72, 0x6e, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65, 0x74,
+	0x75, 0x72, 0x6e, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0d, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x12, 0x31, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x19, 0x2e, 0x6c, 0x69, 0x6e, 0x65, 0x70, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61,
+	0x79, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x2a, 0xc0, 0x01, 0x0a, 0x0d, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1e, 0x0a, 0x1a, 0x50, 0x41, 0x59, 0x4d, 0x45, 0x4e, 0x54,
+	0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46,
+	0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1a, 0x0a, 0x16, 0x50, 0x41, 0x59, 0x4d, 0x45, 0x4e, 0x54,
+	0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x50, 0x45, 0x4e, 0x44, 0x49, 0x4e, 0x47, 0x10,
+	0x01, 0x12, 0x1d, 0x0a, 0x19, 0x50, 0x41, 0x59, 0x4d, 0x45, 0x4e, 0x54, 0x5f, 0x53, 0x54, 0x41,
+	0x54, 0x55, 0x53, 0x5f, 0x41, 0x55, 0x54, 0x48, 0x4f, 0x52, 0x49, 0x5a, 0x45, 0x44, 0x10, 0x02,
+	0x12, 0x1b, 0x0a, 0x17, 0x50, 0x41, 0x59, 0x4d, 0x45, 0x4e, 0x54, 0x5f, 0x53, 0x54, 0x41, 0x54,
+	0x55, 0x53, 0x5f, 0x43, 0x41, 0x4e, 0x43, 0x45, 0x4c, 0x45, 0x44, 0x10, 0x03, 0x12, 0x19, 0x0a,
+	0x15, 0x50, 0x41, 0x59, 0x4d, 0x45, 0x4e, 0x54, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f,
+	0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x04, 0x12, 0x1c, 0x0a, 0x18, 0x50, 0x41, 0x59, 0x4d,
+	0x45, 0x4e, 0x54, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x43, 0x4f, 0x4d, 0x50, 0x4c,
+	0x45, 0x54, 0x45, 0x44, 0x10, 0x05, 0x32, 0xc0, 0x04, 0x0a, 0x0f, 0x50, 0x61, 0x79, 0x6d, 0x65,
+	0x6e, 0x74, 0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x44, 0x0a, 0x07, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x2e, 0x6c, 0x69, 0x6e, 0x65, 0x70, 0x61, 0x79, 0x2e,
+	0x76, 0x31, 0x2e, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x6c, 0x69, 0x6e, 0x65, 0x70, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e,
+	0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x52, 0x0a, 0x07, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x12, 0x22, 0x2e, 0x6c, 0x69,
+	0x6e, 0x65, 0x70, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74,
+	0x73, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x23, 0x2e, 0x6c, 0x69, 0x6e, 0x65, 0x70, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x79,
+	0x6d, 0x65, 0x6e, 0x74, 0x73, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x52, 0x0a, 0x07, 0x43, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x12,
+	0x22, 0x2e, 0x6c, 0x69, 0x6e, 0x65, 0x70, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x79,
+	0x6d, 0x65, 0x6e, 0x74, 0x73, 0x43, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x6c, 0x69, 0x6e, 0x65, 0x70, 0x61, 0x79, 0x2e, 0x76, 0x31,
+	0x2e, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x43, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x49, 0x0a, 0x04, 0x56, 0x6f, 0x69, 0x64,
+	0x12, 0x1f, 0x2e, 0x6c, 0x69, 0x6e, 0x65, 0x70, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61,
+	0x79, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x56, 0x6f, 0x69, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x20, 0x2e, 0x6c, 0x69, 0x6e, 0x65, 0x70, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x50,
+	0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x56, 0x6f, 0x69, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x4f, 0x0a, 0x06, 0x52, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x12, 0x21, 0x2e,
+	0x6c, 0x69, 0x6e, 0x65, 0x70, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x79, 0x6d, 0x65,
+	0x6e, 0x74, 0x73, 0x52, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x22, 0x2e, 0x6c, 0x69, 0x6e, 0x65, 0x70, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61,
+	0x79, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x52, 0x0a, 0x07, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x12,
+	0x22, 0x2e, 0x6c, 0x69, 0x6e, 0x65, 0x70, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x79,
+	0x6d, 0x65, 0x6e, 0x74, 0x73, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x6c, 0x69, 0x6e, 0x65, 0x70, 0x61, 0x79, 0x2e, 0x76, 0x31,
+	0x2e, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4f, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x21, 0x2e, 0x6c, 0x69, 0x6e, 0x65, 0x70, 0x61, 0x79, 0x2e, 0x76, 0x31, 0x2e,
+	0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x6c, 0x69, 0x6e, 0x65, 0x70, 0x61, 0x79, 0x2e,
+	0x76, 0x31, 0x2e, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x35, 0x5a, 0x33, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x68, 0x79, 0x31, 0x36, 0x38, 0x2f, 0x6c,
+	0x69, 0x6e, 0x65, 0x2d, 0x70, 0x61, 0x79, 0x2d, 0x73, 0x64, 0x6b, 0x2d, 0x67, 0x6f, 0x2f, 0x67,
+	0x72, 0x70, 0x63, 0x70, 0x61, 0x79, 0x2f, 0x6c, 0x69, 0x6e, 0x65, 0x70, 0x61, 0x79, 0x70, 0x62,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_grpcpay_linepaypb_linepay_proto_rawDescOnce sync.Once
+	file_grpcpay_linepaypb_linepay_proto_rawDescData = file_grpcpay_linepaypb_linepay_proto_rawDesc
+)
+
+func file_grpcpay_linepaypb_linepay_proto_rawDescGZIP() []byte {
+	file_grpcpay_linepaypb_linepay_proto_rawDescOnce.Do(func() {
+		file_grpcpay_linepaypb_linepay_proto_rawDescData = protoimpl.X.CompressGZIP(file_grpcpay_linepaypb_linepay_proto_rawDescData)
+	})
+	return file_grpcpay_linepaypb_linepay_proto_rawDescData
+}
+
+var file_grpcpay_linepaypb_linepay_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_grpcpay_linepaypb_linepay_proto_msgTypes = make([]protoimpl.MessageInfo, 36)
+var file_grpcpay_linepaypb_linepay_proto_goTypes = []any{
+	(PaymentStatus)(0),              // 0: linepay.v1.PaymentStatus
+	(*PaymentsRequest)(nil),         // 1: linepay.v1.PaymentsRequest
+	(*Package)(nil),                 // 2: linepay.v1.Package
+	(*Product)(nil),                 // 3: linepay.v1.Product
+	(*RedirectUrls)(nil),            // 4: linepay.v1.RedirectUrls
+	(*Options)(nil),                 // 5: linepay.v1.Options
+	(*PaymentOptions)(nil),          // 6: linepay.v1.PaymentOptions
+	(*DisplayOptions)(nil),          // 7: linepay.v1.DisplayOptions
+	(*ShippingOptions)(nil),         // 8: linepay.v1.ShippingOptions
+	(*Address)(nil),                 // 9: linepay.v1.Address
+	(*Recipient)(nil),               // 10: linepay.v1.Recipient
+	(*FamilyServiceOptions)(nil),    // 11: linepay.v1.FamilyServiceOptions
+	(*AddFriends)(nil),              // 12: linepay.v1.AddFriends
+	(*ExtraOptions)(nil),            // 13: linepay.v1.ExtraOptions
+	(*PaymentsResponse)(nil),        // 14: linepay.v1.PaymentsResponse
+	(*PaymentsInfo)(nil),            // 15: linepay.v1.PaymentsInfo
+	(*PaymentUrl)(nil),              // 16: linepay.v1.PaymentUrl
+	(*PaymentsConfirmRequest)(nil),  // 17: linepay.v1.PaymentsConfirmRequest
+	(*PaymentsConfirmResponse)(nil), // 18: linepay.v1.PaymentsConfirmResponse
+	(*ConfirmInfo)(nil),             // 19: linepay.v1.ConfirmInfo
+	(*PayInfo)(nil),                 // 20: linepay.v1.PayInfo
+	(*PackageAmount)(nil),           // 21: linepay.v1.PackageAmount
+	(*Shipping)(nil),                // 22: linepay.v1.Shipping
+	(*PaymentsCaptureRequest)(nil),  // 23: linepay.v1.PaymentsCaptureRequest
+	(*PaymentsCaptureResponse)(nil), // 24: linepay.v1.PaymentsCaptureResponse
+	(*CaptureInfo)(nil),             // 25: linepay.v1.CaptureInfo
+	(*PaymentsVoidRequest)(nil),     // 26: linepay.v1.PaymentsVoidRequest
+	(*PaymentsVoidResponse)(nil),    // 27: linepay.v1.PaymentsVoidResponse
+	(*PaymentsRefundRequest)(nil),   // 28: linepay.v1.PaymentsRefundRequest
+	(*PaymentsRefundResponse)(nil),  // 29: linepay.v1.PaymentsRefundResponse
+	(*RefundInfo)(nil),              // 30: linepay.v1.RefundInfo
+	(*PaymentsDetailsRequest)(nil),  // 31: linepay.v1.PaymentsDetailsRequest
+	(*PaymentsDetailsResponse)(nil), // 32: linepay.v1.PaymentsDetailsResponse
+	(*DetailsInfo)(nil),             // 33: linepay.v1.DetailsInfo
+	(*RefundListItem)(nil),          // 34: linepay.v1.RefundListItem
+	(*PaymentsStatusRequest)(nil),   // 35: linepay.v1.PaymentsStatusRequest
+	(*PaymentsStatusResponse)(nil),  // 36: linepay.v1.PaymentsStatusResponse
+	(*timestamppb.Timestamp)(nil),   // 37: google.protobuf.Timestamp
+}
+var file_grpcpay_linepaypb_linepay_proto_depIdxs = []int32{
+	2,  // 0: linepay.v1.PaymentsRequest.packages:type_name -> linepay.v1.Package
+	4,  // 1: linepay.v1.PaymentsRequest.redirect_urls:type_name -> linepay.v1.RedirectUrls
+	5,  // 2: linepay.v1.PaymentsRequest.options:type_name -> linepay.v1.Options
+	3,  // 3: linepay.v1.Package.products:type_name -> linepay.v1.Product
+	6,  // 4: linepay.v1.Options.payment:type_name -> linepay.v1.PaymentOptions
+	7,  // 5: linepay.v1.Options.display:type_name -> linepay.v1.DisplayOptions
+	8,  // 6: linepay.v1.Options.shipping:type_name -> linepay.v1.ShippingOptions
+	11, // 7: linepay.v1.Options.family_service:type_name -> linepay.v1.FamilyServiceOptions
+	13, // 8: linepay.v1.Options.extra:type_name -> linepay.v1.ExtraOptions
+	9,  // 9: linepay.v1.ShippingOptions.address:type_name -> linepay.v1.Address
+	10, // 10: linepay.v1.Address.recipient:type_name -> linepay.v1.Recipient
+	12, // 11: linepay.v1.FamilyServiceOptions.add_friends:type_name -> linepay.v1.AddFriends
+	15, // 12: linepay.v1.PaymentsResponse.info:type_name -> linepay.v1.PaymentsInfo
+	16, // 13: linepay.v1.PaymentsInfo.payment_url:type_name -> linepay.v1.PaymentUrl
+	19, // 14: linepay.v1.PaymentsConfirmResponse.info:type_name -> linepay.v1.ConfirmInfo
+	37, // 15: linepay.v1.ConfirmInfo.authorization_expire_date:type_name -> google.protobuf.Timestamp
+	20, // 16: linepay.v1.ConfirmInfo.pay_info:type_name -> linepay.v1.PayInfo
+	21, // 17: linepay.v1.ConfirmInfo.packages:type_name -> linepay.v1.PackageAmount
+	22, // 18: linepay.v1.ConfirmInfo.shipping:type_name -> linepay.v1.Shipping
+	9,  // 19: linepay.v1.Shipping.address:type_name -> linepay.v1.Address
+	25, // 20: linepay.v1.PaymentsCaptureResponse.info:type_name -> linepay.v1.CaptureInfo
+	20, // 21: linepay.v1.CaptureInfo.pay_info:type_name -> linepay.v1.PayInfo
+	30, // 22: linepay.v1.PaymentsRefundResponse.info:type_name -> linepay.v1.RefundInfo
+	37, // 23: linepay.v1.RefundInfo.refund_transaction_date:type_name -> google.protobuf.Timestamp
+	33, // 24: linepay.v1.PaymentsDetailsResponse.info:type_name -> linepay.v1.DetailsInfo
+	37, // 25: linepay.v1.DetailsInfo.transaction_date:type_name -> google.protobuf.Timestamp
+	37, // 26: linepay.v1.DetailsInfo.authorization_expire_date:type_name -> google.protobuf.Timestamp
+	20, // 27: linepay.v1.DetailsInfo.pay_info:type_name -> linepay.v1.PayInfo
+	34, // 28: linepay.v1.DetailsInfo.refund_list:type_name -> linepay.v1.RefundListItem
+	2,  // 29: linepay.v1.DetailsInfo.packages:type_name -> linepay.v1.Package
+	22, // 30: linepay.v1.DetailsInfo.shipping:type_name -> linepay.v1.Shipping
+	37, // 31: linepay.v1.RefundListItem.refund_transaction_date:type_name -> google.protobuf.Timestamp
+	0,  // 32: linepay.v1.PaymentsStatusResponse.status:type_name -> linepay.v1.PaymentStatus
+	1,  // 33: linepay.v1.PaymentsService.Request:input_type -> linepay.v1.PaymentsRequest
+	17, // 34: linepay.v1.PaymentsService.Confirm:input_type -> linepay.v1.PaymentsConfirmRequest
+	23, // 35: linepay.v1.PaymentsService.Capture:input_type -> linepay.v1.PaymentsCaptureRequest
+	26, // 36: linepay.v1.PaymentsService.Void:input_type -> linepay.v1.PaymentsVoidRequest
+	28, // 37: linepay.v1.PaymentsService.Refund:input_type -> linepay.v1.PaymentsRefundRequest
+	31, // 38: linepay.v1.PaymentsService.Details:input_type -> linepay.v1.PaymentsDetailsRequest
+	35, // 39: linepay.v1.PaymentsService.Status:input_type -> linepay.v1.PaymentsStatusRequest
+	14, // 40: linepay.v1.PaymentsService.Request:output_type -> linepay.v1.PaymentsResponse
+	18, // 41: linepay.v1.PaymentsService.Confirm:output_type -> linepay.v1.PaymentsConfirmResponse
+	24, // 42: linepay.v1.PaymentsService.Capture:output_type -> linepay.v1.PaymentsCaptureResponse
+	27, // 43: linepay.v1.PaymentsService.Void:output_type -> linepay.v1.PaymentsVoidResponse
+	29, // 44: linepay.v1.PaymentsService.Refund:output_type -> linepay.v1.PaymentsRefundResponse
+	32, // 45: linepay.v1.PaymentsService.Details:output_type -> linepay.v1.PaymentsDetailsResponse
+	36, // 46: linepay.v1.PaymentsService.Status:output_type -> linepay.v1.PaymentsStatusResponse
+	40, // [40:47] is the sub-list for method output_type
+	33, // [33:40] is the sub-list for method input_type
+	33, // [33:33] is the sub-list for extension type_name
+	33, // [33:33] is the sub-list for extension extendee
+	0,  // [0:33] is the sub-list for field type_name
+}
+
+func init() { file_grpcpay_linepaypb_linepay_proto_init() }
+func file_grpcpay_linepaypb_linepay_proto_init() {
+	if File_grpcpay_linepaypb_linepay_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_grpcpay_linepaypb_linepay_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*PaymentsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpcpay_linepaypb_linepay_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*Package); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpcpay_linepaypb_linepay_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*Product); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpcpay_linepaypb_linepay_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*RedirectUrls); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpcpay_linepaypb_linepay_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*Options); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpcpay_linepaypb_linepay_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*PaymentOptions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpcpay_linepaypb_linepay_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*DisplayOptions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpcpay_linepaypb_linepay_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*ShippingOptions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpcpay_linepaypb_linepay_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*Address); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpcpay_linepaypb_linepay_proto_msgTypes[9].Exporter = func(v any, i int) any {
+			switch v := v.(*Recipient); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpcpay_linepaypb_linepay_proto_msgTypes[10].Exporter = func(v any, i int) any {
+			switch v := v.(*FamilyServiceOptions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpcpay_linepaypb_linepay_proto_msgTypes[11].Exporter = func(v any, i int) any {
+			switch v := v.(*AddFriends); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpcpay_linepaypb_linepay_proto_msgTypes[12].Exporter = func(v any, i int) any {
+			switch v := v.(*ExtraOptions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpcpay_linepaypb_linepay_proto_msgTypes[13].Exporter = func(v any, i int) any {
+			switch v := v.(*PaymentsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpcpay_linepaypb_linepay_proto_msgTypes[14].Exporter = func(v any, i int) any {
+			switch v := v.(*PaymentsInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpcpay_linepaypb_linepay_proto_msgTypes[15].Exporter = func(v any, i int) any {
+			switch v := v.(*PaymentUrl); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpcpay_linepaypb_linepay_proto_msgTypes[16].Exporter = func(v any, i int) any {
+			switch v := v.(*PaymentsConfirmRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpcpay_linepaypb_linepay_proto_msgTypes[17].Exporter = func(v any, i int) any {
+			switch v := v.(*PaymentsConfirmResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpcpay_linepaypb_linepay_proto_msgTypes[18].Exporter = func(v any, i int) any {
+			switch v := v.(*ConfirmInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpcpay_linepaypb_linepay_proto_msgTypes[19].Exporter = func(v any, i int) any {
+			switch v := v.(*PayInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpcpay_linepaypb_linepay_proto_msgTypes[20].Exporter = func(v any, i int) any {
+			switch v := v.(*PackageAmount); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpcpay_linepaypb_linepay_proto_msgTypes[21].Exporter = func(v any, i int) any {
+			switch v := v.(*Shipping); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpcpay_linepaypb_linepay_proto_msgTypes[22].Exporter = func(v any, i int) any {
+			switch v := v.(*PaymentsCaptureRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpcpay_linepaypb_linepay_proto_msgTypes[23].Exporter = func(v any, i int) any {
+			switch v := v.(*PaymentsCaptureResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpcpay_linepaypb_linepay_proto_msgTypes[24].Exporter = func(v any, i int) any {
+			switch v := v.(*CaptureInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpcpay_linepaypb_linepay_proto_msgTypes[25].Exporter = func(v any, i int) any {
+			switch v := v.(*PaymentsVoidRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpcpay_linepaypb_linepay_proto_msgTypes[26].Exporter = func(v any, i int) any {
+			switch v := v.(*PaymentsVoidResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpcpay_linepaypb_linepay_proto_msgTypes[27].Exporter = func(v any, i int) any {
+			switch v := v.(*PaymentsRefundRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpcpay_linepaypb_linepay_proto_msgTypes[28].Exporter = func(v any, i int) any {
+			switch v := v.(*PaymentsRefundResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpcpay_linepaypb_linepay_proto_msgTypes[29].Exporter = func(v any, i int) any {
+			switch v := v.(*RefundInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpcpay_linepaypb_linepay_proto_msgTypes[30].Exporter = func(v any, i int) any {
+			switch v := v.(*PaymentsDetailsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpcpay_linepaypb_linepay_proto_msgTypes[31].Exporter = func(v any, i int) any {
+			switch v := v.(*PaymentsDetailsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpcpay_linepaypb_linepay_proto_msgTypes[32].Exporter = func(v any, i int) any {
+			switch v := v.(*DetailsInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpcpay_linepaypb_linepay_proto_msgTypes[33].Exporter = func(v any, i int) any {
+			switch v := v.(*RefundListItem); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpcpay_linepaypb_linepay_proto_msgTypes[34].Exporter = func(v any, i int) any {
+			switch v := v.(*PaymentsStatusRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpcpay_linepaypb_linepay_proto_msgTypes[35].Exporter = func(v any, i int) any {
+			switch v := v.(*PaymentsStatusResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_grpcpay_linepaypb_linepay_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   36,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_grpcpay_linepaypb_linepay_proto_goTypes,
+		DependencyIndexes: file_grpcpay_linepaypb_linepay_proto_depIdxs,
+		EnumInfos:         file_grpcpay_linepaypb_linepay_proto_enumTypes,
+		MessageInfos:      file_grpcpay_linepaypb_linepay_proto_msgTypes,
+	}.Build()
+	File_grpcpay_linepaypb_linepay_proto = out.File
+	file_grpcpay_linepaypb_linepay_proto_rawDesc = nil
+	file_grpcpay_linepaypb_linepay_proto_goTypes = nil
+	file_grpcpay_linepaypb_linepay_proto_depIdxs = nil
+}
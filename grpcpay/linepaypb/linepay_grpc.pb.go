@@ -0,0 +1,331 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v5.27.1
+// source: grpcpay/linepaypb/linepay.proto
+
+package linepaypb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	PaymentsService_Request_FullMethodName = "/linepay.v1.PaymentsService/Request"
+	PaymentsService_Confirm_FullMethodName = "/linepay.v1.PaymentsService/Confirm"
+	PaymentsService_Capture_FullMethodName = "/linepay.v1.PaymentsService/Capture"
+	PaymentsService_Void_FullMethodName    = "/linepay.v1.PaymentsService/Void"
+	PaymentsService_Refund_FullMethodName  = "/linepay.v1.PaymentsService/Refund"
+	PaymentsService_Details_FullMethodName = "/linepay.v1.PaymentsService/Details"
+	PaymentsService_Status_FullMethodName  = "/linepay.v1.PaymentsService/Status"
+)
+
+// PaymentsServiceClient is the client API for PaymentsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type PaymentsServiceClient interface {
+	Request(ctx context.Context, in *PaymentsRequest, opts ...grpc.CallOption) (*PaymentsResponse, error)
+	Confirm(ctx context.Context, in *PaymentsConfirmRequest, opts ...grpc.CallOption) (*PaymentsConfirmResponse, error)
+	Capture(ctx context.Context, in *PaymentsCaptureRequest, opts ...grpc.CallOption) (*PaymentsCaptureResponse, error)
+	Void(ctx context.Context, in *PaymentsVoidRequest, opts ...grpc.CallOption) (*PaymentsVoidResponse, error)
+	Refund(ctx context.Context, in *PaymentsRefundRequest, opts ...grpc.CallOption) (*PaymentsRefundResponse, error)
+	Details(ctx context.Context, in *PaymentsDetailsRequest, opts ...grpc.CallOption) (*PaymentsDetailsResponse, error)
+	Status(ctx context.Context, in *PaymentsStatusRequest, opts ...grpc.CallOption) (*PaymentsStatusResponse, error)
+}
+
+type paymentsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPaymentsServiceClient(cc grpc.ClientConnInterface) PaymentsServiceClient {
+	return &paymentsServiceClient{cc}
+}
+
+func (c *paymentsServiceClient) Request(ctx context.Context, in *PaymentsRequest, opts ...grpc.CallOption) (*PaymentsResponse, error) {
+	out := new(PaymentsResponse)
+	err := c.cc.Invoke(ctx, PaymentsService_Request_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentsServiceClient) Confirm(ctx context.Context, in *PaymentsConfirmRequest, opts ...grpc.CallOption) (*PaymentsConfirmResponse, error) {
+	out := new(PaymentsConfirmResponse)
+	err := c.cc.Invoke(ctx, PaymentsService_Confirm_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentsServiceClient) Capture(ctx context.Context, in *PaymentsCaptureRequest, opts ...grpc.CallOption) (*PaymentsCaptureResponse, error) {
+	out := new(PaymentsCaptureResponse)
+	err := c.cc.Invoke(ctx, PaymentsService_Capture_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentsServiceClient) Void(ctx context.Context, in *PaymentsVoidRequest, opts ...grpc.CallOption) (*PaymentsVoidResponse, error) {
+	out := new(PaymentsVoidResponse)
+	err := c.cc.Invoke(ctx, PaymentsService_Void_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentsServiceClient) Refund(ctx context.Context, in *PaymentsRefundRequest, opts ...grpc.CallOption) (*PaymentsRefundResponse, error) {
+	out := new(PaymentsRefundResponse)
+	err := c.cc.Invoke(ctx, PaymentsService_Refund_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentsServiceClient) Details(ctx context.Context, in *PaymentsDetailsRequest, opts ...grpc.CallOption) (*PaymentsDetailsResponse, error) {
+	out := new(PaymentsDetailsResponse)
+	err := c.cc.Invoke(ctx, PaymentsService_Details_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentsServiceClient) Status(ctx context.Context, in *PaymentsStatusRequest, opts ...grpc.CallOption) (*PaymentsStatusResponse, error) {
+	out := new(PaymentsStatusResponse)
+	err := c.cc.Invoke(ctx, PaymentsService_Status_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PaymentsServiceServer is the server API for PaymentsService service.
+// All implementations must embed UnimplementedPaymentsServiceServer
+// for forward compatibility
+type PaymentsServiceServer interface {
+	Request(context.Context, *PaymentsRequest) (*PaymentsResponse, error)
+	Confirm(context.Context, *PaymentsConfirmRequest) (*PaymentsConfirmResponse, error)
+	Capture(context.Context, *PaymentsCaptureRequest) (*PaymentsCaptureResponse, error)
+	Void(context.Context, *PaymentsVoidRequest) (*PaymentsVoidResponse, error)
+	Refund(context.Context, *PaymentsRefundRequest) (*PaymentsRefundResponse, error)
+	Details(context.Context, *PaymentsDetailsRequest) (*PaymentsDetailsResponse, error)
+	Status(context.Context, *PaymentsStatusRequest) (*PaymentsStatusResponse, error)
+	mustEmbedUnimplementedPaymentsServiceServer()
+}
+
+// UnimplementedPaymentsServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedPaymentsServiceServer struct {
+}
+
+func (UnimplementedPaymentsServiceServer) Request(context.Context, *PaymentsRequest) (*PaymentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Request not implemented")
+}
+func (UnimplementedPaymentsServiceServer) Confirm(context.Context, *PaymentsConfirmRequest) (*PaymentsConfirmResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Confirm not implemented")
+}
+func (UnimplementedPaymentsServiceServer) Capture(context.Context, *PaymentsCaptureRequest) (*PaymentsCaptureResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Capture not implemented")
+}
+func (UnimplementedPaymentsServiceServer) Void(context.Context, *PaymentsVoidRequest) (*PaymentsVoidResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Void not implemented")
+}
+func (UnimplementedPaymentsServiceServer) Refund(context.Context, *PaymentsRefundRequest) (*PaymentsRefundResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Refund not implemented")
+}
+func (UnimplementedPaymentsServiceServer) Details(context.Context, *PaymentsDetailsRequest) (*PaymentsDetailsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Details not implemented")
+}
+func (UnimplementedPaymentsServiceServer) Status(context.Context, *PaymentsStatusRequest) (*PaymentsStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Status not implemented")
+}
+func (UnimplementedPaymentsServiceServer) mustEmbedUnimplementedPaymentsServiceServer() {}
+
+// UnsafePaymentsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PaymentsServiceServer will
+// result in compilation errors.
+type UnsafePaymentsServiceServer interface {
+	mustEmbedUnimplementedPaymentsServiceServer()
+}
+
+func RegisterPaymentsServiceServer(s grpc.ServiceRegistrar, srv PaymentsServiceServer) {
+	s.RegisterService(&PaymentsService_ServiceDesc, srv)
+}
+
+func _PaymentsService_Request_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PaymentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentsServiceServer).Request(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentsService_Request_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentsServiceServer).Request(ctx, req.(*PaymentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentsService_Confirm_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PaymentsConfirmRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentsServiceServer).Confirm(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentsService_Confirm_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentsServiceServer).Confirm(ctx, req.(*PaymentsConfirmRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentsService_Capture_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PaymentsCaptureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentsServiceServer).Capture(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentsService_Capture_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentsServiceServer).Capture(ctx, req.(*PaymentsCaptureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentsService_Void_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PaymentsVoidRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentsServiceServer).Void(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentsService_Void_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentsServiceServer).Void(ctx, req.(*PaymentsVoidRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentsService_Refund_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PaymentsRefundRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentsServiceServer).Refund(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentsService_Refund_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentsServiceServer).Refund(ctx, req.(*PaymentsRefundRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentsService_Details_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PaymentsDetailsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentsServiceServer).Details(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentsService_Details_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentsServiceServer).Details(ctx, req.(*PaymentsDetailsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentsService_Status_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PaymentsStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentsServiceServer).Status(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentsService_Status_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentsServiceServer).Status(ctx, req.(*PaymentsStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PaymentsService_ServiceDesc is the grpc.ServiceDesc for PaymentsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PaymentsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "linepay.v1.PaymentsService",
+	HandlerType: (*PaymentsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Request",
+			Handler:    _PaymentsService_Request_Handler,
+		},
+		{
+			MethodName: "Confirm",
+			Handler:    _PaymentsService_Confirm_Handler,
+		},
+		{
+			MethodName: "Capture",
+			Handler:    _PaymentsService_Capture_Handler,
+		},
+		{
+			MethodName: "Void",
+			Handler:    _PaymentsService_Void_Handler,
+		},
+		{
+			MethodName: "Refund",
+			Handler:    _PaymentsService_Refund_Handler,
+		},
+		{
+			MethodName: "Details",
+			Handler:    _PaymentsService_Details_Handler,
+		},
+		{
+			MethodName: "Status",
+			Handler:    _PaymentsService_Status_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "grpcpay/linepaypb/linepay.proto",
+}
@@ -0,0 +1,169 @@
+// Package grpcpay serves the `linepay.v1.PaymentsService` of package linepaypb by delegating to a linepay.PaymentsAPI,
+// usually a *linepay.Client:
+//
+//	server := grpc.NewServer()
+//	linepaypb.RegisterPaymentsServiceServer(server, grpcpay.New(client))
+//
+// The context of each call, with its deadline, is passed to the SDK. A LINE Pay `returnCode` other than 0000 is
+// returned as a status of code Code(returnCode), carrying a google.rpc.ErrorInfo detail read by ReturnCode.
+package grpcpay
+
+import (
+	"context"
+
+	linepay "github.com/chy168/line-pay-sdk-go"
+	"github.com/chy168/line-pay-sdk-go/grpcpay/linepaypb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Server implements linepaypb.PaymentsServiceServer
+type Server struct {
+	linepaypb.UnimplementedPaymentsServiceServer
+	client linepay.PaymentsAPI
+}
+
+// New returns a Server calling LINE Pay with `client`
+func New(client linepay.PaymentsAPI) *Server {
+	return &Server{client: client}
+}
+
+func transactionID(id int64) (linepay.TransactionID, error) {
+	if id <= 0 {
+		return 0, status.Error(codes.InvalidArgument, "transaction_id is required")
+	}
+	return linepay.TransactionID(id), nil
+}
+
+// Request calls the Request API
+func (s *Server) Request(ctx context.Context, req *linepaypb.PaymentsRequest) (*linepaypb.PaymentsResponse, error) {
+
+	if req.GetOrderId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "order_id is required")
+	}
+
+	res, err := s.client.PaymentsRequest(ctx, paymentsRequest(req))
+	if err != nil {
+		return nil, callError(ctx, err)
+	}
+	if err := returnCodeError(res.ReturnCode, res.ReturnMessage); err != nil {
+		return nil, err
+	}
+	return paymentsResponse(res), nil
+}
+
+// Confirm calls the Confirm API
+func (s *Server) Confirm(ctx context.Context, req *linepaypb.PaymentsConfirmRequest) (*linepaypb.PaymentsConfirmResponse, error) {
+
+	id, err := transactionID(req.GetTransactionId())
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.client.PaymentsConfirm(ctx, id, &linepay.PaymentsConfirmRequest{Amount: int(req.GetAmount()), Currency: req.GetCurrency()})
+	if err != nil {
+		return nil, callError(ctx, err)
+	}
+	if err := returnCodeError(res.ReturnCode, res.ReturnMessage); err != nil {
+		return nil, err
+	}
+	return confirmResponse(res), nil
+}
+
+// Capture calls the Capture API
+func (s *Server) Capture(ctx context.Context, req *linepaypb.PaymentsCaptureRequest) (*linepaypb.PaymentsCaptureResponse, error) {
+
+	id, err := transactionID(req.GetTransactionId())
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.client.PaymentsCapture(ctx, id, &linepay.PaymentsCaptureRequest{Amount: int(req.GetAmount()), Currency: req.GetCurrency()})
+	if err != nil {
+		return nil, callError(ctx, err)
+	}
+	if err := returnCodeError(res.ReturnCode, res.ReturnMessage); err != nil {
+		return nil, err
+	}
+	return captureResponse(res), nil
+}
+
+// Void calls the Void API
+func (s *Server) Void(ctx context.Context, req *linepaypb.PaymentsVoidRequest) (*linepaypb.PaymentsVoidResponse, error) {
+
+	id, err := transactionID(req.GetTransactionId())
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.client.PaymentsVoid(ctx, id)
+	if err != nil {
+		return nil, callError(ctx, err)
+	}
+	if err := returnCodeError(res.ReturnCode, res.ReturnMessage); err != nil {
+		return nil, err
+	}
+	return &linepaypb.PaymentsVoidResponse{ReturnCode: res.ReturnCode, ReturnMessage: res.ReturnMessage}, nil
+}
+
+// Refund calls the Refund API
+func (s *Server) Refund(ctx context.Context, req *linepaypb.PaymentsRefundRequest) (*linepaypb.PaymentsRefundResponse, error) {
+
+	id, err := transactionID(req.GetTransactionId())
+	if err != nil {
+		return nil, err
+	}
+	if req.GetRefundAmount() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "refund_amount must not be negative")
+	}
+
+	res, err := s.client.PaymentsRefund(ctx, id, &linepay.PaymentsRefundRequest{RefundAmount: int(req.GetRefundAmount())})
+	if err != nil {
+		return nil, callError(ctx, err)
+	}
+	if err := returnCodeError(res.ReturnCode, res.ReturnMessage); err != nil {
+		return nil, err
+	}
+	return refundResponse(res), nil
+}
+
+// Details calls the Payment Details API
+func (s *Server) Details(ctx context.Context, req *linepaypb.PaymentsDetailsRequest) (*linepaypb.PaymentsDetailsResponse, error) {
+
+	if len(req.GetTransactionIds()) == 0 && len(req.GetOrderIds()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "transaction_ids or order_ids is required")
+	}
+
+	request := &linepay.PaymentsDetailsRequest{OrderIDs: req.GetOrderIds(), Fields: req.GetFields()}
+	for _, id := range req.GetTransactionIds() {
+		request.TransactionIDs = append(request.TransactionIDs, linepay.TransactionID(id))
+	}
+
+	res, err := s.client.PaymentsDetails(ctx, request)
+	if err != nil {
+		return nil, callError(ctx, err)
+	}
+	if err := returnCodeError(res.ReturnCode, res.ReturnMessage); err != nil {
+		return nil, err
+	}
+	return detailsResponse(res), nil
+}
+
+// Status calls the Check Payment Status API, every payment status is answered with OK
+func (s *Server) Status(ctx context.Context, req *linepaypb.PaymentsStatusRequest) (*linepaypb.PaymentsStatusResponse, error) {
+
+	id, err := transactionID(req.GetTransactionId())
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.client.PaymentsStatus(ctx, id)
+	if err != nil {
+		return nil, callError(ctx, err)
+	}
+	st, ok := paymentStatus[res.ReturnCode]
+	if !ok {
+		return nil, returnCodeError(res.ReturnCode, res.ReturnMessage)
+	}
+	return &linepaypb.PaymentsStatusResponse{ReturnCode: res.ReturnCode, ReturnMessage: res.ReturnMessage, Status: st}, nil
+}
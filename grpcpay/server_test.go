@@ -0,0 +1,205 @@
+package grpcpay
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	linepay "github.com/chy168/line-pay-sdk-go"
+	"github.com/chy168/line-pay-sdk-go/grpcpay/linepaypb"
+	"github.com/chy168/line-pay-sdk-go/linepaymock"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// dial serves `api` on an in-memory listener and returns a client of it
+func dial(t *testing.T, api linepay.PaymentsAPI) linepaypb.PaymentsServiceClient {
+
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	linepaypb.RegisterPaymentsServiceServer(server, New(api))
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("grpc.NewClient() error = %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return linepaypb.NewPaymentsServiceClient(conn)
+}
+
+func TestServer(t *testing.T) {
+
+	ctx := context.Background()
+	authorized := time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	mock := linepaymock.New()
+	mock.On(linepay.OperationPaymentsRequest, linepaymock.Any).Do(func(ctx context.Context, args []interface{}) (interface{}, error) {
+		res := &linepay.PaymentsResponse{ReturnCode: linepay.ApiReturnCodeSuccess}
+		res.Info.TransactionID = 2023050100001
+		res.Info.PaymentURL.Web = "https://sandbox-web-pay.line.me/web/payment/wait?transactionReserveId=abc"
+		return res, nil
+	})
+	mock.On(linepay.OperationPaymentsConfirm, linepay.TransactionID(2023050100001), &linepay.PaymentsConfirmRequest{Amount: 250, Currency: "TWD"}).
+		Do(func(ctx context.Context, args []interface{}) (interface{}, error) {
+			res := &linepay.PaymentsConfirmResponse{ReturnCode: linepay.ApiReturnCodeSuccess}
+			res.Info.OrderID = "order-1"
+			res.Info.TransactionID = 2023050100001
+			res.Info.AuthorizationExpireDate = authorized
+			return res, nil
+		})
+	mock.On(linepay.OperationPaymentsCapture, linepay.TransactionID(2023050100001), linepaymock.Any).ReturnCode(linepay.ApiReturnCodeSuccess, "Success.")
+	mock.On(linepay.OperationPaymentsRefund, linepay.TransactionID(2023050100001), &linepay.PaymentsRefundRequest{RefundAmount: 50}).
+		Return(&linepay.PaymentsRefundResponse{ReturnCode: linepay.ApiReturnCodeSuccess, Info: linepay.PaymentsRefundInfoResponse{RefundTransactionID: 2023050100002}}, nil)
+	mock.On(linepay.OperationPaymentsRefund, linepay.TransactionID(2023050100001), linepaymock.Any).ReturnCode("1165", "Transaction already refunded.")
+	mock.On(linepay.OperationPaymentsVoid, linepay.TransactionID(2023050100003)).Return(nil, errors.New("connection reset"))
+	mock.On(linepay.OperationPaymentsDetails, &linepay.PaymentsDetailsRequest{OrderIDs: []string{"order-1"}}).
+		Return(&linepay.PaymentsDetailsResponse{ReturnCode: linepay.ApiReturnCodeSuccess, Info: []linepay.PaymentsDetailsInfoResponse{{TransactionID: 2023050100001, OrderID: "order-1"}}}, nil)
+	mock.On(linepay.OperationPaymentsDetails, linepaymock.Any).ReturnCode(linepay.ApiReturnCodeTransactionNotFound, "Transaction record not found.")
+	mock.On(linepay.OperationPaymentsStatus, linepay.TransactionID(2023050100001)).ReturnCode(linepay.PaymentsStatusAuthorized, "Authorized.")
+	mock.On(linepay.OperationPaymentsStatus, linepaymock.Any).ReturnCode(linepay.ApiReturnCodeHeaderError, "Header information error.")
+
+	client := dial(t, mock)
+
+	request, err := client.Request(ctx, &linepaypb.PaymentsRequest{Amount: 250, Currency: "TWD", OrderId: "order-1"})
+	if err != nil || request.GetInfo().GetTransactionId() != 2023050100001 || request.GetInfo().GetPaymentUrl().GetWeb() == "" {
+		t.Fatalf("Request() = %v, %v", request, err)
+	}
+	if sent := mock.CallsTo(linepay.OperationPaymentsRequest)[0].Args[0].(*linepay.PaymentsRequest); sent.OrderID != "order-1" || sent.Amount != 250 {
+		t.Errorf("PaymentsRequest request = %+v", sent)
+	}
+
+	confirm, err := client.Confirm(ctx, &linepaypb.PaymentsConfirmRequest{TransactionId: 2023050100001, Amount: 250, Currency: "TWD"})
+	if err != nil || confirm.GetInfo().GetOrderId() != "order-1" || !confirm.GetInfo().GetAuthorizationExpireDate().AsTime().Equal(authorized) {
+		t.Errorf("Confirm() = %v, %v", confirm, err)
+	}
+
+	if capture, err := client.Capture(ctx, &linepaypb.PaymentsCaptureRequest{TransactionId: 2023050100001, Amount: 250, Currency: "TWD"}); err != nil || capture.GetReturnCode() != linepay.ApiReturnCodeSuccess {
+		t.Errorf("Capture() = %v, %v", capture, err)
+	}
+
+	refund, err := client.Refund(ctx, &linepaypb.PaymentsRefundRequest{TransactionId: 2023050100001, RefundAmount: 50})
+	if err != nil || refund.GetInfo().GetRefundTransactionId() != 2023050100002 || refund.GetInfo().GetRefundTransactionDate() != nil {
+		t.Errorf("Refund() = %v, %v", refund, err)
+	}
+
+	details, err := client.Details(ctx, &linepaypb.PaymentsDetailsRequest{OrderIds: []string{"order-1"}})
+	if err != nil || len(details.GetInfo()) != 1 || details.GetInfo()[0].GetTransactionId() != 2023050100001 {
+		t.Errorf("Details() = %v, %v", details, err)
+	}
+
+	if st, err := client.Status(ctx, &linepaypb.PaymentsStatusRequest{TransactionId: 2023050100001}); err != nil || st.GetStatus() != linepaypb.PaymentStatus_PAYMENT_STATUS_AUTHORIZED {
+		t.Errorf("Status() = %v, %v", st, err)
+	}
+
+	// LINE Pay errors
+	_, err = client.Refund(ctx, &linepaypb.PaymentsRefundRequest{TransactionId: 2023050100001})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Refund() again error = %v, want FailedPrecondition", err)
+	}
+	if returnCode, ok := ReturnCode(err); !ok || returnCode != "1165" {
+		t.Errorf("ReturnCode(%v) = %q, %v", err, returnCode, ok)
+	}
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); !ok || info.Metadata["returnMessage"] != "Transaction already refunded." {
+			t.Errorf("Refund() error detail = %v", detail)
+		}
+	}
+
+	if _, err := client.Details(ctx, &linepaypb.PaymentsDetailsRequest{TransactionIds: []int64{404}}); status.Code(err) != codes.NotFound {
+		t.Errorf("Details() unknown error = %v, want NotFound", err)
+	}
+	if _, err := client.Status(ctx, &linepaypb.PaymentsStatusRequest{TransactionId: 404}); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Status() rejected error = %v, want Unauthenticated", err)
+	}
+	if _, err := client.Void(ctx, &linepaypb.PaymentsVoidRequest{TransactionId: 2023050100003}); status.Code(err) != codes.Unavailable {
+		t.Errorf("Void() network error = %v, want Unavailable", err)
+	}
+	if _, ok := ReturnCode(errors.New("connection reset")); ok {
+		t.Errorf("ReturnCode() of a plain error is ok")
+	}
+
+	// invalid arguments never reach LINE Pay
+	calls := len(mock.Calls())
+	if _, err := client.Confirm(ctx, &linepaypb.PaymentsConfirmRequest{Amount: 250, Currency: "TWD"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Confirm() without transaction_id error = %v", err)
+	}
+	if _, err := client.Details(ctx, &linepaypb.PaymentsDetailsRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Details() without ids error = %v", err)
+	}
+	if _, err := client.Request(ctx, &linepaypb.PaymentsRequest{Amount: 250}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Request() without order_id error = %v", err)
+	}
+	if len(mock.Calls()) != calls {
+		t.Errorf("invalid calls reached the client: %+v", mock.Calls()[calls:])
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestServerDeadline(t *testing.T) {
+
+	deadlines := make(chan time.Time, 1)
+	mock := linepaymock.New()
+	mock.On(linepay.OperationPaymentsVoid, linepaymock.Any).Do(func(ctx context.Context, args []interface{}) (interface{}, error) {
+		deadline, _ := ctx.Deadline()
+		deadlines <- deadline
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+
+	client := dial(t, mock)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	want, _ := ctx.Deadline()
+
+	_, err := client.Void(ctx, &linepaypb.PaymentsVoidRequest{TransactionId: 1})
+	if status.Code(err) != codes.DeadlineExceeded {
+		t.Errorf("Void() error = %v, want DeadlineExceeded", err)
+	}
+	var deadline time.Time
+	select {
+	case deadline = <-deadlines:
+	case <-time.After(time.Second):
+		t.Fatal("Void() did not reach the client")
+	}
+	if diff := deadline.Sub(want); deadline.IsZero() || diff > 50*time.Millisecond || diff < -100*time.Millisecond {
+		t.Errorf("SDK context deadline = %v, want about %v", deadline, want)
+	}
+}
+
+func TestCode(t *testing.T) {
+
+	tests := []struct {
+		returnCode string
+		want       codes.Code
+	}{
+		{linepay.ApiReturnCodeSuccess, codes.OK},
+		{linepay.ApiReturnCodeHeaderError, codes.Unauthenticated},
+		{linepay.ApiReturnCodeTransactionNotFound, codes.NotFound},
+		{"1172", codes.AlreadyExists},
+		{"1124", codes.InvalidArgument},
+		{"1165", codes.FailedPrecondition},
+		{linepay.ApiReturnCodeRegKeyExpired, codes.FailedPrecondition},
+		{linepay.ApiReturnCodePreapprovedForbidden, codes.PermissionDenied},
+		{"1198", codes.Aborted},
+		{"9000", codes.Internal},
+		{"4242", codes.Unknown},
+	}
+	for _, tt := range tests {
+		if got := Code(tt.returnCode); got != tt.want {
+			t.Errorf("Code(%q) = %v, want %v", tt.returnCode, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package grpcpay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	linepay "github.com/chy168/line-pay-sdk-go"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorDomain is the `domain` of the google.rpc.ErrorInfo detail of a LINE Pay error
+const ErrorDomain = "pay.line.me"
+
+// codeOf the LINE Pay return codes, an unlisted code is codes.Unknown
+var codeOf = map[string]codes.Code{
+	linepay.ApiReturnCodeSuccess: codes.OK,
+
+	linepay.ApiReturnCodeHeaderError: codes.Unauthenticated, // channel id or secret rejected
+
+	"1102": codes.PermissionDenied, // user restricted from transactions
+	"1104": codes.PermissionDenied, // merchant not found
+	"1105": codes.PermissionDenied, // merchant cannot use LINE Pay
+	"1283": codes.PermissionDenied, // suspected fraud
+	linepay.ApiReturnCodePreapprovedForbidden: codes.PermissionDenied,
+
+	"1124": codes.InvalidArgument, // amount error
+	"1153": codes.InvalidArgument, // amount different from the request
+	"1177": codes.InvalidArgument, // more than 100 transactions requested
+	"1178": codes.InvalidArgument, // currency not supported
+	"1183": codes.InvalidArgument, // amount not greater than 0
+	"1184": codes.InvalidArgument, // refund amount greater than the payment
+	"2101": codes.InvalidArgument, // parameter error
+	"2102": codes.InvalidArgument, // JSON error
+
+	linepay.ApiReturnCodeTransactionNotFound: codes.NotFound,
+	"1159":                                   codes.NotFound, // payment request not found
+	linepay.ApiReturnCodeRegKeyNotFound:      codes.NotFound,
+
+	"1152": codes.AlreadyExists, // transaction exists
+	"1172": codes.AlreadyExists, // order id exists
+
+	"1101":                             codes.FailedPrecondition, // not a LINE Pay user
+	"1110":                             codes.FailedPrecondition, // credit card unavailable
+	"1141":                             codes.FailedPrecondition, // account status error
+	"1142":                             codes.FailedPrecondition, // insufficient balance
+	"1155":                             codes.FailedPrecondition, // transaction cannot be refunded
+	"1163":                             codes.FailedPrecondition, // refund period over
+	"1164":                             codes.FailedPrecondition, // refundable amount exceeded
+	"1165":                             codes.FailedPrecondition, // already refunded
+	"1179":                             codes.FailedPrecondition, // transaction status cannot be processed
+	"1180":                             codes.FailedPrecondition, // payment deadline expired
+	linepay.ApiReturnCodeRegKeyExpired: codes.FailedPrecondition,
+	"1281":                             codes.FailedPrecondition, // credit card payment error
+	"1282":                             codes.FailedPrecondition, // credit card authorization error
+	"1287":                             codes.FailedPrecondition, // credit card expired
+	"1288":                             codes.FailedPrecondition, // insufficient credit card balance
+	"1289":                             codes.FailedPrecondition, // credit limit exceeded
+
+	"1145": codes.Aborted, // payment in progress
+	"1170": codes.Aborted, // balance changed during the payment
+	"1198": codes.Aborted, // request repeated
+
+	"1280": codes.Unavailable, // temporary credit card error
+	"1284": codes.Unavailable, // credit card payment suspended
+
+	"1199": codes.Internal, // LINE Pay internal request error
+	"9000": codes.Internal, // LINE Pay internal error
+}
+
+// Code maps a LINE Pay `returnCode` to the gRPC code of the status returned by the Server
+func Code(returnCode string) codes.Code {
+	if code, ok := codeOf[returnCode]; ok {
+		return code
+	}
+	return codes.Unknown
+}
+
+// returnCodeError is the status of a LINE Pay `returnCode` other than success, with an ErrorInfo detail
+func returnCodeError(returnCode, returnMessage string) error {
+
+	if returnCode == linepay.ApiReturnCodeSuccess {
+		return nil
+	}
+
+	st := status.New(Code(returnCode), fmt.Sprintf("LINE Pay returned %s: %s", returnCode, returnMessage))
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason:   returnCode,
+		Domain:   ErrorDomain,
+		Metadata: map[string]string{"returnMessage": returnMessage},
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// callError is the status of a call which got no LINE Pay response
+func callError(ctx context.Context, err error) error {
+	switch {
+	case ctx.Err() == context.DeadlineExceeded || errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case ctx.Err() == context.Canceled || errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, linepay.ErrNotSupportedByV2):
+		return status.Error(codes.Unimplemented, err.Error())
+	}
+	return status.Error(codes.Unavailable, err.Error())
+}
+
+// ReturnCode returns the LINE Pay `returnCode` of an error returned by a PaymentsService client
+func ReturnCode(err error) (returnCode string, ok bool) {
+
+	st, ok := status.FromError(err)
+	if !ok {
+		return "", false
+	}
+	for _, detail := range st.Details() {
+		if info, isInfo := detail.(*errdetails.ErrorInfo); isInfo && info.Domain == ErrorDomain {
+			return info.Reason, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,160 @@
+package linepay
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ErrCredentialsRejected is returned by VerifyCredentials when LINE Pay rejects the channel id or secret, also when
+// they belong to the other environment (sandbox or production)
+var ErrCredentialsRejected = errors.New("linepay: credentials rejected")
+
+// healthCheckOrderID is an order id no payment uses
+const healthCheckOrderID = "line-pay-sdk-go-healthcheck"
+
+// GET /v3/payments, separate from PaymentsDetails in the metrics, limits and circuits
+var endpointVerifyCredentials = &Endpoint{Operation: OperationVerifyCredentials, Method: http.MethodGet, Path: "/v3/payments"}
+
+// VerifyCredentials makes a harmless signed call, the Payment Details of an order which does not exist.
+// It returns nil when LINE Pay authenticated the call, an error matching ErrCredentialsRejected when LINE Pay
+// rejected the channel id or secret (returnCode 1106 or HTTP 401), another error when LINE Pay cannot be reached.
+//
+//	if err := client.VerifyCredentials(ctx); err != nil {
+//		log.Fatalf("LINE Pay channel misconfigured: %v", err)
+//	}
+func (client *Client) VerifyCredentials(ctx context.Context) error {
+
+	var res struct {
+		ReturnCode    string `json:"returnCode"`
+		ReturnMessage string `json:"returnMessage"`
+	}
+	_, err := client.Call(ctx, endpointVerifyCredentials, url.Values{"orderId": {healthCheckOrderID}}, &res)
+
+	var status *statusError
+	if errors.As(err, &status) && status.StatusCode == http.StatusUnauthorized {
+		return fmt.Errorf("%w: HTTP %d", ErrCredentialsRejected, status.StatusCode)
+	}
+	if err != nil {
+		return err
+	}
+
+	switch res.ReturnCode {
+	case ApiReturnCodeTransactionNotFound, ApiReturnCodeSuccess:
+		return nil
+	case ApiReturnCodeHeaderError:
+		return fmt.Errorf("%w: %s %s", ErrCredentialsRejected, res.ReturnCode, res.ReturnMessage)
+	}
+	return fmt.Errorf("unexpected returnCode %s: %s", res.ReturnCode, res.ReturnMessage)
+}
+
+// Ping is VerifyCredentials, and returns the duration of the call
+func (client *Client) Ping(ctx context.Context) (latency time.Duration, err error) {
+	start := time.Now()
+	err = client.VerifyCredentials(ctx)
+	return time.Since(start), err
+}
+
+// DefaultHealthTTL is how long HealthHandler keeps a result when `HealthOptions.TTL` is not set
+const DefaultHealthTTL = 30 * time.Second
+
+// HealthOptions of HealthHandler.
+// `TTL` optional, how long a result is answered before checking again, DefaultHealthTTL by default
+// `Timeout` optional, limits a check, 5 seconds by default
+type HealthOptions struct {
+	TTL     time.Duration
+	Timeout time.Duration
+}
+
+// Health is the JSON body of HealthHandler.
+// `Status` is "ok", or "fail" with the `Error` of the check, "credentials rejected" or "unavailable" (the details
+// are logged). `Circuits` lists the operations whose circuit is not closed (see BreakerPolicy), they do not fail the
+// check.
+type Health struct {
+	Status    string            `json:"status"`
+	ChannelID string            `json:"channelId"`
+	CheckedAt time.Time         `json:"checkedAt"`
+	LatencyMs int64             `json:"latencyMs"`
+	Error     string            `json:"error,omitempty"`
+	Circuits  map[string]string `json:"circuits,omitempty"`
+}
+
+// HealthHandler answers the result of Ping for a `/healthz` or readiness endpoint: 200 when LINE Pay authenticated
+// the channel, 503 otherwise. A result is kept `TTL`, so frequent probes do not call LINE Pay. `opts` may be nil.
+//
+//	http.Handle("/healthz", linepay.HealthHandler(client, nil))
+func HealthHandler(client *Client, opts *HealthOptions) http.Handler {
+
+	h := &healthHandler{client: client, ttl: DefaultHealthTTL, timeout: 5 * time.Second}
+	if opts != nil && opts.TTL > 0 {
+		h.ttl = opts.TTL
+	}
+	if opts != nil && opts.Timeout > 0 {
+		h.timeout = opts.Timeout
+	}
+	return h
+}
+
+type healthHandler struct {
+	client  *Client
+	ttl     time.Duration
+	timeout time.Duration
+
+	mu   sync.Mutex
+	last *Health
+}
+
+func (h *healthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	health := h.check()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if health.Status != "ok" {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	json.NewEncoder(w).Encode(health)
+}
+
+// check returns the last result while fresh, the concurrent requests wait for a single check
+func (h *healthHandler) check() Health {
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.last == nil || time.Since(h.last.CheckedAt) >= h.ttl {
+		// not the request context, a client going away must not save a failed check
+		ctx, cancel := context.WithTimeout(context.Background(), h.timeout)
+		defer cancel()
+
+		latency, err := h.client.Ping(ctx)
+		health := &Health{Status: "ok", ChannelID: h.client.ChannelID(), CheckedAt: time.Now(), LatencyMs: latency.Milliseconds()}
+		if err != nil {
+			// the error may hold the LINE Pay URL or returnMessage, the probe only gets its class
+			logrus.Warnf("linepay health check error: %s", err.Error())
+			health.Status, health.Error = "fail", "unavailable"
+			if errors.Is(err, ErrCredentialsRejected) {
+				health.Error = "credentials rejected"
+			}
+		}
+		h.last = health
+	}
+
+	health := *h.last
+	for operation, state := range h.client.CircuitStates() {
+		if state != CircuitClosed {
+			if health.Circuits == nil {
+				health.Circuits = map[string]string{}
+			}
+			health.Circuits[operation] = state.String()
+		}
+	}
+	return health
+}
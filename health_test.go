@@ -0,0 +1,108 @@
+package linepay
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// healthServer answers by channel: 1001 is known, 1002 is rejected, 1003 is refused with HTTP 401
+func healthServer(t *testing.T, calls *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(calls, 1)
+		if r.URL.Path != "/v3/payments" || r.URL.Query().Get("orderId") != healthCheckOrderID {
+			t.Errorf("health check request %s", r.URL)
+		}
+		switch r.Header.Get("X-LINE-ChannelId") {
+		case "1001":
+			fmt.Fprint(w, `{"returnCode":"1150","returnMessage":"Transaction record not found."}`)
+		case "1002":
+			fmt.Fprint(w, `{"returnCode":"1106","returnMessage":"Header information error."}`)
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+}
+
+func TestClient_VerifyCredentials(t *testing.T) {
+
+	var calls int32
+	ts := healthServer(t, &calls)
+	defer ts.Close()
+
+	ctx := context.Background()
+	for _, tt := range []struct {
+		channelID string
+		rejected  bool
+	}{
+		{"1001", false},
+		{"1002", true},
+		{"1003", true},
+	} {
+		client, _ := NewClient(tt.channelID, "secret", nil, &ClientOpts{APIEndpoint: ts.URL})
+		err := client.VerifyCredentials(ctx)
+		if rejected := errors.Is(err, ErrCredentialsRejected); rejected != tt.rejected || (!tt.rejected && err != nil) {
+			t.Errorf("channel %s VerifyCredentials() error = %v, want rejected %v", tt.channelID, err, tt.rejected)
+		}
+	}
+
+	// unreachable is not rejected
+	ts.Close()
+	client, _ := NewClient("1001", "secret", nil, &ClientOpts{APIEndpoint: ts.URL})
+	if _, err := client.Ping(ctx); err == nil || errors.Is(err, ErrCredentialsRejected) {
+		t.Errorf("Ping() of a closed server error = %v", err)
+	}
+}
+
+func TestHealthHandler(t *testing.T) {
+
+	var calls int32
+	ts := healthServer(t, &calls)
+	defer ts.Close()
+
+	get := func(h http.Handler) (int, Health) {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+		var health Health
+		if err := json.Unmarshal(w.Body.Bytes(), &health); err != nil {
+			t.Fatalf("health body %q: %v", w.Body.String(), err)
+		}
+		return w.Code, health
+	}
+
+	client, _ := NewClient("1001", "secret", nil, &ClientOpts{APIEndpoint: ts.URL})
+	h := HealthHandler(client, &HealthOptions{TTL: 50 * time.Millisecond})
+
+	for i := 0; i < 3; i++ {
+		if code, health := get(h); code != http.StatusOK || health.Status != "ok" || health.ChannelID != "1001" {
+			t.Errorf("healthz = %d %+v", code, health)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("%d LINE Pay calls within the TTL, want 1", calls)
+	}
+	time.Sleep(60 * time.Millisecond)
+	get(h)
+	if calls != 2 {
+		t.Errorf("%d LINE Pay calls after the TTL, want 2", calls)
+	}
+
+	rejected, _ := NewClient("1002", "secret", nil, &ClientOpts{APIEndpoint: ts.URL})
+	if code, health := get(HealthHandler(rejected, nil)); code != http.StatusServiceUnavailable || health.Status != "fail" || health.Error != "credentials rejected" {
+		t.Errorf("healthz of rejected credentials = %d %+v", code, health)
+	}
+
+	// the error of an unreachable LINE Pay is not answered, it names the host
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+	unreachable, _ := NewClient("1001", "secret", nil, &ClientOpts{APIEndpoint: down.URL})
+	if code, health := get(HealthHandler(unreachable, nil)); code != http.StatusServiceUnavailable || health.Error != "unavailable" {
+		t.Errorf("healthz of an unreachable LINE Pay = %d %+v", code, health)
+	}
+}
@@ -0,0 +1,211 @@
+package linepay
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultIdempotencyTTL is how long a response is replayed when `IdempotencyPolicy.TTL` is not set
+const DefaultIdempotencyTTL = 24 * time.Hour
+
+// ErrIdempotencyKeyReused is returned when an idempotency key is sent again with another operation or request body
+var ErrIdempotencyKeyReused = errors.New("idempotency key reused with a different request")
+
+// IdempotencyPolicy of the POST calls made with an idempotency key, see WithIdempotencyKey.
+// `Store` optional, an in-memory store of the client by default, share a store between the instances of a service
+// `TTL` optional, how long a response is replayed, DefaultIdempotencyTTL by default
+type IdempotencyPolicy struct {
+	Store IdempotencyStore
+	TTL   time.Duration
+}
+
+// IdempotentResponse is the saved response of a call made with an idempotency key.
+// `Fingerprint` identifies the operation, path and body of the call. `Body` holds regKeys and payment access
+// tokens like ResponseMeta.Body: a persistent store must protect it.
+type IdempotentResponse struct {
+	Fingerprint string      `json:"fingerprint"`
+	StatusCode  int         `json:"statusCode"`
+	Header      http.Header `json:"header"`
+	Body        []byte      `json:"body"`
+}
+
+// IdempotencyStore saves the successful responses of the calls made with an idempotency key.
+// Get returns nil without error for an unknown or expired key.
+type IdempotencyStore interface {
+	Get(ctx context.Context, key string) (*IdempotentResponse, error)
+	Put(ctx context.Context, key string, response *IdempotentResponse, ttl time.Duration) error
+}
+
+type idempotencyKey struct{}
+
+// WithIdempotencyKey returns a context making the POST calls of the Client idempotent on `key`: the first successful
+// response (returnCode 0000) is saved, and a later call with the same key gets it back with `Meta.Replayed` set,
+// instead of e.g. a duplicate order error. Concurrent calls with the same key are sent once.
+// Use one key per operation, e.g. "confirm:" + orderID; a key sent with another request fails with
+// ErrIdempotencyKeyReused. A call answered with another returnCode is not saved and may be sent again.
+//
+//	ctx = linepay.WithIdempotencyKey(ctx, "request:"+orderID)
+//	res, err := client.PaymentsRequest(ctx, request) // safe to retry
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKey{}, key)
+}
+
+// IdempotencyKey returns the key set by WithIdempotencyKey, or ""
+func IdempotencyKey(ctx context.Context) string {
+	key, _ := ctx.Value(idempotencyKey{}).(string)
+	return key
+}
+
+// idempotent sends the call once for `key`, the response of `send` is saved when successful.
+// `replayed` is false when this call sent the request.
+func (client *Client) idempotent(ctx context.Context, key, fingerprint string, send func(ctx context.Context) (*http.Response, []byte, error)) (res *http.Response, body []byte, replayed bool, err error) {
+
+	key = client.channelID + ":" + key
+
+	result, leader, err := client.flights.do(ctx, key, func() (*flightResult, error) {
+
+		saved, err := client.idempotency.Store.Get(ctx, key)
+		if err != nil {
+			return nil, fmt.Errorf("idempotency store error: %w", err)
+		}
+		if saved != nil {
+			return &flightResult{response: saved}, nil
+		}
+
+		res, body, err := send(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		saved = &IdempotentResponse{Fingerprint: fingerprint, StatusCode: res.StatusCode, Header: res.Header, Body: body}
+		if returnCodeOf(body) == ApiReturnCodeSuccess {
+			if err := client.idempotency.Store.Put(ctx, key, saved, client.idempotency.TTL); err != nil {
+				logrus.Warnf("idempotency store error, the response is not saved: %s", err.Error())
+			}
+		}
+		return &flightResult{response: saved, sent: true}, nil
+	})
+	if err != nil {
+		return
+	}
+	if result.response.Fingerprint != fingerprint {
+		err = ErrIdempotencyKeyReused
+		return
+	}
+
+	res = &http.Response{StatusCode: result.response.StatusCode, Header: result.response.Header}
+	return res, result.response.Body, !leader || !result.sent, nil
+}
+
+// fingerprint of a call, to tell a retry from another call reusing the key
+func fingerprint(operation, path string, body []byte) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\n%s\n", operation, path)
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// flightGroup collapses the concurrent calls of a key into the first one
+type flightGroup struct {
+	mu      sync.Mutex
+	flights map[string]*flight
+}
+
+type flight struct {
+	done   chan struct{}
+	result *flightResult
+	err    error
+}
+
+// flightResult tells whether the response was sent by the flight or read from the store
+type flightResult struct {
+	response *IdempotentResponse
+	sent     bool
+}
+
+// do runs `fn` unless a call of `key` is in flight, then it waits for its result. `leader` tells `fn` was run.
+func (g *flightGroup) do(ctx context.Context, key string, fn func() (*flightResult, error)) (result *flightResult, leader bool, err error) {
+
+	g.mu.Lock()
+	if f, ok := g.flights[key]; ok {
+		g.mu.Unlock()
+		select {
+		case <-f.done:
+			return f.result, false, f.err
+		case <-ctx.Done():
+			return nil, false, ctx.Err()
+		}
+	}
+	if g.flights == nil {
+		g.flights = map[string]*flight{}
+	}
+	f := &flight{done: make(chan struct{})}
+	g.flights[key] = f
+	g.mu.Unlock()
+
+	defer func() {
+		g.mu.Lock()
+		delete(g.flights, key)
+		g.mu.Unlock()
+		close(f.done)
+	}()
+
+	f.result, f.err = fn()
+	return f.result, true, f.err
+}
+
+// MemoryIdempotencyStore is the IdempotencyStore of a single process, safe for concurrent use
+type MemoryIdempotencyStore struct {
+	mu      sync.Mutex
+	entries map[string]memoryIdempotencyEntry
+	purged  time.Time
+}
+
+type memoryIdempotencyEntry struct {
+	response *IdempotentResponse
+	expires  time.Time
+}
+
+func NewMemoryIdempotencyStore() *MemoryIdempotencyStore {
+	return &MemoryIdempotencyStore{entries: map[string]memoryIdempotencyEntry{}}
+}
+
+func (s *MemoryIdempotencyStore) Get(ctx context.Context, key string) (*IdempotentResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.entries[key]
+	if !ok || !time.Now().Before(e.expires) {
+		return nil, nil
+	}
+	response := *e.response
+	return &response, nil
+}
+
+// Put saves `response` for `ttl`, the expired entries are dropped every minute
+func (s *MemoryIdempotencyStore) Put(ctx context.Context, key string, response *IdempotentResponse, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(s.purged) > time.Minute {
+		for k, e := range s.entries {
+			if !now.Before(e.expires) {
+				delete(s.entries, k)
+			}
+		}
+		s.purged = now
+	}
+
+	saved := *response
+	s.entries[key] = memoryIdempotencyEntry{response: &saved, expires: now.Add(ttl)}
+	return nil
+}
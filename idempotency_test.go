@@ -0,0 +1,111 @@
+package linepay
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClient_Idempotency(t *testing.T) {
+
+	var requests, confirms int32
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v3/payments/request":
+			// LINE Pay rejects an order id sent twice
+			if atomic.AddInt32(&requests, 1) > 1 {
+				fmt.Fprint(w, `{"returnCode":"1172","returnMessage":"Existing same orderId."}`)
+				return
+			}
+			fmt.Fprint(w, `{"returnCode":"0000","returnMessage":"Success.","info":{"transactionId":2023050100001,"paymentAccessToken":"187568751124"}}`)
+		case "/v3/payments/2023050100001/confirm":
+			atomic.AddInt32(&confirms, 1)
+			<-release
+			fmt.Fprint(w, `{"returnCode":"0000","returnMessage":"Success.","info":{"orderId":"order-1","transactionId":2023050100001}}`)
+		}
+	}))
+	defer ts.Close()
+
+	client, _ := NewClient("1001", "secret", nil, &ClientOpts{APIEndpoint: ts.URL})
+	request := &PaymentsRequest{Amount: 100, Currency: "TWD", OrderID: "order-1"}
+
+	ctx := WithIdempotencyKey(context.Background(), "request:order-1")
+	first, err := client.PaymentsRequest(ctx, request)
+	if err != nil || first.ReturnCode != ApiReturnCodeSuccess || first.Meta.Replayed {
+		t.Fatalf("first PaymentsRequest() = %+v, %v", first, err)
+	}
+	again, err := client.PaymentsRequest(ctx, request)
+	if err != nil || again.Info.TransactionID != 2023050100001 || !again.Meta.Replayed || requests != 1 {
+		t.Errorf("retried PaymentsRequest() = %+v, %v after %d requests", again, err, requests)
+	}
+
+	if _, err := client.PaymentsRequest(ctx, &PaymentsRequest{Amount: 200, Currency: "TWD", OrderID: "order-1"}); err != ErrIdempotencyKeyReused {
+		t.Errorf("PaymentsRequest() of another body error = %v", err)
+	}
+
+	// a rejected call is not saved, nor a call without key
+	ctx = WithIdempotencyKey(context.Background(), "request:order-2")
+	for i := 0; i < 2; i++ {
+		if res, err := client.PaymentsRequest(ctx, request); err != nil || res.ReturnCode != "1172" || res.Meta.Replayed {
+			t.Errorf("rejected PaymentsRequest() = %+v, %v", res, err)
+		}
+	}
+	client.PaymentsRequest(context.Background(), request)
+	if requests != 4 {
+		t.Errorf("%d requests sent, want 4", requests)
+	}
+
+	// concurrent calls are sent once
+	ctx = WithIdempotencyKey(context.Background(), "confirm:order-1")
+	var wg sync.WaitGroup
+	var sent int32
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res, err := client.PaymentsConfirm(ctx, 2023050100001, &PaymentsConfirmRequest{Amount: 100, Currency: "TWD"})
+			if err != nil || res.Info.OrderID != "order-1" {
+				t.Errorf("PaymentsConfirm() = %+v, %v", res, err)
+				return
+			}
+			if !res.Meta.Replayed {
+				atomic.AddInt32(&sent, 1)
+			}
+		}()
+	}
+	for atomic.LoadInt32(&confirms) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	if confirms != 1 || sent != 1 {
+		t.Errorf("%d confirms sent, %d responses not replayed, want 1", confirms, sent)
+	}
+}
+
+func TestMemoryIdempotencyStore(t *testing.T) {
+
+	ctx := context.Background()
+	store := NewMemoryIdempotencyStore()
+
+	saved := &IdempotentResponse{Fingerprint: "f", StatusCode: http.StatusOK, Body: []byte(`{"returnCode":"0000"}`)}
+	store.Put(ctx, "kept", saved, time.Hour)
+	store.Put(ctx, "expired", saved, 0)
+
+	if res, err := store.Get(ctx, "kept"); err != nil || res == nil || string(res.Body) != string(saved.Body) {
+		t.Errorf("Get(kept) = %+v, %v", res, err)
+	}
+	if res, err := store.Get(ctx, "expired"); err != nil || res != nil {
+		t.Errorf("Get(expired) = %+v, %v", res, err)
+	}
+	if res, err := store.Get(ctx, "unknown"); err != nil || res != nil {
+		t.Errorf("Get(unknown) = %+v, %v", res, err)
+	}
+}
@@ -0,0 +1,47 @@
+package ledger
+
+// Account of the chart of accounts, e.g. "4000 Sales"
+type Account string
+
+// Chart maps the LINE Pay movements to the accounts of the ledger.
+// `Holds` debited by an authorization, credited back by its capture, void or expiry, against `HoldsPending`
+// `Methods` debited by a capture for every `payInfo` method, e.g. CREDIT_CARD, BALANCE, DISCOUNT; `OtherMethods` for the methods not listed
+// `Sales` credited by a capture, net of `ShippingFees` and `UserFees` of the packages
+// `Refunds` debited by a refund, against `RefundsPayable`
+type Chart struct {
+	Holds          Account
+	HoldsPending   Account
+	Methods        map[string]Account
+	OtherMethods   Account
+	Sales          Account
+	ShippingFees   Account
+	UserFees       Account
+	Refunds        Account
+	RefundsPayable Account
+}
+
+// DefaultChart returns the chart used when New is given none
+func DefaultChart() *Chart {
+	return &Chart{
+		Holds:        "1190 LINE Pay authorizations",
+		HoldsPending: "2190 LINE Pay authorizations pending",
+		Methods: map[string]Account{
+			"CREDIT_CARD": "1110 LINE Pay credit card receivable",
+			"BALANCE":     "1120 LINE Pay balance receivable",
+			"DISCOUNT":    "6110 LINE Pay discounts",
+		},
+		OtherMethods:   "1130 LINE Pay other receivable",
+		Sales:          "4000 Sales",
+		ShippingFees:   "4100 Shipping fees",
+		UserFees:       "4200 User fees",
+		Refunds:        "4900 Sales refunds",
+		RefundsPayable: "2110 LINE Pay refunds payable",
+	}
+}
+
+func (chart *Chart) method(method string) Account {
+	if a, ok := chart.Methods[method]; ok {
+		return a
+	}
+	return chart.OtherMethods
+}
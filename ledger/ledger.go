@@ -0,0 +1,385 @@
+package ledger
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	linepay "github.com/chy168/line-pay-sdk-go"
+)
+
+// Kind of the LINE Pay movement booked by an Entry
+type Kind string
+
+const (
+	KindAuthorization Kind = "AUTHORIZATION"
+	KindCapture       Kind = "CAPTURE"
+	KindVoid          Kind = "VOID"
+	KindExpiry        Kind = "EXPIRY"
+	KindRefund        Kind = "REFUND"
+)
+
+// Line of an Entry, either `Debit` or `Credit` is set
+type Line struct {
+	Account Account `json:"account"`
+	Debit   int     `json:"debit,omitempty"`
+	Credit  int     `json:"credit,omitempty"`
+}
+
+// Entry is a balanced journal entry of a LINE Pay movement.
+// `ID` is derived from the movement, e.g. `capture:<transactionId>`, so feeding the same response twice posts it once.
+// `TransactionID` is the refund transaction of a KindRefund, `OriginalTransactionID` its payment.
+// `ShippingFee` and `UserFee` of a KindAuthorization are booked by its capture.
+type Entry struct {
+	ID                    string                `json:"id"`
+	Kind                  Kind                  `json:"kind"`
+	OrderID               string                `json:"orderId"`
+	TransactionID         linepay.TransactionID `json:"transactionId"`
+	OriginalTransactionID linepay.TransactionID `json:"originalTransactionId,omitempty"`
+	Currency              string                `json:"currency"`
+	PostedAt              time.Time             `json:"postedAt"`
+	Lines                 []Line                `json:"lines"`
+	ShippingFee           int                   `json:"shippingFee,omitempty"`
+	UserFee               int                   `json:"userFee,omitempty"`
+}
+
+// Balanced tells whether the debits equal the credits
+func (entry *Entry) Balanced() bool {
+	var debit, credit int
+	for _, l := range entry.Lines {
+		debit += l.Debit
+		credit += l.Credit
+	}
+	return debit == credit
+}
+
+func (entry *Entry) amount() (amount int) {
+	for _, l := range entry.Lines {
+		amount += l.Debit
+	}
+	return
+}
+
+func (entry *Entry) debit(account Account, amount int) {
+	if amount != 0 {
+		entry.Lines = append(entry.Lines, Line{Account: account, Debit: amount})
+	}
+}
+
+func (entry *Entry) credit(account Account, amount int) {
+	if amount != 0 {
+		entry.Lines = append(entry.Lines, Line{Account: account, Credit: amount})
+	}
+}
+
+// Ledger books the LINE Pay responses as double-entry journal entries.
+// Responses which did not succeed are ignored.
+type Ledger struct {
+	store Store
+	chart *Chart
+	now   func() time.Time
+}
+
+// New creates a Ledger, DefaultChart is used when `chart` is nil
+func New(store Store, chart *Chart) *Ledger {
+	if chart == nil {
+		chart = DefaultChart()
+	}
+	return &Ledger{store: store, chart: chart, now: time.Now}
+}
+
+// payInfo is the split of a payment by method
+type payInfo struct {
+	method string
+	amount int
+}
+
+// Confirm books the payment confirmed by `PaymentsConfirm`: an authorization hold when it was not captured
+// (`options.payment.capture` false), a capture otherwise.
+// With a nil `request` the amount is the sum of the `payInfo` and the currency the one of the response.
+func (l *Ledger) Confirm(ctx context.Context, request *linepay.PaymentsConfirmRequest, res *linepay.PaymentsConfirmResponse) error {
+
+	if res.ReturnCode != linepay.ApiReturnCodeSuccess {
+		return nil
+	}
+
+	info := res.Info
+	userFee := 0
+	for _, p := range info.Packages {
+		userFee += p.UserFeeAmount
+	}
+
+	var split []payInfo
+	amount, currency := 0, res.Currency
+	for _, p := range info.PayInfo {
+		split = append(split, payInfo{method: p.Method, amount: p.Amount})
+		amount += p.Amount
+	}
+	if request != nil {
+		amount, currency = request.Amount, request.Currency
+	}
+	if currency == "" {
+		return fmt.Errorf("%w: confirm of transaction %s", ErrNoCurrency, info.TransactionID)
+	}
+
+	if !info.AuthorizationExpireDate.IsZero() {
+		return l.hold(ctx, info.OrderID, info.TransactionID, currency, amount, info.Shipping.FeeAmount, userFee)
+	}
+	return l.capture(ctx, info.OrderID, info.TransactionID, currency, split, info.Shipping.FeeAmount, userFee, false)
+}
+
+// Capture books the capture of an authorization, the fees are the ones of the authorization booked by Confirm or Details.
+// With a nil `request` the currency is the one of the authorization.
+func (l *Ledger) Capture(ctx context.Context, request *linepay.PaymentsCaptureRequest, res *linepay.PaymentsCaptureResponse) error {
+
+	if res.ReturnCode != linepay.ApiReturnCodeSuccess {
+		return nil
+	}
+
+	var split []payInfo
+	for _, p := range res.Info.PayInfo {
+		split = append(split, payInfo{method: p.Method, amount: p.Amount})
+	}
+	currency := ""
+	if request != nil {
+		currency = request.Currency
+	}
+	return l.capture(ctx, res.Info.OrderID, res.Info.TransactionID, currency, split, 0, 0, true)
+}
+
+// Refund books the refund of the captured payment `transactionId`, the capture must have been booked before.
+// A `request` without `RefundAmount` refunds what was not refunded yet.
+func (l *Ledger) Refund(ctx context.Context, transactionId linepay.TransactionID, request *linepay.PaymentsRefundRequest, res *linepay.PaymentsRefundResponse) error {
+
+	if res.ReturnCode != linepay.ApiReturnCodeSuccess {
+		return nil
+	}
+
+	capture, err := l.store.Get(ctx, captureID(transactionId))
+	if err != nil {
+		return fmt.Errorf("ledger: capture of transaction %s: %s", transactionId, err.Error())
+	}
+
+	amount := 0
+	if request != nil {
+		amount = request.RefundAmount
+	}
+	if amount == 0 {
+		entries, err := l.store.Entries(ctx, capture.OrderID)
+		if err != nil {
+			return err
+		}
+		amount = l.captured(capture)
+		for _, e := range entries {
+			if e.Kind == KindRefund && e.OriginalTransactionID == transactionId {
+				amount -= e.amount()
+			}
+		}
+	}
+
+	return l.refund(ctx, capture.OrderID, transactionId, res.Info.RefundTransactionID, capture.Currency, amount)
+}
+
+// Details books every transaction of a `PaymentsDetails` response: authorizations with their void or expiry,
+// captures with their refunds. Movements already booked are skipped.
+func (l *Ledger) Details(ctx context.Context, res *linepay.PaymentsDetailsResponse) error {
+
+	if res.ReturnCode != linepay.ApiReturnCodeSuccess {
+		return nil
+	}
+
+	for _, info := range res.Info {
+		var err error
+		switch {
+		case info.OriginalTransactionID != 0:
+			amount := 0
+			for _, p := range info.PayInfo {
+				amount += abs(p.Amount)
+			}
+			err = l.refund(ctx, info.OrderID, info.OriginalTransactionID, info.TransactionID, info.Currency, amount)
+
+		case info.PayStatus == "AUTHORIZATION" || info.PayStatus == "VOIDED_AUTHORIZATION" || info.PayStatus == "EXPIRED_AUTHORIZATION":
+			err = l.details(ctx, info, false)
+
+		default:
+			err = l.details(ctx, info, true)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (l *Ledger) details(ctx context.Context, info linepay.PaymentsDetailsInfoResponse, captured bool) error {
+
+	amount := 0
+	var split []payInfo
+	for _, p := range info.PayInfo {
+		amount += p.Amount
+		split = append(split, payInfo{method: p.Method, amount: p.Amount})
+	}
+	userFee := 0
+	for _, p := range info.Packages {
+		userFee += p.UserFeeAmount
+	}
+
+	if !captured {
+		if err := l.hold(ctx, info.OrderID, info.TransactionID, info.Currency, amount, info.Shipping.FeeAmount, userFee); err != nil {
+			return err
+		}
+		switch info.PayStatus {
+		case "VOIDED_AUTHORIZATION":
+			return l.release(ctx, KindVoid, info.TransactionID)
+		case "EXPIRED_AUTHORIZATION":
+			return l.release(ctx, KindExpiry, info.TransactionID)
+		}
+		return nil
+	}
+
+	if err := l.capture(ctx, info.OrderID, info.TransactionID, info.Currency, split, info.Shipping.FeeAmount, userFee, false); err != nil {
+		return err
+	}
+	for _, r := range info.RefundList {
+		if err := l.refund(ctx, info.OrderID, info.TransactionID, r.RefundTransactionID, info.Currency, abs(r.RefundAmount)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (l *Ledger) hold(ctx context.Context, orderID string, transactionId linepay.TransactionID, currency string, amount, shippingFee, userFee int) error {
+
+	entry := &Entry{
+		ID:            "authorization:" + transactionId.String(),
+		Kind:          KindAuthorization,
+		OrderID:       orderID,
+		TransactionID: transactionId,
+		Currency:      currency,
+		ShippingFee:   shippingFee,
+		UserFee:       userFee,
+	}
+	entry.debit(l.chart.Holds, amount)
+	entry.credit(l.chart.HoldsPending, amount)
+
+	return l.post(ctx, entry)
+}
+
+// release books the void or expiry of an authorization
+func (l *Ledger) release(ctx context.Context, kind Kind, transactionId linepay.TransactionID) error {
+
+	hold, err := l.store.Get(ctx, "authorization:"+transactionId.String())
+	if err != nil {
+		return fmt.Errorf("ledger: authorization of transaction %s: %s", transactionId, err.Error())
+	}
+
+	entry := &Entry{
+		ID:            "release:" + transactionId.String(),
+		Kind:          kind,
+		OrderID:       hold.OrderID,
+		TransactionID: transactionId,
+		Currency:      hold.Currency,
+	}
+	entry.debit(l.chart.HoldsPending, hold.amount())
+	entry.credit(l.chart.Holds, hold.amount())
+
+	return l.post(ctx, entry)
+}
+
+// capture books the payment split by method, and releases the authorization hold when there is one.
+// `holdFees` takes the fees of the authorization instead of `shippingFee` and `userFee`.
+func (l *Ledger) capture(ctx context.Context, orderID string, transactionId linepay.TransactionID, currency string, split []payInfo, shippingFee, userFee int, holdFees bool) error {
+
+	hold, err := l.store.Get(ctx, "authorization:"+transactionId.String())
+	if err == ErrNotFound {
+		hold = nil
+	} else if err != nil {
+		return err
+	}
+	if hold != nil && holdFees {
+		shippingFee, userFee = hold.ShippingFee, hold.UserFee
+	}
+	if hold != nil && currency == "" {
+		currency = hold.Currency
+	}
+	if currency == "" {
+		return fmt.Errorf("%w: capture of transaction %s", ErrNoCurrency, transactionId)
+	}
+
+	entry := &Entry{
+		ID:            captureID(transactionId),
+		Kind:          KindCapture,
+		OrderID:       orderID,
+		TransactionID: transactionId,
+		Currency:      currency,
+	}
+
+	amount := 0
+	for _, p := range split {
+		amount += p.amount
+		entry.debit(l.chart.method(p.method), p.amount)
+	}
+	entry.credit(l.chart.Sales, amount-shippingFee-userFee)
+	entry.credit(l.chart.ShippingFees, shippingFee)
+	entry.credit(l.chart.UserFees, userFee)
+
+	if hold != nil {
+		entry.debit(l.chart.HoldsPending, hold.amount())
+		entry.credit(l.chart.Holds, hold.amount())
+	}
+
+	return l.post(ctx, entry)
+}
+
+func (l *Ledger) refund(ctx context.Context, orderID string, transactionId, refundTransactionId linepay.TransactionID, currency string, amount int) error {
+
+	entry := &Entry{
+		ID:                    "refund:" + refundTransactionId.String(),
+		Kind:                  KindRefund,
+		OrderID:               orderID,
+		TransactionID:         refundTransactionId,
+		OriginalTransactionID: transactionId,
+		Currency:              currency,
+	}
+	entry.debit(l.chart.Refunds, amount)
+	entry.credit(l.chart.RefundsPayable, amount)
+
+	return l.post(ctx, entry)
+}
+
+// post saves a balanced entry, an entry already posted is not an error
+func (l *Ledger) post(ctx context.Context, entry *Entry) error {
+
+	if !entry.Balanced() {
+		return fmt.Errorf("ledger: entry '%s' is not balanced", entry.ID)
+	}
+	entry.PostedAt = l.now()
+
+	err := l.store.Post(ctx, entry)
+	if err == ErrDuplicate {
+		return nil
+	}
+	return err
+}
+
+func captureID(transactionId linepay.TransactionID) string {
+	return "capture:" + transactionId.String()
+}
+
+// captured returns the amount paid by the user of a capture entry, without the hold it released
+func (l *Ledger) captured(capture *Entry) (amount int) {
+	for _, line := range capture.Lines {
+		if line.Account != l.chart.HoldsPending {
+			amount += line.Debit
+		}
+	}
+	return
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
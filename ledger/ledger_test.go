@@ -0,0 +1,159 @@
+package ledger
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	linepay "github.com/chy168/line-pay-sdk-go"
+)
+
+func TestLedger(t *testing.T) {
+
+	ctx := context.Background()
+	store := NewMemoryStore()
+	l := New(store, nil)
+	chart := DefaultChart()
+
+	// o1: authorized then captured, with shipping and user fees
+	auth := &linepay.PaymentsConfirmResponse{ReturnCode: linepay.ApiReturnCodeSuccess}
+	auth.Info.OrderID = "o1"
+	auth.Info.TransactionID = 1
+	auth.Info.AuthorizationExpireDate = time.Date(2020, 1, 8, 0, 0, 0, 0, time.UTC)
+	auth.Info.Shipping.FeeAmount = 100
+	auth.Info.Packages = []linepay.PaymentsConfirmInfoPackagesResponse{{ID: "p1", Amount: 950, UserFeeAmount: 50}}
+	if err := l.Confirm(ctx, &linepay.PaymentsConfirmRequest{Amount: 1100, Currency: "TWD"}, auth); err != nil {
+		t.Fatalf("Confirm(authorization) error = %v", err)
+	}
+
+	capture := &linepay.PaymentsCaptureResponse{ReturnCode: linepay.ApiReturnCodeSuccess}
+	capture.Info.OrderID = "o1"
+	capture.Info.TransactionID = 1
+	capture.Info.PayInfo = append(capture.Info.PayInfo,
+		struct {
+			Method string `json:"method"`
+			Amount int    `json:"amount"`
+		}{"CREDIT_CARD", 1000},
+		struct {
+			Method string `json:"method"`
+			Amount int    `json:"amount"`
+		}{"DISCOUNT", 100})
+	if err := l.Capture(ctx, &linepay.PaymentsCaptureRequest{Amount: 1100, Currency: "TWD"}, capture); err != nil {
+		t.Fatalf("Capture() error = %v", err)
+	}
+
+	// o2: captured by confirm (fed twice), refunded partially then fully
+	paid := &linepay.PaymentsConfirmResponse{ReturnCode: linepay.ApiReturnCodeSuccess}
+	paid.Info.OrderID = "o2"
+	paid.Info.TransactionID = 2
+	paid.Info.PayInfo = []linepay.PaymentsConfirmInfoPayInfoResponse{{Method: "BALANCE", Amount: 500}}
+	for i := 0; i < 2; i++ {
+		if err := l.Confirm(ctx, &linepay.PaymentsConfirmRequest{Amount: 500, Currency: "TWD"}, paid); err != nil {
+			t.Fatalf("Confirm(capture) error = %v", err)
+		}
+	}
+	refund := &linepay.PaymentsRefundResponse{ReturnCode: linepay.ApiReturnCodeSuccess, Info: linepay.PaymentsRefundInfoResponse{RefundTransactionID: 21}}
+	if err := l.Refund(ctx, 2, &linepay.PaymentsRefundRequest{RefundAmount: 200}, refund); err != nil {
+		t.Fatalf("Refund(200) error = %v", err)
+	}
+	refund = &linepay.PaymentsRefundResponse{ReturnCode: linepay.ApiReturnCodeSuccess, Info: linepay.PaymentsRefundInfoResponse{RefundTransactionID: 22}}
+	if err := l.Refund(ctx, 2, nil, refund); err != nil {
+		t.Fatalf("Refund(rest) error = %v", err)
+	}
+	if err := l.Refund(ctx, 9, nil, refund); err == nil {
+		t.Errorf("Refund() of a transaction not captured no error")
+	}
+
+	// details: a voided authorization, and o2 already booked
+	details := &linepay.PaymentsDetailsResponse{ReturnCode: linepay.ApiReturnCodeSuccess, Info: []linepay.PaymentsDetailsInfoResponse{
+		{TransactionID: 3, OrderID: "o3", PayStatus: "VOIDED_AUTHORIZATION", Currency: "JPY", PayInfo: []linepay.PaymentsDetailsInfoPayInfoResponse{{Method: "CREDIT_CARD", Amount: 300}}},
+		{TransactionID: 2, OrderID: "o2", TransactionType: "PAYMENT", Currency: "TWD", PayInfo: []linepay.PaymentsDetailsInfoPayInfoResponse{{Method: "BALANCE", Amount: 500}},
+			RefundList: []linepay.PaymentsDetailsInfoRefundListResponse{{RefundTransactionID: 21, RefundAmount: -200}}},
+	}}
+	if err := l.Details(ctx, details); err != nil {
+		t.Fatalf("Details() error = %v", err)
+	}
+
+	entries, _ := store.Entries(ctx, "")
+	if len(entries) != 7 {
+		t.Fatalf("%d entries posted: %+v", len(entries), entries)
+	}
+
+	tb, err := l.TrialBalance(ctx)
+	if err != nil || !tb.Balanced() {
+		t.Fatalf("TrialBalance() = %+v, %v", tb, err)
+	}
+	want := map[Balance]int{
+		{Account: chart.Holds, Currency: "TWD"}:                  0,
+		{Account: chart.Holds, Currency: "JPY"}:                  0,
+		{Account: chart.Methods["CREDIT_CARD"], Currency: "TWD"}: 1000,
+		{Account: chart.Methods["DISCOUNT"], Currency: "TWD"}:    100,
+		{Account: chart.Methods["BALANCE"], Currency: "TWD"}:     500,
+		{Account: chart.Sales, Currency: "TWD"}:                  -1450,
+		{Account: chart.ShippingFees, Currency: "TWD"}:           -100,
+		{Account: chart.UserFees, Currency: "TWD"}:               -50,
+		{Account: chart.Refunds, Currency: "TWD"}:                500,
+		{Account: chart.RefundsPayable, Currency: "TWD"}:         -500,
+	}
+	for _, b := range tb.Balances {
+		k := Balance{Account: b.Account, Currency: b.Currency}
+		if net, ok := want[k]; ok && b.Net() != net {
+			t.Errorf("%s %s net = %d, want %d", b.Account, b.Currency, b.Net(), net)
+		}
+		delete(want, k)
+	}
+	if len(want) != 0 {
+		t.Errorf("TrialBalance() missing %v", want)
+	}
+
+	st, err := l.Statement(ctx, "o1")
+	if err != nil || len(st.Entries) != 2 || st.Entries[1].Kind != KindCapture || !st.Entries[1].Balanced() {
+		t.Errorf("Statement(o1) = %+v, %v", st, err)
+	}
+}
+
+// nil requests are booked from the response and the authorization
+func TestLedger_NilRequest(t *testing.T) {
+
+	ctx := context.Background()
+	store := NewMemoryStore()
+	l := New(store, nil)
+
+	auth := &linepay.PaymentsConfirmResponse{ReturnCode: linepay.ApiReturnCodeSuccess}
+	auth.Info.OrderID = "o1"
+	auth.Info.TransactionID = 1
+	auth.Info.AuthorizationExpireDate = time.Date(2020, 1, 8, 0, 0, 0, 0, time.UTC)
+	auth.Info.PayInfo = []linepay.PaymentsConfirmInfoPayInfoResponse{{Method: "CREDIT_CARD", Amount: 300}}
+	if err := l.Confirm(ctx, nil, auth); !errors.Is(err, ErrNoCurrency) {
+		t.Errorf("Confirm(nil) without currency error = %v", err)
+	}
+	auth.Currency = "TWD"
+	if err := l.Confirm(ctx, nil, auth); err != nil {
+		t.Fatalf("Confirm(nil) error = %v", err)
+	}
+	hold, err := store.Get(ctx, "authorization:1")
+	if err != nil || hold.Currency != "TWD" || hold.amount() != 300 {
+		t.Errorf("authorization = %+v, %v", hold, err)
+	}
+
+	capture := &linepay.PaymentsCaptureResponse{ReturnCode: linepay.ApiReturnCodeSuccess}
+	capture.Info.OrderID = "o1"
+	capture.Info.TransactionID = 1
+	capture.Info.PayInfo = append(capture.Info.PayInfo, struct {
+		Method string `json:"method"`
+		Amount int    `json:"amount"`
+	}{"CREDIT_CARD", 300})
+	if err := l.Capture(ctx, nil, capture); err != nil {
+		t.Fatalf("Capture(nil) error = %v", err)
+	}
+	if entry, err := store.Get(ctx, captureID(1)); err != nil || entry.Currency != "TWD" || !entry.Balanced() {
+		t.Errorf("capture = %+v, %v", entry, err)
+	}
+
+	// no authorization to take the currency from
+	capture.Info.TransactionID = 2
+	if err := l.Capture(ctx, nil, capture); !errors.Is(err, ErrNoCurrency) {
+		t.Errorf("Capture(nil) without authorization error = %v", err)
+	}
+}
@@ -0,0 +1,99 @@
+package ledger
+
+import (
+	"context"
+	"sort"
+)
+
+// Balance of an account in a currency
+type Balance struct {
+	Account  Account `json:"account"`
+	Currency string  `json:"currency"`
+	Debit    int     `json:"debit"`
+	Credit   int     `json:"credit"`
+}
+
+// Net is the debit balance, negative for a credit balance
+func (b Balance) Net() int {
+	return b.Debit - b.Credit
+}
+
+// TrialBalance lists the totals of every account, by currency then account
+type TrialBalance struct {
+	Balances []Balance `json:"balances"`
+}
+
+// Balanced tells whether the debits equal the credits in every currency
+func (tb *TrialBalance) Balanced() bool {
+	net := map[string]int{}
+	for _, b := range tb.Balances {
+		net[b.Currency] += b.Net()
+	}
+	for _, n := range net {
+		if n != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Statement of an order, its entries in the order they were posted and the resulting balances
+type Statement struct {
+	OrderID  string    `json:"orderId"`
+	Entries  []*Entry  `json:"entries"`
+	Balances []Balance `json:"balances"`
+}
+
+// TrialBalance totals every entry of the ledger
+func (l *Ledger) TrialBalance(ctx context.Context) (*TrialBalance, error) {
+
+	entries, err := l.store.Entries(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	return &TrialBalance{Balances: balances(entries)}, nil
+}
+
+// Statement returns the entries of the order
+func (l *Ledger) Statement(ctx context.Context, orderID string) (*Statement, error) {
+
+	entries, err := l.store.Entries(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	return &Statement{OrderID: orderID, Entries: entries, Balances: balances(entries)}, nil
+}
+
+func balances(entries []*Entry) []Balance {
+
+	type key struct {
+		account  Account
+		currency string
+	}
+	totals := map[key]*Balance{}
+
+	for _, e := range entries {
+		for _, line := range e.Lines {
+			k := key{account: line.Account, currency: e.Currency}
+			b, ok := totals[k]
+			if !ok {
+				b = &Balance{Account: line.Account, Currency: e.Currency}
+				totals[k] = b
+			}
+			b.Debit += line.Debit
+			b.Credit += line.Credit
+		}
+	}
+
+	list := make([]Balance, 0, len(totals))
+	for _, b := range totals {
+		list = append(list, *b)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Currency != list[j].Currency {
+			return list[i].Currency < list[j].Currency
+		}
+		return list[i].Account < list[j].Account
+	})
+	return list
+}
@@ -0,0 +1,79 @@
+package ledger
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+var (
+	// ErrDuplicate returned by Store when an entry of the same ID was already posted
+	ErrDuplicate = errors.New("ledger: duplicate entry")
+
+	// ErrNotFound returned by Store when the entry does not exist
+	ErrNotFound = errors.New("ledger: not found")
+
+	// ErrNoCurrency returned by Confirm and Capture when neither the request nor the response or the booked
+	// authorization tells the currency
+	ErrNoCurrency = errors.New("ledger: currency unknown")
+)
+
+// Store persists the journal entries. Implementations must be safe for concurrent use,
+// Post must check and save atomically so an entry is never posted twice.
+type Store interface {
+	Post(ctx context.Context, entry *Entry) error
+	Get(ctx context.Context, id string) (*Entry, error)
+	// Entries returns the entries of the order in the order they were posted, every entry when `orderID` is empty
+	Entries(ctx context.Context, orderID string) ([]*Entry, error)
+}
+
+// MemoryStore is an in-memory Store, useful for tests and single instance deployments.
+type MemoryStore struct {
+	mu      sync.RWMutex
+	ids     map[string]int
+	entries []Entry
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{ids: map[string]int{}}
+}
+
+func (m *MemoryStore) Post(ctx context.Context, entry *Entry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.ids[entry.ID]; ok {
+		return ErrDuplicate
+	}
+	e := *entry
+	e.Lines = append([]Line(nil), entry.Lines...)
+	m.ids[entry.ID] = len(m.entries)
+	m.entries = append(m.entries, e)
+	return nil
+}
+
+func (m *MemoryStore) Get(ctx context.Context, id string) (*Entry, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	i, ok := m.ids[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	e := m.entries[i]
+	return &e, nil
+}
+
+func (m *MemoryStore) Entries(ctx context.Context, orderID string) ([]*Entry, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var entries []*Entry
+	for _, e := range m.entries {
+		if orderID == "" || e.OrderID == orderID {
+			e := e
+			entries = append(entries, &e)
+		}
+	}
+	return entries, nil
+}
@@ -0,0 +1,254 @@
+package linepay
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DefaultLimitRecovery is the delay to double a throttled rate back when `LimitPolicy.Recovery` is not set
+const DefaultLimitRecovery = 10 * time.Second
+
+// a throttled rate is halved down to 1/16 of the configured rate
+const minRateFactor = 1.0 / 16
+
+// Limit of the calls of an operation, the zero value does not limit.
+// `Rate` calls per second, retries included, `Burst` calls sent at once after an idle time (1 by default)
+// `MaxInFlight` calls in progress at once
+type Limit struct {
+	Rate        float64
+	Burst       int
+	MaxInFlight int
+}
+
+// LimitPolicy of the Client, the zero value does not limit.
+// `Default` applies to the operations absent of `Operations`, which is keyed by operation (e.g. OperationPaymentsDetails).
+// When LINE Pay throttles an operation (HTTP 429), its rate is halved, then doubled back every `Recovery` without
+// throttling (DefaultLimitRecovery by default).
+// A call waiting for its limit fails with the error of its context, at once when its deadline is too close.
+type LimitPolicy struct {
+	Default    Limit
+	Operations map[string]Limit
+	Recovery   time.Duration
+}
+
+// LimitObserver is implemented by an Observer following the limits of the Client, for metrics (see package `prompay`).
+// LimitWaited is called when a call had to wait for its limit, `err` is set when its context ended first.
+// LimitRate is called when the rate of an operation changes, `throttled` tells it was reduced because of LINE Pay.
+type LimitObserver interface {
+	LimitWaited(operation string, wait time.Duration, err error)
+	LimitRate(operation string, rate float64, throttled bool)
+}
+
+// limits holds the limiter of every operation, created at the first call
+type limits struct {
+	policy    LimitPolicy
+	observers []LimitObserver
+
+	mu         sync.Mutex
+	operations map[string]*limiter
+}
+
+func newLimits(policy LimitPolicy, observers []Observer) *limits {
+	if policy.Recovery <= 0 {
+		policy.Recovery = DefaultLimitRecovery
+	}
+	l := &limits{policy: policy, operations: map[string]*limiter{}}
+	for _, o := range observers {
+		if lo, ok := o.(LimitObserver); ok {
+			l.observers = append(l.observers, lo)
+		}
+	}
+	return l
+}
+
+// of returns the limiter of `operation`, nil when it is not limited
+func (l *limits) of(operation string) *limiter {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if lim, ok := l.operations[operation]; ok {
+		return lim
+	}
+
+	limit, ok := l.policy.Operations[operation]
+	if !ok {
+		limit = l.policy.Default
+	}
+
+	var lim *limiter
+	if limit.Rate > 0 || limit.MaxInFlight > 0 {
+		lim = &limiter{operation: operation, recovery: l.policy.Recovery, observers: l.observers}
+		if limit.Rate > 0 {
+			burst := float64(limit.Burst)
+			if burst < 1 {
+				burst = 1
+			}
+			lim.bucket = &tokenBucket{rate: limit.Rate, burst: burst, tokens: burst, factor: 1}
+		}
+		if limit.MaxInFlight > 0 {
+			lim.slots = make(chan struct{}, limit.MaxInFlight)
+		}
+	}
+	l.operations[operation] = lim
+	return lim
+}
+
+// limiter of an operation, a nil limiter does not limit
+type limiter struct {
+	operation string
+	recovery  time.Duration
+	observers []LimitObserver
+	bucket    *tokenBucket
+	slots     chan struct{}
+}
+
+// acquire takes an in-flight slot, `release` gives it back
+func (lim *limiter) acquire(ctx context.Context) (release func(), err error) {
+
+	if lim == nil || lim.slots == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case lim.slots <- struct{}{}:
+		return func() { <-lim.slots }, nil
+	default:
+	}
+
+	start := time.Now()
+	select {
+	case lim.slots <- struct{}{}:
+		lim.waited(time.Since(start), nil)
+		return func() { <-lim.slots }, nil
+	case <-ctx.Done():
+		lim.waited(time.Since(start), ctx.Err())
+		return nil, ctx.Err()
+	}
+}
+
+// wait takes a token of the rate, before every attempt
+func (lim *limiter) wait(ctx context.Context) error {
+
+	if lim == nil || lim.bucket == nil {
+		return nil
+	}
+
+	now := time.Now()
+	delay, rate, recovered := lim.bucket.reserve(now, lim.recovery)
+	if recovered {
+		lim.rateChanged(rate, false)
+	}
+	if delay <= 0 {
+		return nil
+	}
+
+	if deadline, ok := ctx.Deadline(); ok && deadline.Sub(now) < delay {
+		lim.bucket.cancel()
+		lim.waited(0, context.DeadlineExceeded)
+		return context.DeadlineExceeded
+	}
+
+	t := time.NewTimer(delay)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		lim.waited(delay, nil)
+		return nil
+	case <-ctx.Done():
+		lim.bucket.cancel()
+		lim.waited(time.Since(now), ctx.Err())
+		return ctx.Err()
+	}
+}
+
+// response adapts the rate to the response of an attempt
+func (lim *limiter) response(res *http.Response) {
+	if lim == nil || lim.bucket == nil || res == nil || res.StatusCode != http.StatusTooManyRequests {
+		return
+	}
+	if rate, changed := lim.bucket.throttle(time.Now()); changed {
+		lim.rateChanged(rate, true)
+	}
+}
+
+func (lim *limiter) waited(wait time.Duration, err error) {
+	for _, o := range lim.observers {
+		o.LimitWaited(lim.operation, wait, err)
+	}
+}
+
+func (lim *limiter) rateChanged(rate float64, throttled bool) {
+	for _, o := range lim.observers {
+		o.LimitRate(lim.operation, rate, throttled)
+	}
+}
+
+// tokenBucket of a rate reduced by `factor` while LINE Pay throttles
+type tokenBucket struct {
+	mu      sync.Mutex
+	rate    float64
+	burst   float64
+	tokens  float64
+	factor  float64
+	last    time.Time
+	changed time.Time
+}
+
+// reserve takes a token and returns how long to wait for it. `recovered` tells the rate was raised to `rate`.
+func (b *tokenBucket) reserve(now time.Time, recovery time.Duration) (delay time.Duration, rate float64, recovered bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.factor < 1 && now.Sub(b.changed) >= recovery {
+		b.factor *= 2
+		if b.factor > 1 {
+			b.factor = 1
+		}
+		b.changed = now
+		recovered = true
+	}
+
+	rate = b.rate * b.factor
+	if !b.last.IsZero() {
+		b.tokens += now.Sub(b.last).Seconds() * rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+	}
+	b.last = now
+
+	b.tokens--
+	if b.tokens < 0 {
+		delay = time.Duration(-b.tokens / rate * float64(time.Second))
+	}
+	return
+}
+
+// cancel gives back the token of a call which did not wait for it
+func (b *tokenBucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens++
+}
+
+// throttle halves the rate, once a second at most as the attempts sent before were throttled too
+func (b *tokenBucket) throttle(now time.Time) (rate float64, changed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.factor <= minRateFactor || now.Sub(b.changed) < time.Second {
+		return b.rate * b.factor, false
+	}
+	b.factor /= 2
+	if b.factor < minRateFactor {
+		b.factor = minRateFactor
+	}
+	b.changed = now
+	return b.rate * b.factor, true
+}
@@ -0,0 +1,109 @@
+package linepay
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClient_Limits(t *testing.T) {
+
+	var inFlight, maxInFlight, calls int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		fmt.Fprint(w, `{"returnCode":"0000","returnMessage":"Success."}`)
+	}))
+	defer ts.Close()
+
+	client, _ := NewClient("1001", "secret", nil, &ClientOpts{
+		APIEndpoint: ts.URL,
+		Limits: LimitPolicy{Operations: map[string]Limit{
+			OperationPaymentsDetails: {MaxInFlight: 2},
+			OperationPaymentsStatus:  {Rate: 20, Burst: 2},
+		}},
+	})
+	ctx := context.Background()
+
+	// at most 2 details at once
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.PaymentsDetails(ctx, &PaymentsDetailsRequest{OrderIDs: []string{"o1"}}); err != nil {
+				t.Errorf("PaymentsDetails() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if maxInFlight != 2 {
+		t.Errorf("max details in flight = %d, want 2", maxInFlight)
+	}
+
+	// 2 status calls at once, then one every 50ms
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		if _, err := client.PaymentsStatus(ctx, 1); err != nil {
+			t.Fatalf("PaymentsStatus() error = %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 80*time.Millisecond {
+		t.Errorf("4 status calls in %v, want 2 of them delayed by the rate", elapsed)
+	}
+
+	// a deadline closer than the next token fails at once, without calling LINE Pay
+	client, _ = NewClient("1001", "secret", nil, &ClientOpts{APIEndpoint: ts.URL, Limits: LimitPolicy{Default: Limit{Rate: 0.1}}})
+	if _, err := client.PaymentsVoid(ctx, 1); err != nil {
+		t.Fatalf("PaymentsVoid() error = %v", err)
+	}
+	before := atomic.LoadInt32(&calls)
+	tctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	start = time.Now()
+	if _, err := client.PaymentsVoid(tctx, 1); err == nil || time.Since(start) > 500*time.Millisecond || atomic.LoadInt32(&calls) != before {
+		t.Errorf("limited PaymentsVoid() error = %v after %v", err, time.Since(start))
+	}
+}
+
+func TestTokenBucket_Throttle(t *testing.T) {
+
+	now := time.Now()
+	b := &tokenBucket{rate: 16, burst: 1, tokens: 1, factor: 1}
+
+	if rate, changed := b.throttle(now); !changed || rate != 8 {
+		t.Errorf("throttle() = %v, %v, want 8", rate, changed)
+	}
+	// the attempts sent before the reduction do not reduce it again
+	if rate, changed := b.throttle(now.Add(100 * time.Millisecond)); changed || rate != 8 {
+		t.Errorf("throttle() again = %v, %v, want unchanged 8", rate, changed)
+	}
+	for i := 2; i < 10; i++ {
+		b.throttle(now.Add(time.Duration(i) * time.Second))
+	}
+	if b.rate*b.factor != 1 {
+		t.Errorf("rate after throttling = %v, want the minimum 1", b.rate*b.factor)
+	}
+
+	// doubled back every recovery
+	last := now.Add(9 * time.Second)
+	for _, want := range []float64{2, 4, 8, 16, 16} {
+		last = last.Add(10 * time.Second)
+		if _, rate, _ := b.reserve(last, 10*time.Second); rate != want {
+			t.Errorf("rate after recovery = %v, want %v", rate, want)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package linepaymock
+
+import (
+	"context"
+
+	linepay "github.com/chy168/line-pay-sdk-go"
+)
+
+// The Sealed methods are recorded under the operation of their plain method, with the linepay.Sealed regKey as argument.
+// PaymentsRequestV2 is recorded as OperationPaymentsRequest with the *linepay.PaymentsRequestV2 argument.
+
+func (m *MockClient) PaymentsRequest(ctx context.Context, request *linepay.PaymentsRequest) (*linepay.PaymentsResponse, error) {
+	response, err := m.call(ctx, linepay.OperationPaymentsRequest, request)
+	res, _ := response.(*linepay.PaymentsResponse)
+	return res, err
+}
+
+func (m *MockClient) PaymentsRequestV2(ctx context.Context, request *linepay.PaymentsRequestV2) (*linepay.PaymentsResponse, error) {
+	response, err := m.call(ctx, linepay.OperationPaymentsRequest, request)
+	res, _ := response.(*linepay.PaymentsResponse)
+	return res, err
+}
+
+func (m *MockClient) PaymentsConfirm(ctx context.Context, transactionId linepay.TransactionID, request *linepay.PaymentsConfirmRequest) (*linepay.PaymentsConfirmResponse, error) {
+	response, err := m.call(ctx, linepay.OperationPaymentsConfirm, transactionId, request)
+	res, _ := response.(*linepay.PaymentsConfirmResponse)
+	return res, err
+}
+
+func (m *MockClient) PaymentsCapture(ctx context.Context, transactionId linepay.TransactionID, request *linepay.PaymentsCaptureRequest) (*linepay.PaymentsCaptureResponse, error) {
+	response, err := m.call(ctx, linepay.OperationPaymentsCapture, transactionId, request)
+	res, _ := response.(*linepay.PaymentsCaptureResponse)
+	return res, err
+}
+
+func (m *MockClient) PaymentsVoid(ctx context.Context, transactionId linepay.TransactionID) (*linepay.PaymentsVoidResponse, error) {
+	response, err := m.call(ctx, linepay.OperationPaymentsVoid, transactionId)
+	res, _ := response.(*linepay.PaymentsVoidResponse)
+	return res, err
+}
+
+func (m *MockClient) PaymentsRefund(ctx context.Context, transactionId linepay.TransactionID, request *linepay.PaymentsRefundRequest) (*linepay.PaymentsRefundResponse, error) {
+	response, err := m.call(ctx, linepay.OperationPaymentsRefund, transactionId, request)
+	res, _ := response.(*linepay.PaymentsRefundResponse)
+	return res, err
+}
+
+func (m *MockClient) PaymentsDetails(ctx context.Context, request *linepay.PaymentsDetailsRequest) (*linepay.PaymentsDetailsResponse, error) {
+	response, err := m.call(ctx, linepay.OperationPaymentsDetails, request)
+	res, _ := response.(*linepay.PaymentsDetailsResponse)
+	return res, err
+}
+
+func (m *MockClient) PaymentsStatus(ctx context.Context, transactionId linepay.TransactionID) (*linepay.PaymentsStatusResponse, error) {
+	response, err := m.call(ctx, linepay.OperationPaymentsStatus, transactionId)
+	res, _ := response.(*linepay.PaymentsStatusResponse)
+	return res, err
+}
+
+func (m *MockClient) PaymentsPreapproved(ctx context.Context, regKey string, request *linepay.PaymentsPreapprovedRequest) (*linepay.PaymentsPreapprovedResponse, error) {
+	response, err := m.call(ctx, linepay.OperationPaymentsPreapproved, regKey, request)
+	res, _ := response.(*linepay.PaymentsPreapprovedResponse)
+	return res, err
+}
+
+func (m *MockClient) PaymentsCheckRegKey(ctx context.Context, regKey string, request *linepay.PaymentsCheckRegKeyRequest) (*linepay.PaymentsCheckRegKeyResponse, error) {
+	response, err := m.call(ctx, linepay.OperationPaymentsCheckRegKey, regKey, request)
+	res, _ := response.(*linepay.PaymentsCheckRegKeyResponse)
+	return res, err
+}
+
+func (m *MockClient) PaymentsExpireRegKey(ctx context.Context, regKey string) (*linepay.PaymentsExpireRegKeyResponse, error) {
+	response, err := m.call(ctx, linepay.OperationPaymentsExpireRegKey, regKey)
+	res, _ := response.(*linepay.PaymentsExpireRegKeyResponse)
+	return res, err
+}
+
+func (m *MockClient) PaymentsPreapprovedSealed(ctx context.Context, regKey linepay.Sealed, request *linepay.PaymentsPreapprovedRequest) (*linepay.PaymentsPreapprovedResponse, error) {
+	response, err := m.call(ctx, linepay.OperationPaymentsPreapproved, regKey, request)
+	res, _ := response.(*linepay.PaymentsPreapprovedResponse)
+	return res, err
+}
+
+func (m *MockClient) PaymentsCheckRegKeySealed(ctx context.Context, regKey linepay.Sealed, request *linepay.PaymentsCheckRegKeyRequest) (*linepay.PaymentsCheckRegKeyResponse, error) {
+	response, err := m.call(ctx, linepay.OperationPaymentsCheckRegKey, regKey, request)
+	res, _ := response.(*linepay.PaymentsCheckRegKeyResponse)
+	return res, err
+}
+
+func (m *MockClient) PaymentsExpireRegKeySealed(ctx context.Context, regKey linepay.Sealed) (*linepay.PaymentsExpireRegKeyResponse, error) {
+	response, err := m.call(ctx, linepay.OperationPaymentsExpireRegKey, regKey)
+	res, _ := response.(*linepay.PaymentsExpireRegKeyResponse)
+	return res, err
+}
@@ -0,0 +1,243 @@
+// Package linepaymock provides MockClient, a programmable `linepay.PaymentsAPI` for unit tests.
+//
+//	mock := linepaymock.New()
+//	mock.On(linepay.OperationPaymentsConfirm, linepay.TransactionID(2019049910005496810), linepaymock.Any).
+//		Return(&linepay.PaymentsConfirmResponse{ReturnCode: linepay.ApiReturnCodeSuccess}, nil).Once()
+//	mock.On(linepay.OperationPaymentsRefund, linepaymock.Any, linepaymock.Any).ReturnCode("1165", "Transaction already refunded.")
+//	mock.On(linepay.OperationPaymentsVoid, linepaymock.Any).Return(nil, context.DeadlineExceeded)
+//
+//	service := NewCheckoutService(mock) // depends on linepay.PaymentsAPI
+//	...
+//	mock.AssertExpectations(t)
+//
+// Expectations are matched on the operation and the arguments after the context, in the order they were added.
+// A call without a matching expectation returns an *UnexpectedCallError and fails AssertExpectations.
+package linepaymock
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	linepay "github.com/chy168/line-pay-sdk-go"
+)
+
+// Any matches any argument
+var Any = anyArg{}
+
+type anyArg struct{}
+
+func (anyArg) String() string { return "Any" }
+
+// response type of every operation, the Sealed methods share the operation of their plain method
+var responseTypes = map[string]reflect.Type{
+	linepay.OperationPaymentsRequest:      reflect.TypeOf(&linepay.PaymentsResponse{}),
+	linepay.OperationPaymentsConfirm:      reflect.TypeOf(&linepay.PaymentsConfirmResponse{}),
+	linepay.OperationPaymentsCapture:      reflect.TypeOf(&linepay.PaymentsCaptureResponse{}),
+	linepay.OperationPaymentsVoid:         reflect.TypeOf(&linepay.PaymentsVoidResponse{}),
+	linepay.OperationPaymentsRefund:       reflect.TypeOf(&linepay.PaymentsRefundResponse{}),
+	linepay.OperationPaymentsDetails:      reflect.TypeOf(&linepay.PaymentsDetailsResponse{}),
+	linepay.OperationPaymentsStatus:       reflect.TypeOf(&linepay.PaymentsStatusResponse{}),
+	linepay.OperationPaymentsPreapproved:  reflect.TypeOf(&linepay.PaymentsPreapprovedResponse{}),
+	linepay.OperationPaymentsCheckRegKey:  reflect.TypeOf(&linepay.PaymentsCheckRegKeyResponse{}),
+	linepay.OperationPaymentsExpireRegKey: reflect.TypeOf(&linepay.PaymentsExpireRegKeyResponse{}),
+}
+
+// Call is a recorded call, `Args` are the arguments after the context
+type Call struct {
+	Operation string
+	Args      []interface{}
+}
+
+// UnexpectedCallError is returned for a call matching no expectation
+type UnexpectedCallError struct {
+	Call Call
+}
+
+func (e *UnexpectedCallError) Error() string {
+	return fmt.Sprintf("linepaymock: unexpected call %s(%s)", e.Call.Operation, formatArgs(e.Call.Args))
+}
+
+// TestingT is the part of `*testing.T` used by AssertExpectations
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+// Expectation of a call, returned by `MockClient.On`
+type Expectation struct {
+	operation string
+	args      []interface{}
+	response  interface{}
+	err       error
+	do        func(ctx context.Context, args []interface{}) (interface{}, error)
+	times     int
+	calls     int
+}
+
+// Return sets the canned response and error, `response` must be of the operation's type (e.g.
+// *linepay.PaymentsConfirmResponse for OperationPaymentsConfirm) or nil
+func (e *Expectation) Return(response interface{}, err error) *Expectation {
+	if response != nil && reflect.TypeOf(response) != responseTypes[e.operation] {
+		panic(fmt.Sprintf("linepaymock: %s returns %s, not %T", e.operation, responseTypes[e.operation], response))
+	}
+	e.response, e.err = response, err
+	return e
+}
+
+// ReturnCode answers a response of the operation's type with `returnCode` and `returnMessage`, like LINE Pay
+// rejecting the call
+func (e *Expectation) ReturnCode(returnCode, returnMessage string) *Expectation {
+	response := reflect.New(responseTypes[e.operation].Elem())
+	response.Elem().FieldByName("ReturnCode").SetString(returnCode)
+	response.Elem().FieldByName("ReturnMessage").SetString(returnMessage)
+	return e.Return(response.Interface(), nil)
+}
+
+// Do computes the response of every matching call, it replaces Return
+func (e *Expectation) Do(do func(ctx context.Context, args []interface{}) (response interface{}, err error)) *Expectation {
+	e.do = do
+	return e
+}
+
+// Times limits the expectation to `n` calls, and makes AssertExpectations require them. Unlimited by default.
+func (e *Expectation) Times(n int) *Expectation {
+	e.times = n
+	return e
+}
+
+func (e *Expectation) Once() *Expectation {
+	return e.Times(1)
+}
+
+func (e *Expectation) matches(call Call) bool {
+	if e.operation != call.Operation || len(e.args) != len(call.Args) {
+		return false
+	}
+	if e.times > 0 && e.calls >= e.times {
+		return false
+	}
+	for i, arg := range e.args {
+		if arg != Any && !reflect.DeepEqual(arg, call.Args[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// MockClient implements `linepay.PaymentsAPI`, safe for concurrent use
+type MockClient struct {
+	mu           sync.Mutex
+	expectations []*Expectation
+	calls        []Call
+	unexpected   []Call
+}
+
+var _ linepay.PaymentsAPI = (*MockClient)(nil)
+
+func New() *MockClient {
+	return &MockClient{}
+}
+
+// On expects a call of `operation` (linepay.OperationPaymentsConfirm...) with `args`, the arguments after the context.
+// Use Any to match any argument.
+func (m *MockClient) On(operation string, args ...interface{}) *Expectation {
+	if _, ok := responseTypes[operation]; !ok {
+		panic("linepaymock: unknown operation " + operation)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e := &Expectation{operation: operation, args: args}
+	m.expectations = append(m.expectations, e)
+	return e
+}
+
+// Calls returns the recorded calls, in order
+func (m *MockClient) Calls() []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Call(nil), m.calls...)
+}
+
+// CallsTo returns the recorded calls of `operation`
+func (m *MockClient) CallsTo(operation string) (calls []Call) {
+	for _, c := range m.Calls() {
+		if c.Operation == operation {
+			calls = append(calls, c)
+		}
+	}
+	return
+}
+
+// AssertExpectations fails `t` on unexpected calls and on expectations never called, or called less than their Times
+func (m *MockClient) AssertExpectations(t TestingT) bool {
+	t.Helper()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ok := true
+	for _, c := range m.unexpected {
+		t.Errorf("linepaymock: unexpected call %s(%s)", c.Operation, formatArgs(c.Args))
+		ok = false
+	}
+	for _, e := range m.expectations {
+		if e.calls == 0 || e.calls < e.times {
+			t.Errorf("linepaymock: expected call %s(%s), called %d times", e.operation, formatArgs(e.args), e.calls)
+			ok = false
+		}
+	}
+	return ok
+}
+
+// Reset removes the expectations and the recorded calls
+func (m *MockClient) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.expectations, m.calls, m.unexpected = nil, nil, nil
+}
+
+// call records the call and returns the response of the first matching expectation
+func (m *MockClient) call(ctx context.Context, operation string, args ...interface{}) (interface{}, error) {
+
+	call := Call{Operation: operation, Args: args}
+
+	m.mu.Lock()
+	m.calls = append(m.calls, call)
+
+	var e *Expectation
+	for _, candidate := range m.expectations {
+		if candidate.matches(call) {
+			e = candidate
+			break
+		}
+	}
+	if e == nil {
+		m.unexpected = append(m.unexpected, call)
+		m.mu.Unlock()
+		return nil, &UnexpectedCallError{Call: call}
+	}
+	e.calls++
+	response, err, do := e.response, e.err, e.do
+	m.mu.Unlock()
+
+	if do != nil {
+		response, err = do(ctx, args)
+		if response != nil && reflect.TypeOf(response) != responseTypes[operation] {
+			panic(fmt.Sprintf("linepaymock: %s returns %s, not %T", operation, responseTypes[operation], response))
+		}
+	}
+	return response, err
+}
+
+func formatArgs(args []interface{}) string {
+	s := make([]string, len(args))
+	for i, arg := range args {
+		s[i] = fmt.Sprintf("%v", arg)
+	}
+	return strings.Join(s, ", ")
+}
@@ -0,0 +1,79 @@
+package linepaymock
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	linepay "github.com/chy168/line-pay-sdk-go"
+)
+
+type recordingT struct {
+	errors []string
+}
+
+func (t *recordingT) Helper() {}
+
+func (t *recordingT) Errorf(format string, args ...interface{}) {
+	t.errors = append(t.errors, format)
+}
+
+func TestMockClient(t *testing.T) {
+
+	ctx := context.Background()
+	errNetwork := errors.New("connection reset")
+
+	mock := New()
+	mock.On(linepay.OperationPaymentsConfirm, linepay.TransactionID(1), Any).
+		Return(&linepay.PaymentsConfirmResponse{ReturnCode: linepay.ApiReturnCodeSuccess}, nil).Once()
+	mock.On(linepay.OperationPaymentsConfirm, linepay.TransactionID(1), Any).ReturnCode("1172", "Existing same orderId.")
+	mock.On(linepay.OperationPaymentsVoid, Any).Return(nil, errNetwork)
+	mock.On(linepay.OperationPaymentsRefund, Any, &linepay.PaymentsRefundRequest{RefundAmount: 50}).
+		Do(func(ctx context.Context, args []interface{}) (interface{}, error) {
+			return &linepay.PaymentsRefundResponse{ReturnCode: linepay.ApiReturnCodeSuccess, Info: linepay.PaymentsRefundInfoResponse{RefundTransactionID: args[0].(linepay.TransactionID) + 1}}, nil
+		})
+
+	var api linepay.PaymentsAPI = mock
+
+	confirm := &linepay.PaymentsConfirmRequest{Amount: 100, Currency: "TWD"}
+	if res, err := api.PaymentsConfirm(ctx, 1, confirm); err != nil || res.ReturnCode != linepay.ApiReturnCodeSuccess {
+		t.Errorf("first PaymentsConfirm() = %+v, %v", res, err)
+	}
+	if res, err := api.PaymentsConfirm(ctx, 1, confirm); err != nil || res.ReturnCode != "1172" || res.ReturnMessage == "" {
+		t.Errorf("second PaymentsConfirm() = %+v, %v", res, err)
+	}
+	if _, err := api.PaymentsVoid(ctx, 7); err != errNetwork {
+		t.Errorf("PaymentsVoid() error = %v", err)
+	}
+	if res, err := api.PaymentsRefund(ctx, 7, &linepay.PaymentsRefundRequest{RefundAmount: 50}); err != nil || res.Info.RefundTransactionID != 8 {
+		t.Errorf("PaymentsRefund() = %+v, %v", res, err)
+	}
+
+	if calls := mock.CallsTo(linepay.OperationPaymentsConfirm); len(calls) != 2 || calls[1].Args[1] != confirm {
+		t.Errorf("CallsTo(PaymentsConfirm) = %+v", calls)
+	}
+	if !mock.AssertExpectations(t) {
+		return
+	}
+
+	// unexpected call and unmet expectation
+	mock.On(linepay.OperationPaymentsStatus, linepay.TransactionID(7)).Once()
+	_, err := api.PaymentsStatus(ctx, 8)
+	if e, ok := err.(*UnexpectedCallError); !ok || e.Call.Operation != linepay.OperationPaymentsStatus {
+		t.Errorf("PaymentsStatus(8) error = %v", err)
+	}
+
+	rt := &recordingT{}
+	if mock.AssertExpectations(rt) || len(rt.errors) != 2 {
+		t.Errorf("AssertExpectations() reported %v", rt.errors)
+	}
+}
+
+func TestMockClient_ReturnWrongType(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Return() of a wrong type did not panic")
+		}
+	}()
+	New().On(linepay.OperationPaymentsVoid, Any).Return(&linepay.PaymentsRefundResponse{}, nil)
+}
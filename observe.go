@@ -0,0 +1,117 @@
+package linepay
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// Operation names of the API calls, given to Observer in `CallInfo.Operation`
+const (
+	OperationPaymentsRequest      string = "PaymentsRequest"
+	OperationPaymentsConfirm      string = "PaymentsConfirm"
+	OperationPaymentsCapture      string = "PaymentsCapture"
+	OperationPaymentsVoid         string = "PaymentsVoid"
+	OperationPaymentsRefund       string = "PaymentsRefund"
+	OperationPaymentsDetails      string = "PaymentsDetails"
+	OperationPaymentsStatus       string = "PaymentsStatus"
+	OperationPaymentsPreapproved  string = "PaymentsPreapproved"
+	OperationPaymentsCheckRegKey  string = "PaymentsCheckRegKey"
+	OperationPaymentsExpireRegKey string = "PaymentsExpireRegKey"
+	OperationVerifyCredentials    string = "VerifyCredentials"
+)
+
+// CallInfo describes an API call to an Observer.
+// `Route` is the endpoint template like `/v3/payments/{transactionId}/confirm`, ids and regKeys are never included.
+// `Currency` and `Amount` are set for the calls moving money.
+type CallInfo struct {
+	Operation string
+	Method    string
+	Route     string
+	Currency  string
+	Amount    int
+
+	retry RetryClass
+}
+
+// CallResult is the outcome of an API call.
+// `StatusCode` and `ReturnCode` are empty when `Err` is a network error.
+type CallResult struct {
+	StatusCode int
+	ReturnCode string
+	Retries    int
+	Duration   time.Duration
+	Err        error
+}
+
+// Observer is notified of every API call of the Client, for tracing or metrics (see package `otelpay`).
+// StartCall is called before the first attempt, the returned context is used by the HTTP requests
+// and `end` is called once with the final result.
+type Observer interface {
+	StartCall(ctx context.Context, info *CallInfo) (context.Context, func(result *CallResult))
+}
+
+// HeaderInjector is implemented by an Observer propagating its context (e.g. trace context) in the HTTP headers of every attempt
+type HeaderInjector interface {
+	InjectHeader(ctx context.Context, header http.Header)
+}
+
+type callResultKey struct{}
+
+// observe runs `send` between the StartCall and end of every observer
+func (client *Client) observe(ctx context.Context, call *CallInfo, send func(ctx context.Context) (*http.Response, error)) (res *http.Response, err error) {
+
+	if len(client.observers) == 0 || call == nil {
+		return send(ctx)
+	}
+
+	start := time.Now()
+	result := &CallResult{}
+	ctx = context.WithValue(ctx, callResultKey{}, result)
+
+	ends := make([]func(*CallResult), len(client.observers))
+	for i, o := range client.observers {
+		ctx, ends[i] = o.StartCall(ctx, call)
+	}
+
+	res, err = send(ctx)
+	if err == nil {
+		var bodyBytes []byte
+		bodyBytes, err = ioutil.ReadAll(res.Body)
+		res.Body.Close()
+		res.Body = ioutil.NopCloser(bytes.NewReader(bodyBytes))
+
+		result.StatusCode = res.StatusCode
+		result.ReturnCode = returnCodeOf(bodyBytes)
+	}
+	result.Err = err
+	result.Duration = time.Since(start)
+
+	for i := len(ends) - 1; i >= 0; i-- {
+		ends[i](result)
+	}
+	return
+}
+
+// observeAttempt injects the observers headers, and counts the retries of the call
+func (client *Client) observeAttempt(ctx context.Context, req *http.Request, retry int) {
+	if result, ok := ctx.Value(callResultKey{}).(*CallResult); ok {
+		result.Retries = retry
+	}
+	for _, o := range client.observers {
+		if hi, ok := o.(HeaderInjector); ok {
+			hi.InjectHeader(ctx, req.Header)
+		}
+	}
+}
+
+func returnCodeOf(body []byte) string {
+	res := struct {
+		ReturnCode string `json:"returnCode"`
+	}{}
+	json.Unmarshal(body, &res)
+	return res.ReturnCode
+}
@@ -0,0 +1,198 @@
+// Package otelpay instruments `linepay.Client` with OpenTelemetry traces and metrics.
+//
+//	observer, err := otelpay.New()
+//	client, err := linepay.NewClient(channelID, channelSecret, nil, &linepay.ClientOpts{Observers: []linepay.Observer{observer}})
+//
+// Every API call produces a client span named after the operation (PaymentsRequest, PaymentsConfirm...), a latency
+// histogram and a call counter. The trace context is propagated in the HTTP headers. Signatures, nonces, transaction
+// ids and regKeys are never recorded, only the endpoint template. A failed call records the class of its error
+// (timeout, canceled, network), not its message which holds the request URL.
+package otelpay
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+
+	linepay "github.com/chy168/line-pay-sdk-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/chy168/line-pay-sdk-go/otelpay"
+
+// attribute keys
+const (
+	AttrOperation    = attribute.Key("linepay.operation")
+	AttrReturnCode   = attribute.Key("linepay.return_code")
+	AttrCurrency     = attribute.Key("linepay.currency")
+	AttrAmountBucket = attribute.Key("linepay.amount_bucket")
+	AttrRetries      = attribute.Key("linepay.retries")
+	AttrMethod       = attribute.Key("http.request.method")
+	AttrRoute        = attribute.Key("http.route")
+	AttrStatusCode   = attribute.Key("http.response.status_code")
+	AttrErrorType    = attribute.Key("error.type")
+)
+
+// Option of New
+type Option func(*config)
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+	propagator     propagation.TextMapPropagator
+}
+
+// WithTracerProvider uses `tp` instead of the global tracer provider
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *config) { c.tracerProvider = tp }
+}
+
+// WithMeterProvider uses `mp` instead of the global meter provider
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(c *config) { c.meterProvider = mp }
+}
+
+// WithPropagator uses `p` instead of the global text map propagator
+func WithPropagator(p propagation.TextMapPropagator) Option {
+	return func(c *config) { c.propagator = p }
+}
+
+// Observer implements `linepay.Observer` and `linepay.HeaderInjector`
+type Observer struct {
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+	duration   metric.Float64Histogram
+	calls      metric.Int64Counter
+}
+
+var (
+	_ linepay.Observer       = (*Observer)(nil)
+	_ linepay.HeaderInjector = (*Observer)(nil)
+)
+
+func New(opts ...Option) (*Observer, error) {
+
+	c := &config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+		propagator:     otel.GetTextMapPropagator(),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	meter := c.meterProvider.Meter(instrumentationName)
+
+	duration, err := meter.Float64Histogram("linepay.client.duration",
+		metric.WithDescription("Duration of LINE Pay API calls, retries included"),
+		metric.WithUnit("s"))
+	if err != nil {
+		return nil, err
+	}
+
+	calls, err := meter.Int64Counter("linepay.client.calls",
+		metric.WithDescription("LINE Pay API calls by operation and returnCode"),
+		metric.WithUnit("{call}"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Observer{
+		tracer:     c.tracerProvider.Tracer(instrumentationName),
+		propagator: c.propagator,
+		duration:   duration,
+		calls:      calls,
+	}, nil
+}
+
+func (o *Observer) StartCall(ctx context.Context, info *linepay.CallInfo) (context.Context, func(*linepay.CallResult)) {
+
+	attrs := []attribute.KeyValue{
+		AttrOperation.String(info.Operation),
+		AttrMethod.String(info.Method),
+		AttrRoute.String(info.Route),
+	}
+	if info.Currency != "" {
+		attrs = append(attrs, AttrCurrency.String(info.Currency))
+	}
+	if info.Amount > 0 {
+		attrs = append(attrs, AttrAmountBucket.String(AmountBucket(info.Amount)))
+	}
+
+	ctx, span := o.tracer.Start(ctx, info.Operation, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+
+	return ctx, func(result *linepay.CallResult) {
+
+		metricAttrs := []attribute.KeyValue{
+			AttrOperation.String(info.Operation),
+			AttrReturnCode.String(result.ReturnCode),
+			AttrStatusCode.Int(result.StatusCode),
+		}
+
+		span.SetAttributes(AttrReturnCode.String(result.ReturnCode), AttrRetries.Int(result.Retries))
+		if result.StatusCode != 0 {
+			span.SetAttributes(AttrStatusCode.Int(result.StatusCode))
+		}
+
+		switch {
+		case result.Err != nil:
+			class := ErrorClass(result.Err)
+			span.SetAttributes(AttrErrorType.String(class))
+			span.SetStatus(codes.Error, class+" "+info.Route)
+		case result.StatusCode != http.StatusOK:
+			span.SetStatus(codes.Error, "HTTP "+strconv.Itoa(result.StatusCode))
+		case result.ReturnCode != linepay.ApiReturnCodeSuccess:
+			span.SetStatus(codes.Error, "returnCode "+result.ReturnCode)
+		}
+		span.End()
+
+		o.duration.Record(ctx, result.Duration.Seconds(), metric.WithAttributes(metricAttrs...))
+		o.calls.Add(ctx, 1, metric.WithAttributes(metricAttrs...))
+	}
+}
+
+// InjectHeader propagates the trace context of the call
+func (o *Observer) InjectHeader(ctx context.Context, header http.Header) {
+	o.propagator.Inject(ctx, propagation.HeaderCarrier(header))
+}
+
+// ErrorClass is "timeout", "canceled" or "network" for the errors of a call, "error" otherwise.
+// The messages of these errors are not recorded, they hold the request URL with its ids and regKeys.
+func ErrorClass(err error) string {
+	var netErr net.Error
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.As(err, &netErr):
+		if netErr.Timeout() {
+			return "timeout"
+		}
+		return "network"
+	}
+	return "error"
+}
+
+// AmountBucket groups amounts by order of magnitude, to keep the attribute cardinality low
+func AmountBucket(amount int) string {
+	switch {
+	case amount < 100:
+		return "<100"
+	case amount < 1000:
+		return "100-999"
+	case amount < 10000:
+		return "1000-9999"
+	case amount < 100000:
+		return "10000-99999"
+	default:
+		return ">=100000"
+	}
+}
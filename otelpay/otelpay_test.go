@@ -0,0 +1,188 @@
+package otelpay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	linepay "github.com/chy168/line-pay-sdk-go"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+const regKey = "RK9A4BD2D0E4DC1AB"
+
+func TestObserver(t *testing.T) {
+
+	var traceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		if strings.HasSuffix(r.URL.Path, "/check") {
+			w.Write([]byte(`{"returnCode":"1190","returnMessage":"regKey does not exist"}`))
+			return
+		}
+		w.Write([]byte(`{"returnCode":"0000","returnMessage":"OK","info":{"transactionId":2019049910005496810}}`))
+	}))
+	defer server.Close()
+
+	spans := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+
+	observer, err := New(
+		WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))),
+		WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))),
+		WithPropagator(propagation.TraceContext{}),
+	)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	client, _ := linepay.NewClient("1234567890", "secret", nil, &linepay.ClientOpts{
+		APIEndpoint: server.URL,
+		Observers:   []linepay.Observer{observer},
+	})
+
+	ctx := context.Background()
+	if _, err := client.PaymentsPreapproved(ctx, regKey, &linepay.PaymentsPreapprovedRequest{
+		ProductName: "Monthly plan", Amount: 1500, Currency: "TWD", OrderID: "order-1", Capture: true,
+	}); err != nil {
+		t.Fatalf("PaymentsPreapproved() error = %v", err)
+	}
+	if traceparent == "" {
+		t.Errorf("traceparent header not propagated")
+	}
+	if _, err := client.PaymentsCheckRegKey(ctx, regKey, nil); err != nil {
+		t.Fatalf("PaymentsCheckRegKey() error = %v", err)
+	}
+
+	ended := spans.Ended()
+	if len(ended) != 2 {
+		t.Fatalf("got %d spans, want 2", len(ended))
+	}
+
+	preapproved, check := ended[0], ended[1]
+	if preapproved.Name() != linepay.OperationPaymentsPreapproved || preapproved.Status().Code == codes.Error {
+		t.Errorf("span %s status %v", preapproved.Name(), preapproved.Status())
+	}
+	if check.Status().Code != codes.Error {
+		t.Errorf("span %s status = %v, want error on returnCode 1190", check.Name(), check.Status())
+	}
+
+	attrs := map[string]string{}
+	for _, span := range ended {
+		for _, kv := range span.Attributes() {
+			attrs[string(kv.Key)] = kv.Value.Emit()
+			if strings.Contains(kv.Value.Emit(), regKey) {
+				t.Errorf("span %s attribute %s leaks the regKey", span.Name(), kv.Key)
+			}
+		}
+	}
+	if attrs["http.route"] != "/v3/payments/preapprovedPay/{regKey}/check" || attrs["linepay.return_code"] != "1190" {
+		t.Errorf("attributes = %v", attrs)
+	}
+	if got := preapproved.Attributes(); !hasAttr(got, "linepay.amount_bucket", "1000-9999") || !hasAttr(got, "linepay.currency", "TWD") {
+		t.Errorf("PaymentsPreapproved attributes = %v", got)
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(ctx, &rm); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	var calls int64
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if sum, ok := m.Data.(metricdata.Sum[int64]); ok && m.Name == "linepay.client.calls" {
+				for _, dp := range sum.DataPoints {
+					calls += dp.Value
+				}
+			}
+		}
+	}
+	if calls != 2 {
+		t.Errorf("linepay.client.calls = %d, want 2", calls)
+	}
+}
+
+func TestObserverError(t *testing.T) {
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	spans := tracetest.NewSpanRecorder()
+	observer, err := New(WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	client, _ := linepay.NewClient("1234567890", "secret", nil, &linepay.ClientOpts{
+		APIEndpoint: server.URL,
+		Observers:   []linepay.Observer{observer},
+	})
+
+	if _, err := client.PaymentsCheckRegKey(context.Background(), regKey, nil); err == nil || !strings.Contains(err.Error(), regKey) {
+		t.Fatalf("PaymentsCheckRegKey() error = %v, want the URL error", err)
+	}
+
+	ended := spans.Ended()
+	if len(ended) != 1 {
+		t.Fatalf("got %d spans, want 1", len(ended))
+	}
+	span := ended[0]
+	if span.Status().Code != codes.Error || span.Status().Description != "network /v3/payments/preapprovedPay/{regKey}/check" {
+		t.Errorf("span status = %v", span.Status())
+	}
+	if !hasAttr(span.Attributes(), "error.type", "network") {
+		t.Errorf("span attributes = %v", span.Attributes())
+	}
+	for _, event := range span.Events() {
+		for _, kv := range event.Attributes {
+			if strings.Contains(kv.Value.Emit(), regKey) {
+				t.Errorf("span event %s attribute %s leaks the regKey", event.Name, kv.Key)
+			}
+		}
+	}
+}
+
+func TestErrorClass(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{context.DeadlineExceeded, "timeout"},
+		{fmt.Errorf("call: %w", context.Canceled), "canceled"},
+		{&url.Error{Op: "Get", URL: "https://api-pay.line.me/v3/payments/preapprovedPay/" + regKey + "/check", Err: errors.New("connection refused")}, "network"},
+		{errors.New("unexpected"), "error"},
+	}
+	for _, tt := range tests {
+		if got := ErrorClass(tt.err); got != tt.want {
+			t.Errorf("ErrorClass(%v) = %s, want %s", tt.err, got, tt.want)
+		}
+	}
+}
+
+func hasAttr(attrs []attribute.KeyValue, key, value string) bool {
+	for _, kv := range attrs {
+		if string(kv.Key) == key {
+			return kv.Value.Emit() == value
+		}
+	}
+	return false
+}
+
+func TestAmountBucket(t *testing.T) {
+	tests := map[int]string{1: "<100", 100: "100-999", 9999: "1000-9999", 50000: "10000-99999", 1000000: ">=100000"}
+	for amount, want := range tests {
+		if got := AmountBucket(amount); got != want {
+			t.Errorf("AmountBucket(%d) = %s, want %s", amount, got, want)
+		}
+	}
+}
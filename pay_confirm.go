@@ -2,10 +2,6 @@ package linepay
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
-	"io/ioutil"
-	"net/http"
 	"time"
 )
 
@@ -15,19 +11,24 @@ type PaymentsConfirmRequest struct {
 	Currency string `json:"currency"`
 }
 
+func (request *PaymentsConfirmRequest) money() (int, string) {
+	return request.Amount, request.Currency
+}
+
 type PaymentsConfirmResponse struct {
 	ReturnCode    string                      `json:"returnCode"`
 	ReturnMessage string                      `json:"returnMessage"`
 	Info          PaymentsConfirmInfoResponse `json:"info"`
 	Amount        int                         `json:"amount"`
 	Currency      string                      `json:"currency"`
+	Meta          *ResponseMeta               `json:"-"`
 }
 
 type PaymentsConfirmInfoResponse struct {
 	OrderID                 string                                `json:"orderId"`
-	TransactionID           int64                                 `json:"transactionId"`
+	TransactionID           TransactionID                         `json:"transactionId"`
 	AuthorizationExpireDate time.Time                             `json:"authorizationExpireDate"`
-	RegKey                  string                                `json:"regKey"`
+	RegKey                  SecretString                          `json:"regKey"`
 	PayInfo                 []PaymentsConfirmInfoPayInfoResponse  `json:"payInfo"`
 	Packages                []PaymentsConfirmInfoPackagesResponse `json:"packages"`
 	Shipping                PaymentsConfirmInfoShippingResponse   `json:"shipping"`
@@ -72,31 +73,25 @@ type PaymentsConfirmInfoShippingAddressRecipientResponse struct {
 	PhoneNo           string `json:"phoneNo"`
 }
 
-func (client *Client) PaymentsConfirm(ctx context.Context, transactionId int64, request *PaymentsConfirmRequest) (response *PaymentsConfirmResponse, err error) {
-
-	body, err := json.Marshal(request)
-	res, err := client.post(ctx, fmt.Sprintf(endpointV3PaymentsConfirm, transactionId), body)
-	if err != nil {
-		err = fmt.Errorf("PaymentsRequest post error = %v", err.Error())
-		return
-	}
-	defer res.Body.Close()
+func (client *Client) PaymentsConfirm(ctx context.Context, transactionId TransactionID, request *PaymentsConfirmRequest) (response *PaymentsConfirmResponse, err error) {
 
-	if res.StatusCode == http.StatusOK {
-		bodyBytes, ioerr := ioutil.ReadAll(res.Body)
-		if ioerr != nil {
-			err = fmt.Errorf("ReadAll read body failed: %s", ioerr.Error())
-			return
+	response, err = do[PaymentsConfirmRequest, PaymentsConfirmResponse](ctx, client, endpointPaymentsConfirm, request, transactionId)
+	if err == nil {
+		event := &PaymentConfirmed{
+			EventHeader:             header(EventPaymentConfirmed, transactionId),
+			OrderID:                 response.Info.OrderID,
+			TransactionID:           transactionId,
+			Captured:                response.Info.AuthorizationExpireDate.IsZero(),
+			AuthorizationExpireDate: response.Info.AuthorizationExpireDate,
+			Response:                response,
 		}
-		response = &PaymentsConfirmResponse{}
-		if err = json.Unmarshal(bodyBytes, response); err != nil {
-			return
+		if request != nil {
+			event.Amount, event.Currency = request.Amount, request.Currency
 		}
-
-	} else {
-		err = fmt.Errorf("failed response, StatusCode: %d", res.StatusCode)
-		return
+		for _, p := range response.Info.PayInfo {
+			event.PayInfo = append(event.PayInfo, EventPayInfo{Method: p.Method, Amount: p.Amount})
+		}
+		client.publish(ctx, response.ReturnCode, event)
 	}
-
 	return
 }
@@ -2,12 +2,7 @@ package linepay
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
-	"io/ioutil"
-	"net/http"
 	"net/url"
-	"strconv"
 	"time"
 )
 
@@ -19,19 +14,36 @@ const (
 
 // if assign `TransactionIDs` and `OrderIDs` both at the same time, they should mean for the same record (like `AND` query).
 type PaymentsDetailsRequest struct {
-	TransactionIDs []int64  ``
-	OrderIDs       []string ``
-	Fields         string   ``
+	TransactionIDs []TransactionID ``
+	OrderIDs       []string        ``
+	Fields         string          ``
+}
+
+func (request *PaymentsDetailsRequest) query() url.Values {
+
+	params := url.Values{}
+
+	for _, u := range request.TransactionIDs {
+		params.Add("transactionId", u.String())
+	}
+
+	for _, u := range request.OrderIDs {
+		params.Add("orderId", u)
+	}
+
+	return params
 }
 
 type PaymentsDetailsResponse struct {
 	ReturnCode    string                        `json:"returnCode"`
 	ReturnMessage string                        `json:"returnMessage"`
 	Info          []PaymentsDetailsInfoResponse `json:"info"`
+	Meta          *ResponseMeta                 `json:"-"`
 }
 
 type PaymentsDetailsInfoResponse struct {
-	TransactionID           int64                                   `json:"transactionId"`
+	TransactionID           TransactionID                           `json:"transactionId"`
+	OrderID                 string                                  `json:"orderId"`
 	TransactionDate         time.Time                               `json:"transactionDate"`
 	TransactionType         string                                  `json:"transactionType"`
 	PayStatus               string                                  `json:"payStatus"` // AUTHORIZATION, VOIDED_AUTHORIZATION, EXPIRED_AUTHORIZATION
@@ -41,7 +53,7 @@ type PaymentsDetailsInfoResponse struct {
 	AuthorizationExpireDate time.Time                               `json:"authorizationExpireDate"`
 	PayInfo                 []PaymentsDetailsInfoPayInfoResponse    `json:"payInfo"`
 	RefundList              []PaymentsDetailsInfoRefundListResponse `json:"refundList"` // in case of `Transaction` type
-	OriginalTransactionID   int64                                   `json:"originalTransactionId"`
+	OriginalTransactionID   TransactionID                           `json:"originalTransactionId"`
 	Packages                []PaymentsDetailsInfoPackagesResponse   `json:"packages"`
 	Shipping                PaymentsDetailsInfoShippingResponse     `json:"shipping"`
 }
@@ -52,10 +64,10 @@ type PaymentsDetailsInfoPayInfoResponse struct {
 }
 
 type PaymentsDetailsInfoRefundListResponse struct {
-	RefundTransactionID   int64     `json:"refundTransactionId"`
-	TransactionType       string    `json:"transactionType"` // PAYMENT_REFUND, PARTIAL_REFUND
-	RefundAmount          int       `json:"refundAmount"`
-	RefundTransactionDate time.Time `json:"refundTransactionDate"`
+	RefundTransactionID   TransactionID `json:"refundTransactionId"`
+	TransactionType       string        `json:"transactionType"` // PAYMENT_REFUND, PARTIAL_REFUND
+	RefundAmount          int           `json:"refundAmount"`
+	RefundTransactionDate time.Time     `json:"refundTransactionDate"`
 }
 
 type PaymentsDetailsInfoPackagesResponse struct {
@@ -103,39 +115,20 @@ type PaymentsDetailsInfoShippingAddressRecipientResponse struct {
 // PaymentsDetails
 func (client *Client) PaymentsDetails(ctx context.Context, request *PaymentsDetailsRequest) (response *PaymentsDetailsResponse, err error) {
 
-	params := url.Values{}
-
-	for _, u := range request.TransactionIDs {
-		params.Add("transactionId", strconv.FormatInt(u, 10))
-	}
-
-	for _, u := range request.OrderIDs {
-		params.Add("orderId", u)
-	}
-
-	res, err := client.get(context.Background(), endpointV3PaymentsDetails, &params)
-	if err != nil {
-		err = fmt.Errorf("PaymentsRequest post error = %v", err.Error())
-		return
-	}
-	defer res.Body.Close()
-
-	if res.StatusCode == http.StatusOK {
-		bodyBytes, ioerr := ioutil.ReadAll(res.Body)
-		if ioerr != nil {
-			err = fmt.Errorf("ReadAll read body failed: %s", ioerr.Error())
-			return
-		}
-
-		response = &PaymentsDetailsResponse{}
-		if err = json.Unmarshal(bodyBytes, response); err != nil {
-			return
+	response, err = do[PaymentsDetailsRequest, PaymentsDetailsResponse](ctx, client, endpointPaymentsDetails, request)
+	if err == nil {
+		for i := range response.Info {
+			info := &response.Info[i]
+			if info.PayStatus == "EXPIRED_AUTHORIZATION" {
+				client.publish(ctx, response.ReturnCode, &PaymentExpired{
+					EventHeader:             header(EventPaymentExpired, info.TransactionID),
+					OrderID:                 info.OrderID,
+					TransactionID:           info.TransactionID,
+					AuthorizationExpireDate: info.AuthorizationExpireDate,
+					Info:                    info,
+				})
+			}
 		}
-
-	} else {
-		err = fmt.Errorf("failed response, StatusCode: %d", res.StatusCode)
-		return
 	}
-
 	return
 }
@@ -0,0 +1,95 @@
+package linepay
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// PaymentsPreapprovedRequest request body of pay preapproved api
+// `ProductName` required
+// `Amount` required
+// `Currency` required, is ISO 4217, supported: USD, JPY, TWD, THB
+// `OrderID` required, must be unique for every charge
+// if `Capture` false: the payment is only authorized, call `Capture API` to finalize it
+type PaymentsPreapprovedRequest struct {
+	ProductName string `json:"productName"`
+	Amount      int    `json:"amount"`
+	Currency    string `json:"currency"`
+	OrderID     string `json:"orderId"`
+	Capture     bool   `json:"capture"`
+}
+
+func (request *PaymentsPreapprovedRequest) money() (int, string) {
+	return request.Amount, request.Currency
+}
+
+// PaymentsPreapprovedResponse response body of pay preapproved api
+type PaymentsPreapprovedResponse struct {
+	ReturnCode    string                          `json:"returnCode"`
+	ReturnMessage string                          `json:"returnMessage"`
+	Info          PaymentsPreapprovedInfoResponse `json:"info"`
+	Meta          *ResponseMeta                   `json:"-"`
+}
+
+type PaymentsPreapprovedInfoResponse struct {
+	TransactionID           TransactionID `json:"transactionId"`
+	TransactionDate         time.Time     `json:"transactionDate"`
+	AuthorizationExpireDate time.Time     `json:"authorizationExpireDate"` // only when `Capture` is false
+}
+
+// PaymentsCheckRegKeyRequest query of check regKey api
+// if `CreditCardAuth` true: the credit card bound to the regKey is verified with a minimum amount authorization
+type PaymentsCheckRegKeyRequest struct {
+	CreditCardAuth bool
+}
+
+func (request *PaymentsCheckRegKeyRequest) query() url.Values {
+	return url.Values{"creditCardAuth": {strconv.FormatBool(request.CreditCardAuth)}}
+}
+
+// PaymentsCheckRegKeyResponse response body of check regKey api
+// `ReturnCode`: 0000 valid, 1190 regKey not found, 1193 regKey expired, 1194 preapproved payment not allowed
+type PaymentsCheckRegKeyResponse struct {
+	ReturnCode    string        `json:"returnCode"`
+	ReturnMessage string        `json:"returnMessage"`
+	Meta          *ResponseMeta `json:"-"`
+}
+
+// PaymentsExpireRegKeyResponse response body of expire regKey api
+type PaymentsExpireRegKeyResponse struct {
+	ReturnCode    string        `json:"returnCode"`
+	ReturnMessage string        `json:"returnMessage"`
+	Meta          *ResponseMeta `json:"-"`
+}
+
+// PaymentsPreapproved charges the user with the `regKey` returned by `Confirm API` of a `PREAPPROVED` payment, without user interaction.
+func (client *Client) PaymentsPreapproved(ctx context.Context, regKey string, request *PaymentsPreapprovedRequest) (response *PaymentsPreapprovedResponse, err error) {
+
+	response, err = do[PaymentsPreapprovedRequest, PaymentsPreapprovedResponse](ctx, client, endpointPaymentsPreapproved, request, regKey)
+	if err == nil {
+		event := &PaymentConfirmed{
+			EventHeader:             header(EventPaymentConfirmed, response.Info.TransactionID),
+			TransactionID:           response.Info.TransactionID,
+			AuthorizationExpireDate: response.Info.AuthorizationExpireDate,
+		}
+		if request != nil {
+			event.OrderID, event.Amount, event.Currency, event.Captured = request.OrderID, request.Amount, request.Currency, request.Capture
+		}
+		client.publish(ctx, response.ReturnCode, event)
+	}
+	return
+}
+
+// PaymentsCheckRegKey checks whether the `regKey` is still available for `PaymentsPreapproved`.
+func (client *Client) PaymentsCheckRegKey(ctx context.Context, regKey string, request *PaymentsCheckRegKeyRequest) (response *PaymentsCheckRegKeyResponse, err error) {
+
+	return do[PaymentsCheckRegKeyRequest, PaymentsCheckRegKeyResponse](ctx, client, endpointPaymentsCheckRegKey, request, regKey)
+}
+
+// PaymentsExpireRegKey expires the `regKey`, it can not be used for `PaymentsPreapproved` anymore.
+func (client *Client) PaymentsExpireRegKey(ctx context.Context, regKey string) (response *PaymentsExpireRegKeyResponse, err error) {
+
+	return do[struct{}, PaymentsExpireRegKeyResponse](ctx, client, endpointPaymentsExpireRegKey, nil, regKey)
+}
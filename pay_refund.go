@@ -0,0 +1,48 @@
+package linepay
+
+import (
+	"context"
+	"time"
+)
+
+// PaymentsRefundRequest request body of refund api
+// `RefundAmount` optional, the full amount is refunded when 0
+type PaymentsRefundRequest struct {
+	RefundAmount int `json:"refundAmount,omitempty"`
+}
+
+func (request *PaymentsRefundRequest) money() (int, string) {
+	return request.RefundAmount, ""
+}
+
+// PaymentsRefundResponse response body of refund api
+type PaymentsRefundResponse struct {
+	ReturnCode    string                     `json:"returnCode"`
+	ReturnMessage string                     `json:"returnMessage"`
+	Info          PaymentsRefundInfoResponse `json:"info"`
+	Meta          *ResponseMeta              `json:"-"`
+}
+
+type PaymentsRefundInfoResponse struct {
+	RefundTransactionID   TransactionID `json:"refundTransactionId"`
+	RefundTransactionDate time.Time     `json:"refundTransactionDate"`
+}
+
+// PaymentsRefund refunds a captured payment, fully or partially.
+func (client *Client) PaymentsRefund(ctx context.Context, transactionId TransactionID, request *PaymentsRefundRequest) (response *PaymentsRefundResponse, err error) {
+
+	response, err = do[PaymentsRefundRequest, PaymentsRefundResponse](ctx, client, endpointPaymentsRefund, request, transactionId)
+	if err == nil {
+		event := &PaymentRefunded{
+			EventHeader:         header(EventPaymentRefunded, response.Info.RefundTransactionID),
+			TransactionID:       transactionId,
+			RefundTransactionID: response.Info.RefundTransactionID,
+			Response:            response,
+		}
+		if request != nil {
+			event.RefundAmount = request.RefundAmount
+		}
+		client.publish(ctx, response.ReturnCode, event)
+	}
+	return
+}
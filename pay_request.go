@@ -2,10 +2,6 @@ package linepay
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
-	"io/ioutil"
-	"net/http"
 )
 
 // `Amount` required, valid amount `form.amount != sum(packages[].amount) + sum(packages[].userFee) + shippingFee`
@@ -21,6 +17,10 @@ type PaymentsRequest struct {
 	Options      PaymentsOptionsRequest      `json:"options"`
 }
 
+func (request *PaymentsRequest) money() (int, string) {
+	return request.Amount, request.Currency
+}
+
 // `Id` required
 // `Amount` required, valid amount `packages[].amount != sum(packages[].products[].quantity * packages[].products[].price)`
 // `Name` required
@@ -127,11 +127,12 @@ type PaymentsResponse struct {
 	ReturnCode    string               `json:"returnCode"`
 	ReturnMessage string               `json:"returnMessage"`
 	Info          PaymentsInfoResponse `json:"info"`
+	Meta          *ResponseMeta        `json:"-"`
 }
 
 type PaymentsInfoResponse struct {
-	TransactionID      int64                          `json:"transactionId"`
-	PaymentAccessToken string                         `json:"paymentAccessToken"`
+	TransactionID      TransactionID                  `json:"transactionId"`
+	PaymentAccessToken SecretString                   `json:"paymentAccessToken"`
 	PaymentURL         PaymentsInfoPaymentURLResponse `json:"paymentUrl"`
 }
 
@@ -140,31 +141,23 @@ type PaymentsInfoPaymentURLResponse struct {
 	App string `json:"app"`
 }
 
+// PaymentsRequest on a v2 client sends `request.V2()`
 func (client *Client) PaymentsRequest(ctx context.Context, request *PaymentsRequest) (response *PaymentsResponse, err error) {
 
-	body, err := json.Marshal(request)
-	res, err := client.post(ctx, endpointV3PaymentsRequest, body)
-	if err != nil {
-		err = fmt.Errorf("PaymentsRequest post error = %v", err.Error())
-		return
+	if client.apiVersion == APIVersion2 {
+		return client.PaymentsRequestV2(ctx, request.V2())
 	}
-	defer res.Body.Close()
 
-	if res.StatusCode == http.StatusOK {
-		bodyBytes, ioerr := ioutil.ReadAll(res.Body)
-		if ioerr != nil {
-			err = fmt.Errorf("ReadAll read body failed: %s", ioerr.Error())
-			return
+	response, err = do[PaymentsRequest, PaymentsResponse](ctx, client, endpointPaymentsRequest, request)
+	if err == nil {
+		event := &PaymentRequested{
+			EventHeader:   header(EventPaymentRequested, response.Info.TransactionID),
+			TransactionID: response.Info.TransactionID,
 		}
-		response = &PaymentsResponse{}
-		if err = json.Unmarshal(bodyBytes, response); err != nil {
-			return
+		if request != nil {
+			event.OrderID, event.Amount, event.Currency = request.OrderID, request.Amount, request.Currency
 		}
-
-	} else {
-		err = fmt.Errorf("failed response, StatusCode: %d", res.StatusCode)
-		return
+		client.publish(ctx, response.ReturnCode, event)
 	}
-
 	return
 }
@@ -0,0 +1,96 @@
+package linepay
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNotSupportedByV2 is returned by the methods of a v3 only API when the client uses APIVersion2
+var ErrNotSupportedByV2 = errors.New("linepay: not supported by the v2 API")
+
+// PaymentsRequestV2 is the body of the v2 Request API, one product and flat options instead of packages.
+// The other v2 APIs share the request and response types of v3.
+// `ProductName` required
+// `Amount` required
+// `Currency` required, is ISO 4217, supported: USD, JPY, TWD, THB
+// `OrderID` required
+// `ConfirmURL` required
+// `CancelURL` required
+type PaymentsRequestV2 struct {
+	ProductName            string `json:"productName"`
+	ProductImageURL        string `json:"productImageUrl,omitempty"`
+	Amount                 int    `json:"amount"`
+	Currency               string `json:"currency"`
+	OrderID                string `json:"orderId"`
+	ConfirmURL             string `json:"confirmUrl"`
+	ConfirmURLType         string `json:"confirmUrlType,omitempty"`
+	CancelURL              string `json:"cancelUrl"`
+	CheckConfirmURLBrowser bool   `json:"checkConfirmUrlBrowser,omitempty"`
+	PackageName            string `json:"packageName,omitempty"` // Android app package name
+	Capture                bool   `json:"capture,omitempty"`
+	PayType                string `json:"payType,omitempty"` // NORMAL, PREAPPROVED
+	LangCd                 string `json:"langCd,omitempty"`  // en, ja, ko, th, zh_TW, zh_CN
+}
+
+func (request *PaymentsRequestV2) money() (int, string) {
+	return request.Amount, request.Currency
+}
+
+// V2 converts the v3 request to the v2 body, used by PaymentsRequest on a v2 client.
+// The product is the only product of the request, or the first package when there are several products.
+// Shipping, family service and branch options have no v2 equivalent and are dropped. A nil request converts to nil.
+func (request *PaymentsRequest) V2() *PaymentsRequestV2 {
+
+	if request == nil {
+		return nil
+	}
+
+	v2 := &PaymentsRequestV2{
+		Amount:                 request.Amount,
+		Currency:               request.Currency,
+		OrderID:                request.OrderID,
+		ConfirmURL:             request.RedirectUrls.ConfirmURL,
+		ConfirmURLType:         request.RedirectUrls.ConfirmURLType,
+		CancelURL:              request.RedirectUrls.CancelURL,
+		CheckConfirmURLBrowser: request.Options.Display.CheckConfirmURLBrowser,
+		PackageName:            request.RedirectUrls.AppPackageName,
+		Capture:                request.Options.Payment.Capture,
+		PayType:                request.Options.Payment.PayType,
+		LangCd:                 request.Options.Display.Locale,
+	}
+
+	if len(request.Packages) > 0 {
+		pkg := request.Packages[0]
+		v2.ProductName = pkg.Name
+		if len(request.Packages) == 1 && len(pkg.Products) == 1 {
+			v2.ProductName = pkg.Products[0].Name
+		}
+		if len(pkg.Products) > 0 {
+			v2.ProductImageURL = pkg.Products[0].ImageURL
+		}
+	}
+
+	return v2
+}
+
+// PaymentsRequestV2 calls the v2 Request API with v2 only fields, the client must use APIVersion2
+func (client *Client) PaymentsRequestV2(ctx context.Context, request *PaymentsRequestV2) (response *PaymentsResponse, err error) {
+
+	if client.apiVersion != APIVersion2 {
+		err = errors.New("PaymentsRequestV2 requires a client of APIVersion2")
+		return
+	}
+
+	response, err = do[PaymentsRequestV2, PaymentsResponse](ctx, client, endpointPaymentsRequest, request)
+	if err == nil {
+		event := &PaymentRequested{
+			EventHeader:   header(EventPaymentRequested, response.Info.TransactionID),
+			TransactionID: response.Info.TransactionID,
+		}
+		if request != nil {
+			event.OrderID, event.Amount, event.Currency = request.OrderID, request.Amount, request.Currency
+		}
+		client.publish(ctx, response.ReturnCode, event)
+	}
+	return
+}
@@ -0,0 +1,98 @@
+package linepay
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_V2(t *testing.T) {
+
+	var paths []string
+	var body map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		if r.Header.Get("X-LINE-ChannelId") != "1001" || r.Header.Get("X-LINE-ChannelSecret") != "secret" || r.Header.Get("X-LINE-Authorization") != "" {
+			fmt.Fprint(w, `{"returnCode":"1106","returnMessage":"Header information error."}`)
+			return
+		}
+		b, _ := ioutil.ReadAll(r.Body)
+		body = map[string]interface{}{}
+		json.Unmarshal(b, &body)
+		fmt.Fprint(w, `{"returnCode":"0000","returnMessage":"Success.","info":{"transactionId":2019049910005496810,"paymentUrl":{"web":"https://sandbox-web-pay.line.me/web/wait?transactionReserveId=abc"}}}`)
+	}))
+	defer ts.Close()
+
+	client, err := NewClient("1001", "secret", nil, &ClientOpts{APIEndpoint: ts.URL, APIVersion: APIVersion2})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	request := &PaymentsRequest{
+		Amount:   250,
+		Currency: "TWD",
+		OrderID:  "order-1",
+		Packages: []PaymentsPackageRequest{{ID: "pkg", Amount: 250, Name: "pkg", Products: []PaymentsPackageProductRequest{{Name: "latte", Quantity: 1, Price: 250}}}},
+		RedirectUrls: PaymentsRedirectUrlsRequest{
+			ConfirmURL: "https://example.com/confirm",
+			CancelURL:  "https://example.com/cancel",
+		},
+	}
+	request.Options.Display.Locale = "zh_TW"
+
+	res, err := client.PaymentsRequest(context.Background(), request)
+	if err != nil || res.ReturnCode != ApiReturnCodeSuccess || res.Info.TransactionID != 2019049910005496810 {
+		t.Fatalf("PaymentsRequest() = %+v, %v", res, err)
+	}
+	if body["productName"] != "latte" || body["langCd"] != "zh_TW" || body["confirmUrl"] != "https://example.com/confirm" || body["packages"] != nil {
+		t.Errorf("v2 request body = %v", body)
+	}
+
+	if _, err := client.PaymentsConfirm(context.Background(), 2019049910005496810, &PaymentsConfirmRequest{Amount: 250, Currency: "TWD"}); err != nil {
+		t.Fatalf("PaymentsConfirm() error = %v", err)
+	}
+	if _, err := client.PaymentsExpireRegKey(context.Background(), "RK1"); err != nil {
+		t.Fatalf("PaymentsExpireRegKey() error = %v", err)
+	}
+
+	want := []string{"/v2/payments/request", "/v2/payments/2019049910005496810/confirm", "/v2/payments/preapprovedPay/RK1/expire"}
+	if fmt.Sprint(paths) != fmt.Sprint(want) {
+		t.Errorf("paths = %v, want %v", paths, want)
+	}
+
+	// a nil request is sent without body, like on a v3 client
+	if _, err := client.PaymentsRequest(context.Background(), nil); err != nil {
+		t.Errorf("PaymentsRequest(nil) error = %v", err)
+	}
+
+	if _, err := client.PaymentsStatus(context.Background(), 1); err != ErrNotSupportedByV2 {
+		t.Errorf("PaymentsStatus() error = %v", err)
+	}
+
+	v3, _ := NewClient("1001", "secret", nil, &ClientOpts{APIEndpoint: ts.URL})
+	if _, err := v3.PaymentsRequestV2(context.Background(), request.V2()); err == nil {
+		t.Errorf("PaymentsRequestV2() on a v3 client no error")
+	}
+	if _, err := NewClient("1001", "secret", nil, &ClientOpts{APIVersion: 1}); err == nil {
+		t.Errorf("NewClient() of API version 1 no error")
+	}
+}
+
+func TestPaymentsRequest_V2(t *testing.T) {
+
+	request := &PaymentsRequest{
+		Packages: []PaymentsPackageRequest{
+			{Name: "order #1", Products: []PaymentsPackageProductRequest{{Name: "latte", ImageURL: "https://example.com/latte.png"}, {Name: "bagel"}}},
+		},
+	}
+	request.Options.Payment.PayType = "PREAPPROVED"
+
+	v2 := request.V2()
+	if v2.ProductName != "order #1" || v2.ProductImageURL != "https://example.com/latte.png" || v2.PayType != "PREAPPROVED" {
+		t.Errorf("V2() = %+v", v2)
+	}
+}
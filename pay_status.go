@@ -0,0 +1,33 @@
+package linepay
+
+import (
+	"context"
+)
+
+// `ReturnCode` of check payment status api
+const (
+	PaymentsStatusPending    string = "0000" // waiting for the user to authorize the payment
+	PaymentsStatusAuthorized string = "0110" // authorized by the user, call `Confirm API`
+	PaymentsStatusCanceled   string = "0121" // canceled by the user or timeout
+	PaymentsStatusFailed     string = "0122" // payment failed
+	PaymentsStatusCompleted  string = "0123" // payment completed by `Confirm API`
+)
+
+// PaymentsStatusResponse response body of check payment status api
+type PaymentsStatusResponse struct {
+	ReturnCode    string        `json:"returnCode"`
+	ReturnMessage string        `json:"returnMessage"`
+	Meta          *ResponseMeta `json:"-"`
+}
+
+// PaymentsStatus checks the status of a payment request, useful when `ConfirmURLType` is `NONE` or the confirm redirect was lost.
+// v3 only, a v2 client returns ErrNotSupportedByV2.
+func (client *Client) PaymentsStatus(ctx context.Context, transactionId TransactionID) (response *PaymentsStatusResponse, err error) {
+
+	if client.apiVersion == APIVersion2 {
+		err = ErrNotSupportedByV2
+		return
+	}
+
+	return do[struct{}, PaymentsStatusResponse](ctx, client, endpointPaymentsStatus, nil, transactionId)
+}
@@ -0,0 +1,22 @@
+package linepay
+
+import (
+	"context"
+)
+
+// PaymentsVoidResponse response body of void api
+type PaymentsVoidResponse struct {
+	ReturnCode    string        `json:"returnCode"`
+	ReturnMessage string        `json:"returnMessage"`
+	Meta          *ResponseMeta `json:"-"`
+}
+
+// PaymentsVoid voids an authorization which has not been captured yet (`options.payment.capture` false).
+func (client *Client) PaymentsVoid(ctx context.Context, transactionId TransactionID) (response *PaymentsVoidResponse, err error) {
+
+	response, err = do[struct{}, PaymentsVoidResponse](ctx, client, endpointPaymentsVoid, nil, transactionId)
+	if err == nil {
+		client.publish(ctx, response.ReturnCode, &PaymentVoided{EventHeader: header(EventPaymentVoided, transactionId), TransactionID: transactionId})
+	}
+	return
+}
@@ -0,0 +1,83 @@
+package linepay
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/chy168/line-pay-sdk-go/qrcode"
+)
+
+// LINEAppPackageName is the Android package of the LINE app, which opens the `App` payment URL
+const LINEAppPackageName = "jp.naver.line.android"
+
+// Platform of the user, detected from the User-Agent
+type Platform int
+
+const (
+	PlatformDesktop Platform = iota
+	PlatformAndroid
+	PlatformIOS
+)
+
+// DetectPlatform guesses the platform from a User-Agent header
+func DetectPlatform(userAgent string) Platform {
+	ua := strings.ToLower(userAgent)
+	switch {
+	case strings.Contains(ua, "android"):
+		return PlatformAndroid
+	case strings.Contains(ua, "iphone"), strings.Contains(ua, "ipad"), strings.Contains(ua, "ipod"):
+		return PlatformIOS
+	default:
+		return PlatformDesktop
+	}
+}
+
+// ForUserAgent picks the URL to send the user to:
+// desktop gets `Web`, iOS gets `App`,
+// Android gets `App` when the payment was requested by a merchant app (`redirectUrls.AppPackageName` set, LINE Pay checks the calling package),
+// otherwise the `AndroidIntentURL` which falls back to `Web` when LINE is not installed.
+// `redirectUrls` is the one of the PaymentsRequest, it may be nil.
+func (u PaymentsInfoPaymentURLResponse) ForUserAgent(userAgent string, redirectUrls *PaymentsRedirectUrlsRequest) string {
+
+	if u.App == "" {
+		return u.Web
+	}
+
+	switch DetectPlatform(userAgent) {
+	case PlatformIOS:
+		return u.App
+	case PlatformAndroid:
+		if redirectUrls != nil && redirectUrls.AppPackageName != "" {
+			return u.App
+		}
+		if intent := u.AndroidIntentURL(); intent != "" {
+			return intent
+		}
+		return u.App
+	default:
+		return u.Web
+	}
+}
+
+// AndroidIntentURL converts `App` to an Android intent link opening the LINE app, with `Web` as the browser fallback.
+// Returns "" when `App` is not a valid URL.
+func (u PaymentsInfoPaymentURLResponse) AndroidIntentURL() string {
+
+	app, err := url.Parse(u.App)
+	if err != nil || app.Scheme == "" {
+		return ""
+	}
+
+	scheme := app.Scheme
+	app.Scheme = ""
+	link := "intent:" + app.String() + "#Intent;scheme=" + scheme + ";package=" + LINEAppPackageName + ";"
+	if u.Web != "" {
+		link += "S.browser_fallback_url=" + url.QueryEscape(u.Web) + ";"
+	}
+	return link + "end"
+}
+
+// QRCode encodes the `Web` URL, to be scanned with a phone camera or the LINE app
+func (u PaymentsInfoPaymentURLResponse) QRCode() (*qrcode.QRCode, error) {
+	return qrcode.Encode(u.Web, qrcode.Medium)
+}
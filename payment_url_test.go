@@ -0,0 +1,44 @@
+package linepay
+
+import (
+	"testing"
+)
+
+func TestPaymentsInfoPaymentURLResponse_ForUserAgent(t *testing.T) {
+
+	u := PaymentsInfoPaymentURLResponse{
+		Web: "https://sandbox-web-pay.line.me/web/payment/wait?transactionReserveId=abc",
+		App: "line://pay/payment/abc",
+	}
+
+	const (
+		android = "Mozilla/5.0 (Linux; Android 10; Pixel 3) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/80.0.3987.99 Mobile Safari/537.36"
+		iphone  = "Mozilla/5.0 (iPhone; CPU iPhone OS 13_3 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/13.0.5 Mobile/15E148 Safari/604.1"
+		desktop = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/80.0.3987.132 Safari/537.36"
+	)
+
+	intent := "intent://pay/payment/abc#Intent;scheme=line;package=jp.naver.line.android;" +
+		"S.browser_fallback_url=https%3A%2F%2Fsandbox-web-pay.line.me%2Fweb%2Fpayment%2Fwait%3FtransactionReserveId%3Dabc;end"
+
+	tests := []struct {
+		userAgent    string
+		redirectUrls *PaymentsRedirectUrlsRequest
+		want         string
+	}{
+		{desktop, nil, u.Web},
+		{iphone, nil, u.App},
+		{android, nil, intent},
+		{android, &PaymentsRedirectUrlsRequest{AppPackageName: "com.example.shop"}, u.App},
+	}
+
+	for _, tt := range tests {
+		if got := u.ForUserAgent(tt.userAgent, tt.redirectUrls); got != tt.want {
+			t.Errorf("ForUserAgent(%s) = '%s', want '%s'", tt.userAgent, got, tt.want)
+		}
+	}
+
+	q, err := u.QRCode()
+	if err != nil || q.Size != q.Version*4+17 {
+		t.Errorf("QRCode() = %+v, %v", q, err)
+	}
+}
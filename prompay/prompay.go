@@ -0,0 +1,197 @@
+// Package prompay exports Prometheus metrics of `linepay.Client` calls.
+//
+//	collector := prompay.New()
+//	prometheus.MustRegister(collector)
+//	client, err := linepay.NewClient(channelID, channelSecret, nil, &linepay.ClientOpts{Observers: []linepay.Observer{collector}})
+//
+// Metric names and labels are part of the API, dashboards and alerts rely on them (see `grafana-dashboard.json`).
+// They are only added to, never renamed or removed.
+package prompay
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	linepay "github.com/chy168/line-pay-sdk-go"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// metric names
+const (
+	MetricCalls    = "linepay_client_calls_total"
+	MetricDuration = "linepay_client_call_duration_seconds"
+	MetricRetries  = "linepay_client_retries_total"
+	MetricInFlight = "linepay_client_in_flight_calls"
+
+	MetricLimitWait     = "linepay_client_limit_wait_seconds"
+	MetricLimitCanceled = "linepay_client_limit_canceled_total"
+	MetricThrottled     = "linepay_client_throttled_total"
+	MetricRateLimit     = "linepay_client_rate_limit"
+)
+
+// label names
+const (
+	LabelOperation  = "operation"
+	LabelReturnCode = "return_code"
+	LabelStatusCode = "status_code"
+)
+
+// DefaultBuckets of the latency histogram, in seconds. LINE Pay answers in 100ms to a few seconds and the client
+// timeout is usually below 60s.
+var DefaultBuckets = []float64{.05, .1, .25, .5, 1, 2, 5, 10, 30, 60}
+
+// limitWaitBuckets of the limit wait histogram, in seconds
+var limitWaitBuckets = []float64{.001, .005, .01, .05, .1, .5, 1, 5, 10}
+
+// Option of New
+type Option func(*config)
+
+type config struct {
+	constLabels prometheus.Labels
+	buckets     []float64
+}
+
+// WithConstLabels adds `labels` to every metric, e.g. the channel when a process runs several clients
+func WithConstLabels(labels prometheus.Labels) Option {
+	return func(c *config) { c.constLabels = labels }
+}
+
+// WithBuckets replaces DefaultBuckets of the latency histogram
+func WithBuckets(buckets []float64) Option {
+	return func(c *config) { c.buckets = buckets }
+}
+
+// Collector implements `prometheus.Collector`, `linepay.Observer` and `linepay.LimitObserver`.
+//
+//	linepay_client_calls_total{operation, return_code, status_code}  counter
+//	linepay_client_call_duration_seconds{operation}                   histogram, retries included
+//	linepay_client_retries_total{operation}                           counter
+//	linepay_client_in_flight_calls{operation}                         gauge
+//	linepay_client_limit_wait_seconds{operation}                      histogram, calls which waited for ClientOpts.Limits
+//	linepay_client_limit_canceled_total{operation}                    counter, calls whose context ended while waiting
+//	linepay_client_throttled_total{operation}                         counter, rate reductions after LINE Pay throttled
+//	linepay_client_rate_limit{operation}                              gauge, current rate in calls per second
+//
+// `return_code` is the LINE Pay returnCode, empty on network errors. `status_code` is the HTTP status, 0 on network errors.
+type Collector struct {
+	calls    *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	retries  *prometheus.CounterVec
+	inFlight *prometheus.GaugeVec
+
+	limitWait     *prometheus.HistogramVec
+	limitCanceled *prometheus.CounterVec
+	throttled     *prometheus.CounterVec
+	rateLimit     *prometheus.GaugeVec
+}
+
+var (
+	_ linepay.Observer      = (*Collector)(nil)
+	_ linepay.LimitObserver = (*Collector)(nil)
+	_ prometheus.Collector  = (*Collector)(nil)
+)
+
+func New(opts ...Option) *Collector {
+
+	c := &config{buckets: DefaultBuckets}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return &Collector{
+		calls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name:        MetricCalls,
+			Help:        "LINE Pay API calls by operation, returnCode and HTTP status.",
+			ConstLabels: c.constLabels,
+		}, []string{LabelOperation, LabelReturnCode, LabelStatusCode}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:        MetricDuration,
+			Help:        "Duration of LINE Pay API calls, retries included.",
+			ConstLabels: c.constLabels,
+			Buckets:     c.buckets,
+		}, []string{LabelOperation}),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name:        MetricRetries,
+			Help:        "Retried attempts of LINE Pay API calls.",
+			ConstLabels: c.constLabels,
+		}, []string{LabelOperation}),
+		inFlight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name:        MetricInFlight,
+			Help:        "LINE Pay API calls in progress.",
+			ConstLabels: c.constLabels,
+		}, []string{LabelOperation}),
+		limitWait: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:        MetricLimitWait,
+			Help:        "Time LINE Pay API calls waited for their rate or concurrency limit.",
+			ConstLabels: c.constLabels,
+			Buckets:     limitWaitBuckets,
+		}, []string{LabelOperation}),
+		limitCanceled: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name:        MetricLimitCanceled,
+			Help:        "LINE Pay API calls whose context ended while waiting for their limit.",
+			ConstLabels: c.constLabels,
+		}, []string{LabelOperation}),
+		throttled: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name:        MetricThrottled,
+			Help:        "Rate reductions after LINE Pay throttled the calls.",
+			ConstLabels: c.constLabels,
+		}, []string{LabelOperation}),
+		rateLimit: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name:        MetricRateLimit,
+			Help:        "Rate limit of LINE Pay API calls in calls per second, reduced while LINE Pay throttles.",
+			ConstLabels: c.constLabels,
+		}, []string{LabelOperation}),
+	}
+}
+
+func (c *Collector) StartCall(ctx context.Context, info *linepay.CallInfo) (context.Context, func(*linepay.CallResult)) {
+
+	inFlight := c.inFlight.WithLabelValues(info.Operation)
+	inFlight.Inc()
+
+	return ctx, func(result *linepay.CallResult) {
+		inFlight.Dec()
+		c.calls.WithLabelValues(info.Operation, result.ReturnCode, strconv.Itoa(result.StatusCode)).Inc()
+		c.duration.WithLabelValues(info.Operation).Observe(result.Duration.Seconds())
+		if result.Retries > 0 {
+			c.retries.WithLabelValues(info.Operation).Add(float64(result.Retries))
+		}
+	}
+}
+
+func (c *Collector) LimitWaited(operation string, wait time.Duration, err error) {
+	c.limitWait.WithLabelValues(operation).Observe(wait.Seconds())
+	if err != nil {
+		c.limitCanceled.WithLabelValues(operation).Inc()
+	}
+}
+
+func (c *Collector) LimitRate(operation string, rate float64, throttled bool) {
+	c.rateLimit.WithLabelValues(operation).Set(rate)
+	if throttled {
+		c.throttled.WithLabelValues(operation).Inc()
+	}
+}
+
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.calls.Describe(ch)
+	c.duration.Describe(ch)
+	c.retries.Describe(ch)
+	c.inFlight.Describe(ch)
+	c.limitWait.Describe(ch)
+	c.limitCanceled.Describe(ch)
+	c.throttled.Describe(ch)
+	c.rateLimit.Describe(ch)
+}
+
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.calls.Collect(ch)
+	c.duration.Collect(ch)
+	c.retries.Collect(ch)
+	c.inFlight.Collect(ch)
+	c.limitWait.Collect(ch)
+	c.limitCanceled.Collect(ch)
+	c.throttled.Collect(ch)
+	c.rateLimit.Collect(ch)
+}
@@ -0,0 +1,113 @@
+package prompay
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	linepay "github.com/chy168/line-pay-sdk-go"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestCollector(t *testing.T) {
+
+	collector := New(WithConstLabels(prometheus.Labels{"channel": "tw"}))
+
+	var calls int
+	var inFlight float64
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		inFlight = testutil.ToFloat64(collector.inFlight.WithLabelValues(linepay.OperationPaymentsCapture))
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"returnCode":"1150","returnMessage":"Transaction record not found."}`)
+	}))
+	defer ts.Close()
+
+	client, _ := linepay.NewClient("1001", "secret", nil, &linepay.ClientOpts{
+		APIEndpoint: ts.URL,
+		Retry:       linepay.RetryPolicy{MaxRetries: 1, Backoff: time.Millisecond},
+		Observers:   []linepay.Observer{collector},
+	})
+
+	if _, err := client.PaymentsCapture(context.Background(), 1, &linepay.PaymentsCaptureRequest{Amount: 100, Currency: "TWD"}); err != nil {
+		t.Fatalf("PaymentsCapture() error = %v", err)
+	}
+	if inFlight != 1 {
+		t.Errorf("in flight during the call = %v, want 1", inFlight)
+	}
+
+	registry := prometheus.NewPedanticRegistry()
+	registry.MustRegister(collector)
+
+	expected := `
+# HELP linepay_client_calls_total LINE Pay API calls by operation, returnCode and HTTP status.
+# TYPE linepay_client_calls_total counter
+linepay_client_calls_total{channel="tw",operation="PaymentsCapture",return_code="1150",status_code="200"} 1
+# HELP linepay_client_in_flight_calls LINE Pay API calls in progress.
+# TYPE linepay_client_in_flight_calls gauge
+linepay_client_in_flight_calls{channel="tw",operation="PaymentsCapture"} 0
+# HELP linepay_client_retries_total Retried attempts of LINE Pay API calls.
+# TYPE linepay_client_retries_total counter
+linepay_client_retries_total{channel="tw",operation="PaymentsCapture"} 1
+`
+	if err := testutil.GatherAndCompare(registry, strings.NewReader(expected), MetricCalls, MetricInFlight, MetricRetries); err != nil {
+		t.Error(err)
+	}
+	if n := testutil.CollectAndCount(collector, MetricDuration); n != 1 {
+		t.Errorf("%s series = %d, want 1", MetricDuration, n)
+	}
+}
+
+func TestCollector_Limits(t *testing.T) {
+
+	collector := New()
+
+	var calls int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		fmt.Fprint(w, `{"returnCode":"0000","returnMessage":"Success."}`)
+	}))
+	defer ts.Close()
+
+	client, _ := linepay.NewClient("1001", "secret", nil, &linepay.ClientOpts{
+		APIEndpoint: ts.URL,
+		Retry:       linepay.RetryPolicy{MaxRetries: 1, Backoff: time.Millisecond},
+		Limits:      linepay.LimitPolicy{Default: linepay.Limit{Rate: 100}},
+		Observers:   []linepay.Observer{collector},
+	})
+
+	if _, err := client.PaymentsVoid(context.Background(), 1); err != nil {
+		t.Fatalf("PaymentsVoid() error = %v", err)
+	}
+
+	registry := prometheus.NewPedanticRegistry()
+	registry.MustRegister(collector)
+
+	expected := `
+# HELP linepay_client_rate_limit Rate limit of LINE Pay API calls in calls per second, reduced while LINE Pay throttles.
+# TYPE linepay_client_rate_limit gauge
+linepay_client_rate_limit{operation="PaymentsVoid"} 50
+# HELP linepay_client_throttled_total Rate reductions after LINE Pay throttled the calls.
+# TYPE linepay_client_throttled_total counter
+linepay_client_throttled_total{operation="PaymentsVoid"} 1
+`
+	if err := testutil.GatherAndCompare(registry, strings.NewReader(expected), MetricRateLimit, MetricThrottled); err != nil {
+		t.Error(err)
+	}
+	// the retry waited for the reduced rate
+	if n := testutil.CollectAndCount(collector, MetricLimitWait); n != 1 {
+		t.Errorf("%s series = %d, want 1", MetricLimitWait, n)
+	}
+}
@@ -0,0 +1,397 @@
+// Package qrcode is a dependency free QR Code (ISO/IEC 18004) encoder, for showing payment URLs
+// at kiosks and in terminals. Text is always encoded in byte mode, versions 1 to 40 are supported.
+package qrcode
+
+import (
+	"errors"
+)
+
+// Level of error correction
+type Level int
+
+const (
+	Low      Level = iota // recovers 7% of the code
+	Medium                // 15%
+	Quartile              // 25%
+	High                  // 30%
+)
+
+// ErrTooLong returned when the text does not fit in a version 40 code
+var ErrTooLong = errors.New("qrcode: text too long")
+
+// QRCode is an encoded symbol, `Module(x, y)` tells whether a module is dark
+type QRCode struct {
+	Version int
+	Level   Level
+	Mask    int
+	Size    int
+
+	modules    [][]bool
+	isFunction [][]bool
+}
+
+// Module returns true for a dark module, coordinates outside of the symbol are light
+func (q *QRCode) Module(x, y int) bool {
+	if x < 0 || y < 0 || x >= q.Size || y >= q.Size {
+		return false
+	}
+	return q.modules[y][x]
+}
+
+// Encode encodes the text with the smallest version fitting at the level
+func Encode(text string, level Level) (*QRCode, error) {
+
+	data := []byte(text)
+
+	version := 0
+	for v := 1; v <= 40; v++ {
+		if 4+charCountBits(v)+8*len(data) <= numDataCodewords(v, level)*8 {
+			version = v
+			break
+		}
+	}
+	if version == 0 {
+		return nil, ErrTooLong
+	}
+
+	// byte mode segment, terminator and padding
+	bb := &bitBuffer{}
+	bb.append(0x4, 4)
+	bb.append(len(data), charCountBits(version))
+	for _, b := range data {
+		bb.append(int(b), 8)
+	}
+
+	capacity := numDataCodewords(version, level) * 8
+	terminator := capacity - bb.len()
+	if terminator > 4 {
+		terminator = 4
+	}
+	bb.append(0, terminator)
+	bb.append(0, (8-bb.len()%8)%8)
+	for pad := 0xEC; bb.len() < capacity; pad ^= 0xEC ^ 0x11 {
+		bb.append(pad, 8)
+	}
+
+	q := &QRCode{Version: version, Level: level, Size: version*4 + 17}
+	q.modules = newGrid(q.Size)
+	q.isFunction = newGrid(q.Size)
+
+	q.drawFunctionPatterns()
+	q.drawCodewords(q.addECCAndInterleave(bb.bytes()))
+
+	// pick the mask with the lowest penalty
+	minPenalty := -1
+	for mask := 0; mask < 8; mask++ {
+		q.applyMask(mask)
+		q.drawFormatBits(mask)
+		if p := q.penalty(); minPenalty < 0 || p < minPenalty {
+			q.Mask = mask
+			minPenalty = p
+		}
+		q.applyMask(mask) // XOR again to undo
+	}
+	q.applyMask(q.Mask)
+	q.drawFormatBits(q.Mask)
+
+	return q, nil
+}
+
+func newGrid(size int) [][]bool {
+	g := make([][]bool, size)
+	for i := range g {
+		g[i] = make([]bool, size)
+	}
+	return g
+}
+
+func (q *QRCode) set(x, y int, dark bool) {
+	q.modules[y][x] = dark
+	q.isFunction[y][x] = true
+}
+
+func (q *QRCode) drawFunctionPatterns() {
+
+	for i := 0; i < q.Size; i++ {
+		q.set(6, i, i%2 == 0)
+		q.set(i, 6, i%2 == 0)
+	}
+
+	q.drawFinder(3, 3)
+	q.drawFinder(q.Size-4, 3)
+	q.drawFinder(3, q.Size-4)
+
+	pos := alignmentPositions(q.Version)
+	last := len(pos) - 1
+	for i := range pos {
+		for j := range pos {
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue // overlaps a finder
+			}
+			q.drawAlignment(pos[i], pos[j])
+		}
+	}
+
+	q.drawFormatBits(0) // reserve the area, drawn again with the chosen mask
+	q.drawVersion()
+}
+
+func (q *QRCode) drawFinder(x, y int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			xx, yy := x+dx, y+dy
+			if xx < 0 || yy < 0 || xx >= q.Size || yy >= q.Size {
+				continue
+			}
+			dist := max(abs(dx), abs(dy))
+			q.set(xx, yy, dist != 2 && dist != 4)
+		}
+	}
+}
+
+func (q *QRCode) drawAlignment(x, y int) {
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			q.set(x+dx, y+dy, max(abs(dx), abs(dy)) != 1)
+		}
+	}
+}
+
+func (q *QRCode) drawFormatBits(mask int) {
+
+	data := formatLevelBits[q.Level]<<3 | mask
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = (rem << 1) ^ ((rem >> 9) * 0x537)
+	}
+	bits := (data<<10 | rem) ^ 0x5412
+
+	bit := func(i int) bool { return (bits>>uint(i))&1 != 0 }
+
+	// first copy, around the top left finder
+	for i := 0; i <= 5; i++ {
+		q.set(8, i, bit(i))
+	}
+	q.set(8, 7, bit(6))
+	q.set(8, 8, bit(7))
+	q.set(7, 8, bit(8))
+	for i := 9; i < 15; i++ {
+		q.set(14-i, 8, bit(i))
+	}
+
+	// second copy, split between the top right and bottom left finders
+	for i := 0; i < 8; i++ {
+		q.set(q.Size-1-i, 8, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		q.set(8, q.Size-15+i, bit(i))
+	}
+	q.set(8, q.Size-8, true) // always dark
+}
+
+func (q *QRCode) drawVersion() {
+	if q.Version < 7 {
+		return
+	}
+
+	rem := q.Version
+	for i := 0; i < 12; i++ {
+		rem = (rem << 1) ^ ((rem >> 11) * 0x1F25)
+	}
+	bits := q.Version<<12 | rem
+
+	for i := 0; i < 18; i++ {
+		dark := (bits>>uint(i))&1 != 0
+		a, b := q.Size-11+i%3, i/3
+		q.set(a, b, dark)
+		q.set(b, a, dark)
+	}
+}
+
+// addECCAndInterleave splits the data in blocks, appends the Reed-Solomon codewords of every block and interleaves them
+func (q *QRCode) addECCAndInterleave(data []byte) []byte {
+
+	numBlocks := numECCBlocks[q.Level][q.Version]
+	blockECCLen := eccCodewordsPerBlock[q.Level][q.Version]
+	rawCodewords := numRawDataModules(q.Version) / 8
+	numShortBlocks := numBlocks - rawCodewords%numBlocks
+	shortBlockLen := rawCodewords / numBlocks
+
+	divisor := rsDivisor(blockECCLen)
+	blocks := make([][]byte, numBlocks)
+	k := 0
+	for i := range blocks {
+		datLen := shortBlockLen - blockECCLen
+		if i >= numShortBlocks {
+			datLen++
+		}
+		dat := data[k : k+datLen]
+		k += datLen
+
+		block := append([]byte{}, dat...)
+		if i < numShortBlocks {
+			block = append(block, 0) // placeholder, skipped while interleaving
+		}
+		blocks[i] = append(block, rsRemainder(dat, divisor)...)
+	}
+
+	var result []byte
+	for i := 0; i < len(blocks[0]); i++ {
+		for j, block := range blocks {
+			if i != shortBlockLen-blockECCLen || j >= numShortBlocks {
+				result = append(result, block[i])
+			}
+		}
+	}
+	return result
+}
+
+// drawCodewords places the bits in the zigzag order, two columns at a time from the bottom right
+func (q *QRCode) drawCodewords(data []byte) {
+	i := 0
+	for right := q.Size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5 // skip the vertical timing pattern
+		}
+		for vert := 0; vert < q.Size; vert++ {
+			for j := 0; j < 2; j++ {
+				x := right - j
+				y := vert
+				if (right+1)&2 == 0 {
+					y = q.Size - 1 - vert // upward
+				}
+				if !q.isFunction[y][x] && i < len(data)*8 {
+					q.modules[y][x] = (data[i>>3]>>uint(7-i&7))&1 != 0
+					i++
+				}
+			}
+		}
+	}
+}
+
+func (q *QRCode) applyMask(mask int) {
+	for y := 0; y < q.Size; y++ {
+		for x := 0; x < q.Size; x++ {
+			if q.isFunction[y][x] {
+				continue
+			}
+			var invert bool
+			switch mask {
+			case 0:
+				invert = (x+y)%2 == 0
+			case 1:
+				invert = y%2 == 0
+			case 2:
+				invert = x%3 == 0
+			case 3:
+				invert = (x+y)%3 == 0
+			case 4:
+				invert = (x/3+y/2)%2 == 0
+			case 5:
+				invert = x*y%2+x*y%3 == 0
+			case 6:
+				invert = (x*y%2+x*y%3)%2 == 0
+			case 7:
+				invert = ((x+y)%2+x*y%3)%2 == 0
+			}
+			q.modules[y][x] = q.modules[y][x] != invert
+		}
+	}
+}
+
+// penalty scores the symbol with the four rules of the specification, lower is easier to scan
+func (q *QRCode) penalty() int {
+	const (
+		n1 = 3
+		n2 = 3
+		n3 = 40
+		n4 = 10
+	)
+
+	result := 0
+	line := make([]bool, q.Size)
+	for _, vertical := range []bool{false, true} {
+		for a := 0; a < q.Size; a++ {
+			for b := 0; b < q.Size; b++ {
+				if vertical {
+					line[b] = q.modules[b][a]
+				} else {
+					line[b] = q.modules[a][b]
+				}
+			}
+
+			// runs of 5 or more modules of the same color
+			run := 1
+			for b := 1; b <= q.Size; b++ {
+				if b < q.Size && line[b] == line[b-1] {
+					run++
+					continue
+				}
+				if run >= 5 {
+					result += n1 + run - 5
+				}
+				run = 1
+			}
+
+			// finder like patterns 1:1:3:1:1 with 4 light modules on a side
+			for b := 0; b+11 <= q.Size; b++ {
+				if matches(line[b:b+11], finderLikeLeft) || matches(line[b:b+11], finderLikeRight) {
+					result += n3
+				}
+			}
+		}
+	}
+
+	dark := 0
+	for y := 0; y < q.Size; y++ {
+		for x := 0; x < q.Size; x++ {
+			if q.modules[y][x] {
+				dark++
+			}
+			// 2x2 blocks of the same color
+			if x+1 < q.Size && y+1 < q.Size {
+				c := q.modules[y][x]
+				if c == q.modules[y][x+1] && c == q.modules[y+1][x] && c == q.modules[y+1][x+1] {
+					result += n2
+				}
+			}
+		}
+	}
+
+	// balance of dark and light modules
+	total := q.Size * q.Size
+	k := (abs(dark*20-total*10)+total-1)/total - 1
+	if k > 0 {
+		result += k * n4
+	}
+
+	return result
+}
+
+var (
+	finderLikeLeft  = []bool{false, false, false, false, true, false, true, true, true, false, true}
+	finderLikeRight = []bool{true, false, true, true, true, false, true, false, false, false, false}
+)
+
+func matches(line, pattern []bool) bool {
+	for i := range pattern {
+		if line[i] != pattern[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,187 @@
+package qrcode
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func TestEncode(t *testing.T) {
+
+	tests := []struct {
+		text    string
+		level   Level
+		version int
+	}{
+		{"hello", Low, 1},
+		{strings.Repeat("a", 17), Low, 1},
+		{strings.Repeat("a", 18), Low, 2},
+		{"https://sandbox-web-pay.line.me/web/payment/wait?transactionReserveId=cGhqSnNMZ2pWSjJTbW5Kd3dUbXhSQm9VNEVFcjlxQ1IyazJjVGxaR1FPRVhmMFh4&locale=en", Medium, 8},
+		{strings.Repeat("a", 2953), Low, 40},
+	}
+
+	for _, tt := range tests {
+		q, err := Encode(tt.text, tt.level)
+		if err != nil {
+			t.Fatalf("Encode(%d bytes) error = %v", len(tt.text), err)
+		}
+		if q.Version != tt.version || q.Size != tt.version*4+17 {
+			t.Errorf("Encode(%d bytes) version = %d, want %d", len(tt.text), q.Version, tt.version)
+		}
+
+		// finder pattern corners and the always dark module
+		if !q.Module(0, 0) || !q.Module(q.Size-1, 0) || !q.Module(0, q.Size-1) || q.Module(7, 7) || !q.Module(8, q.Size-8) {
+			t.Errorf("Encode(%d bytes) function patterns not drawn", len(tt.text))
+		}
+	}
+
+	if _, err := Encode(strings.Repeat("a", 2954), Low); err != ErrTooLong {
+		t.Errorf("Encode() too long error = %v", err)
+	}
+}
+
+// known answers, the same matrices are drawn by rsc.io/qr with the mask chosen here
+func TestEncode_KnownAnswer(t *testing.T) {
+
+	tests := []struct {
+		text    string
+		level   Level
+		version int
+		mask    int
+		modules []string
+	}{
+		{"hello", Low, 1, 7, []string{
+			"#######..#.##.#######",
+			"#.....#.##.#..#.....#",
+			"#.###.#.##..#.#.###.#",
+			"#.###.#..#.#..#.###.#",
+			"#.###.#.#...#.#.###.#",
+			"#.....#.#..##.#.....#",
+			"#######.#.#.#.#######",
+			"........#####........",
+			"##.#..##.##...###.##.",
+			".#####.###....#....##",
+			"..##.####.#.##...##.#",
+			"...#.#..#..#.....#.##",
+			"....#.##.##.#.#.#....",
+			"........####...##.#.#",
+			"#######.###..#.#.###.",
+			"#.....#..#####.##....",
+			"#.###.#..#.#..###...#",
+			"#.###.#.#.##...#.####",
+			"#.###.#..##.#...#.#.#",
+			"#.....#.###..##......",
+			"#######.#.###..#.#.#.",
+		}},
+		{"https://pay.line.me", Medium, 2, 1, []string{
+			"#######.##....#...#######",
+			"#.....#..##...#...#.....#",
+			"#.###.#.#.##.#....#.###.#",
+			"#.###.#..#.#####..#.###.#",
+			"#.###.#...#.###.#.#.###.#",
+			"#.....#.#..##.#.#.#.....#",
+			"#######.#.#.#.#.#.#######",
+			".........##...#.#........",
+			"#.#...##.....####..#..#.#",
+			"..####.....###.##.##.#.##",
+			"#.#..#####.##..########.#",
+			".#.#.#.###...#.###...#...",
+			"####..#..##.##..#.#.....#",
+			"..#.#..####.#..#####...##",
+			"##.####.##..####.#...##.#",
+			"..##....##.##.#..#.###...",
+			"###.#.##.....##.#####..#.",
+			"........#.#..##.#...#...#",
+			"#######.##.#....#.#.#...#",
+			"#.....#..#...#.##...#..##",
+			"#.###.#...####.######..##",
+			"#.###.#..#..#.......#.##.",
+			"#.###.#.###.###..#.###.##",
+			"#.....#....##.#.#####....",
+			"#######.###..##.##...#..#",
+		}},
+	}
+
+	for _, tt := range tests {
+		q, err := Encode(tt.text, tt.level)
+		if err != nil {
+			t.Fatalf("Encode(%q) error = %v", tt.text, err)
+		}
+		if q.Version != tt.version || q.Mask != tt.mask {
+			t.Errorf("Encode(%q) version %d mask %d, want version %d mask %d", tt.text, q.Version, q.Mask, tt.version, tt.mask)
+		}
+		for y, row := range tt.modules {
+			var got strings.Builder
+			for x := range row {
+				if q.Module(x, y) {
+					got.WriteByte('#')
+				} else {
+					got.WriteByte('.')
+				}
+			}
+			if got.String() != row {
+				t.Errorf("Encode(%q) row %d = %s, want %s", tt.text, y, got.String(), row)
+			}
+		}
+	}
+}
+
+// both copies of the format information must agree with the level and the chosen mask
+func TestEncode_FormatBits(t *testing.T) {
+
+	q, _ := Encode("01234567", Medium)
+
+	var first, second int
+	for i := 0; i <= 5; i++ {
+		first |= b(q.Module(8, i)) << uint(i)
+	}
+	first |= b(q.Module(8, 7))<<6 | b(q.Module(8, 8))<<7 | b(q.Module(7, 8))<<8
+	for i := 9; i < 15; i++ {
+		first |= b(q.Module(14-i, 8)) << uint(i)
+	}
+	for i := 0; i < 8; i++ {
+		second |= b(q.Module(q.Size-1-i, 8)) << uint(i)
+	}
+	for i := 8; i < 15; i++ {
+		second |= b(q.Module(8, q.Size-15+i)) << uint(i)
+	}
+
+	if first != second {
+		t.Fatalf("format copies differ: %015b %015b", first, second)
+	}
+	if data := (first ^ 0x5412) >> 10; data != formatLevelBits[Medium]<<3|q.Mask {
+		t.Errorf("format data = %05b, want level %d mask %d", data, Medium, q.Mask)
+	}
+}
+
+func b(v bool) int {
+	if v {
+		return 1
+	}
+	return 0
+}
+
+func TestRender(t *testing.T) {
+
+	q, _ := Encode("line://pay/payment/abc", Medium)
+
+	data, err := q.PNG(4, QuietZone)
+	if err != nil {
+		t.Fatalf("PNG() error = %v", err)
+	}
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil || img.Bounds().Dx() != (q.Size+2*QuietZone)*4 {
+		t.Errorf("PNG() decoded %v, %v", img.Bounds(), err)
+	}
+
+	svg := q.SVG(4, QuietZone)
+	if !strings.HasPrefix(svg, "<svg") || !strings.Contains(svg, "M4,4h1v1h-1z") {
+		t.Errorf("SVG() = %s", svg[:80])
+	}
+
+	lines := strings.Split(strings.TrimSuffix(q.Unicode(2, false), "\n"), "\n")
+	if len(lines) != (q.Size+4+1)/2 || len([]rune(lines[0])) != q.Size+4 {
+		t.Errorf("Unicode() got %d lines of %d", len(lines), len([]rune(lines[0])))
+	}
+}
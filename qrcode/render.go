@@ -0,0 +1,90 @@
+package qrcode
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+)
+
+// QuietZone is the light border width in modules recommended by the specification
+const QuietZone = 4
+
+// Image renders the symbol with `scale` pixels per module and a light border of `border` modules
+func (q *QRCode) Image(scale, border int) image.Image {
+	if scale < 1 {
+		scale = 1
+	}
+
+	size := (q.Size + border*2) * scale
+	img := image.NewPaletted(image.Rect(0, 0, size, size), color.Palette{color.White, color.Black})
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			if q.Module(x/scale-border, y/scale-border) {
+				img.SetColorIndex(x, y, 1)
+			}
+		}
+	}
+	return img
+}
+
+// PNG renders the symbol as a PNG image, see Image
+func (q *QRCode) PNG(scale, border int) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	if err := png.Encode(buf, q.Image(scale, border)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// SVG renders the symbol as a SVG document, one unit per module, `scale` sets the width and height in pixels
+func (q *QRCode) SVG(scale, border int) string {
+	if scale < 1 {
+		scale = 1
+	}
+
+	size := q.Size + border*2
+	sb := &strings.Builder{}
+	fmt.Fprintf(sb, `<svg xmlns="http://www.w3.org/2000/svg" version="1.1" viewBox="0 0 %d %d" width="%d" height="%d" stroke="none">`, size, size, size*scale, size*scale)
+	sb.WriteString(`<rect width="100%" height="100%" fill="#FFFFFF"/><path d="`)
+	for y := 0; y < q.Size; y++ {
+		for x := 0; x < q.Size; x++ {
+			if q.modules[y][x] {
+				fmt.Fprintf(sb, "M%d,%dh1v1h-1z", x+border, y+border)
+			}
+		}
+	}
+	sb.WriteString(`" fill="#000000"/></svg>`)
+	return sb.String()
+}
+
+// Unicode renders the symbol with half block characters, two module rows per line.
+// Dark modules are drawn as blocks, which suits light terminals; set `invert` for dark terminals.
+func (q *QRCode) Unicode(border int, invert bool) string {
+	sb := &strings.Builder{}
+	for y := -border; y < q.Size+border; y += 2 {
+		for x := -border; x < q.Size+border; x++ {
+			top, bottom := q.Module(x, y), q.Module(x, y+1)
+			if y+1 >= q.Size+border {
+				bottom = invert // outside of the border, keep the background
+			}
+			if invert {
+				top, bottom = !top, !bottom
+			}
+			switch {
+			case top && bottom:
+				sb.WriteString("█")
+			case top:
+				sb.WriteString("▀")
+			case bottom:
+				sb.WriteString("▄")
+			default:
+				sb.WriteString(" ")
+			}
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
@@ -0,0 +1,391 @@
+package reconcile
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	linepay "github.com/chy168/line-pay-sdk-go"
+)
+
+// Detailer is the part of `linepay.Client` used by the Reconciler
+type Detailer interface {
+	PaymentsDetails(ctx context.Context, request *linepay.PaymentsDetailsRequest) (*linepay.PaymentsDetailsResponse, error)
+}
+
+// Status of a payment in the internal records
+type Status string
+
+const (
+	StatusAuthorized Status = "AUTHORIZED" // LINE Pay `AUTHORIZATION`, waiting for `Capture API`
+	StatusCaptured   Status = "CAPTURED"
+	StatusVoided     Status = "VOIDED"   // LINE Pay `VOIDED_AUTHORIZATION`
+	StatusExpired    Status = "EXPIRED"  // LINE Pay `EXPIRED_AUTHORIZATION`
+	StatusRefunded   Status = "REFUNDED" // fully refunded, a partial refund is StatusCaptured with `Refunded`
+)
+
+// Record is a payment of the internal ledger.
+// `OrderID` required
+// `TransactionID` optional, the payment is looked up by `OrderID` when 0
+// `Refunded` the amount already refunded to the user
+type Record struct {
+	OrderID       string
+	TransactionID linepay.TransactionID
+	Amount        int
+	Currency      string
+	Status        Status
+	Refunded      int
+}
+
+// Iterator reads the internal records, Next returns io.EOF after the last record
+type Iterator interface {
+	Next(ctx context.Context) (*Record, error)
+}
+
+type sliceIterator struct {
+	records []Record
+}
+
+// Records iterates over records already in memory
+func Records(records ...Record) Iterator {
+	return &sliceIterator{records: records}
+}
+
+func (it *sliceIterator) Next(ctx context.Context) (*Record, error) {
+	if len(it.records) == 0 {
+		return nil, io.EOF
+	}
+	r := it.records[0]
+	it.records = it.records[1:]
+	return &r, nil
+}
+
+// DefaultBatchSize is the number of transactions or orders queried by one `PaymentsDetails` call
+const DefaultBatchSize = 100
+
+// `BatchSize` optional, DefaultBatchSize when 0
+type Options struct {
+	BatchSize int
+}
+
+// Reconciler compares the internal records with the payments known by LINE Pay
+type Reconciler struct {
+	details   Detailer
+	batchSize int
+}
+
+// New creates a Reconciler, `opts` may be nil
+func New(details Detailer, opts *Options) *Reconciler {
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	r := &Reconciler{details: details, batchSize: opts.BatchSize}
+	if r.batchSize <= 0 {
+		r.batchSize = DefaultBatchSize
+	}
+	return r
+}
+
+// payment is a LINE Pay payment transaction with its refunds
+type payment struct {
+	info    linepay.PaymentsDetailsInfoResponse
+	refunds map[linepay.TransactionID]int
+}
+
+func (p *payment) amount() (amount int) {
+	for _, pi := range p.info.PayInfo {
+		amount += pi.Amount
+	}
+	return
+}
+
+func (p *payment) refunded() (amount int) {
+	for _, r := range p.refunds {
+		amount += r
+	}
+	return
+}
+
+func (p *payment) status() Status {
+	switch p.info.PayStatus {
+	case "AUTHORIZATION":
+		return StatusAuthorized
+	case "VOIDED_AUTHORIZATION":
+		return StatusVoided
+	case "EXPIRED_AUTHORIZATION":
+		return StatusExpired
+	}
+	if refunded := p.refunded(); refunded > 0 && refunded >= p.amount() {
+		return StatusRefunded
+	}
+	return StatusCaptured
+}
+
+// Run compares every record with LINE Pay. `settled` are transaction ids known on the LINE Pay side,
+// e.g. from the settlement report of the merchant center, those without a record are reported DiscrepancyMissingInRecords.
+// A failed `PaymentsDetails` call stops the run.
+func (r *Reconciler) Run(ctx context.Context, records Iterator, settled ...linepay.TransactionID) (*Report, error) {
+
+	report := &Report{GeneratedAt: time.Now()}
+	seen := map[linepay.TransactionID]bool{}
+
+	var batch []*Record
+	for {
+		record, err := records.Next(ctx)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read records error: %s", err.Error())
+		}
+
+		batch = append(batch, record)
+		if len(batch) < r.batchSize {
+			continue
+		}
+		if err := r.reconcile(ctx, batch, report, seen); err != nil {
+			return nil, err
+		}
+		batch = nil
+	}
+	if err := r.reconcile(ctx, batch, report, seen); err != nil {
+		return nil, err
+	}
+
+	var unseen []linepay.TransactionID
+	for _, id := range settled {
+		if !seen[id] {
+			seen[id] = true
+			unseen = append(unseen, id)
+		}
+	}
+
+	payments, err := r.fetch(ctx, unseen, nil)
+	if err != nil {
+		return nil, err
+	}
+	report.Transactions += len(payments)
+
+	for _, id := range unseen {
+		d := Discrepancy{Kind: DiscrepancyMissingInRecords, TransactionID: id}
+		if p, ok := payments[id]; ok {
+			d.OrderID = p.info.OrderID
+			d.Actual = money(p.amount(), p.info.Currency)
+		}
+		report.Discrepancies = append(report.Discrepancies, d)
+	}
+
+	return report, nil
+}
+
+func (r *Reconciler) reconcile(ctx context.Context, batch []*Record, report *Report, seen map[linepay.TransactionID]bool) error {
+
+	if len(batch) == 0 {
+		return nil
+	}
+
+	var ids []linepay.TransactionID
+	var orders []string
+	for _, record := range batch {
+		if record.TransactionID != 0 {
+			ids = append(ids, record.TransactionID)
+		} else {
+			orders = append(orders, record.OrderID)
+		}
+	}
+
+	payments, err := r.fetch(ctx, ids, orders)
+	if err != nil {
+		return err
+	}
+	report.Transactions += len(payments)
+
+	byOrder := map[string][]*payment{}
+	for _, p := range payments {
+		byOrder[p.info.OrderID] = append(byOrder[p.info.OrderID], p)
+	}
+	for _, ps := range byOrder {
+		sort.Slice(ps, func(i, j int) bool { return ps[i].info.TransactionID < ps[j].info.TransactionID })
+	}
+
+	for _, record := range batch {
+		report.Records++
+
+		var p *payment
+		if record.TransactionID != 0 {
+			p = payments[record.TransactionID]
+		} else {
+			for _, op := range byOrder[record.OrderID] {
+				if !seen[op.info.TransactionID] {
+					p = op
+					break
+				}
+			}
+		}
+
+		if p == nil {
+			report.Discrepancies = append(report.Discrepancies, Discrepancy{
+				Kind:          DiscrepancyMissingInLinePay,
+				OrderID:       record.OrderID,
+				TransactionID: record.TransactionID,
+				Expected:      money(record.Amount, record.Currency),
+			})
+			continue
+		}
+		seen[p.info.TransactionID] = true
+
+		found := compare(record, p)
+		if len(found) == 0 {
+			report.Matched++
+		}
+		report.Discrepancies = append(report.Discrepancies, found...)
+	}
+
+	// another payment of an order looked up by order id, e.g. the user paid twice
+	for _, order := range orders {
+		for _, p := range byOrder[order] {
+			if seen[p.info.TransactionID] {
+				continue
+			}
+			seen[p.info.TransactionID] = true
+			report.Discrepancies = append(report.Discrepancies, Discrepancy{
+				Kind:          DiscrepancyMissingInRecords,
+				OrderID:       order,
+				TransactionID: p.info.TransactionID,
+				Actual:        money(p.amount(), p.info.Currency),
+			})
+		}
+	}
+
+	return nil
+}
+
+func compare(record *Record, p *payment) (found []Discrepancy) {
+
+	d := func(kind DiscrepancyKind, field, expected, actual string) {
+		found = append(found, Discrepancy{
+			Kind:          kind,
+			OrderID:       record.OrderID,
+			TransactionID: p.info.TransactionID,
+			Field:         field,
+			Expected:      expected,
+			Actual:        actual,
+		})
+	}
+
+	if record.Currency != p.info.Currency {
+		d(DiscrepancyAmountMismatch, "currency", record.Currency, p.info.Currency)
+	} else if amount := p.amount(); record.Amount != amount {
+		d(DiscrepancyAmountMismatch, "amount", strconv.Itoa(record.Amount), strconv.Itoa(amount))
+	}
+
+	if status := p.status(); record.Status != status {
+		d(DiscrepancyStatusMismatch, "status", string(record.Status), string(status))
+	}
+
+	// refunds not known by the records, or refunds of the records LINE Pay never made
+	refunded := p.refunded()
+	if refunded > record.Refunded {
+		d(DiscrepancyUnaccountedRefund, "refunded", strconv.Itoa(record.Refunded), strconv.Itoa(refunded))
+	} else if refunded < record.Refunded {
+		d(DiscrepancyAmountMismatch, "refunded", strconv.Itoa(record.Refunded), strconv.Itoa(refunded))
+	}
+
+	return
+}
+
+// fetch gets the payments of `ids` and `orders` by batches, refund transactions are attached to their payment
+func (r *Reconciler) fetch(ctx context.Context, ids []linepay.TransactionID, orders []string) (map[linepay.TransactionID]*payment, error) {
+
+	payments := map[linepay.TransactionID]*payment{}
+	refunds := map[linepay.TransactionID]map[linepay.TransactionID]int{}
+
+	add := func(info []linepay.PaymentsDetailsInfoResponse) {
+		for _, i := range info {
+			if i.OriginalTransactionID != 0 {
+				// a refund transaction
+				amount := 0
+				for _, pi := range i.PayInfo {
+					amount += abs(pi.Amount)
+				}
+				if refunds[i.OriginalTransactionID] == nil {
+					refunds[i.OriginalTransactionID] = map[linepay.TransactionID]int{}
+				}
+				refunds[i.OriginalTransactionID][i.TransactionID] = amount
+				continue
+			}
+
+			p := &payment{info: i, refunds: map[linepay.TransactionID]int{}}
+			for _, rl := range i.RefundList {
+				p.refunds[rl.RefundTransactionID] = abs(rl.RefundAmount)
+			}
+			payments[i.TransactionID] = p
+		}
+	}
+
+	for start := 0; start < len(ids); start += r.batchSize {
+		end := min(start+r.batchSize, len(ids))
+		info, err := r.query(ctx, &linepay.PaymentsDetailsRequest{TransactionIDs: ids[start:end]})
+		if err != nil {
+			return nil, err
+		}
+		add(info)
+	}
+
+	for start := 0; start < len(orders); start += r.batchSize {
+		end := min(start+r.batchSize, len(orders))
+		info, err := r.query(ctx, &linepay.PaymentsDetailsRequest{OrderIDs: orders[start:end]})
+		if err != nil {
+			return nil, err
+		}
+		add(info)
+	}
+
+	for id, rs := range refunds {
+		if p, ok := payments[id]; ok {
+			for refundID, amount := range rs {
+				p.refunds[refundID] = amount
+			}
+		}
+	}
+
+	return payments, nil
+}
+
+func (r *Reconciler) query(ctx context.Context, request *linepay.PaymentsDetailsRequest) ([]linepay.PaymentsDetailsInfoResponse, error) {
+
+	res, err := r.details.PaymentsDetails(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("PaymentsDetails error: %s", err.Error())
+	}
+
+	switch res.ReturnCode {
+	case linepay.ApiReturnCodeSuccess:
+		return res.Info, nil
+	case linepay.ApiReturnCodeTransactionNotFound:
+		return nil, nil
+	}
+	return nil, fmt.Errorf("PaymentsDetails failed: %s %s", res.ReturnCode, res.ReturnMessage)
+}
+
+func money(amount int, currency string) string {
+	return strconv.Itoa(amount) + " " + currency
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,141 @@
+package reconcile
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	linepay "github.com/chy168/line-pay-sdk-go"
+)
+
+type fakeDetailer struct {
+	info     []linepay.PaymentsDetailsInfoResponse
+	requests []linepay.PaymentsDetailsRequest
+}
+
+// PaymentsDetails returns the payments of the transaction ids and their refunds, or the transactions of the orders
+func (f *fakeDetailer) PaymentsDetails(ctx context.Context, request *linepay.PaymentsDetailsRequest) (*linepay.PaymentsDetailsResponse, error) {
+	f.requests = append(f.requests, *request)
+
+	res := &linepay.PaymentsDetailsResponse{ReturnCode: linepay.ApiReturnCodeSuccess}
+	for _, i := range f.info {
+		for _, id := range request.TransactionIDs {
+			if i.TransactionID == id || i.OriginalTransactionID == id {
+				res.Info = append(res.Info, i)
+			}
+		}
+		for _, order := range request.OrderIDs {
+			if i.OrderID == order {
+				res.Info = append(res.Info, i)
+			}
+		}
+	}
+	if len(res.Info) == 0 {
+		res.ReturnCode = linepay.ApiReturnCodeTransactionNotFound
+	}
+	return res, nil
+}
+
+func paid(id linepay.TransactionID, order string, amount int, payStatus string) linepay.PaymentsDetailsInfoResponse {
+	return linepay.PaymentsDetailsInfoResponse{
+		TransactionID:   id,
+		OrderID:         order,
+		TransactionType: "PAYMENT",
+		PayStatus:       payStatus,
+		Currency:        "TWD",
+		PayInfo:         []linepay.PaymentsDetailsInfoPayInfoResponse{{Method: "CREDIT_CARD", Amount: amount}},
+	}
+}
+
+func TestReconciler_Run(t *testing.T) {
+
+	refundedInList := paid(4, "o4", 400, "")
+	refundedInList.RefundList = []linepay.PaymentsDetailsInfoRefundListResponse{{RefundTransactionID: 41, TransactionType: "PARTIAL_REFUND", RefundAmount: -100}}
+
+	refund := paid(51, "o5", -500, "")
+	refund.TransactionType = "PAYMENT_REFUND"
+	refund.OriginalTransactionID = 5
+
+	details := &fakeDetailer{info: []linepay.PaymentsDetailsInfoResponse{
+		paid(1, "o1", 100, ""),
+		paid(2, "o2", 250, ""),
+		paid(3, "o3", 300, "VOIDED_AUTHORIZATION"),
+		refundedInList,
+		paid(5, "o5", 500, ""),
+		refund,
+		paid(7, "o7", 700, "AUTHORIZATION"),
+		paid(8, "o7", 700, "AUTHORIZATION"), // paid twice
+		paid(9, "o9", 900, ""),
+	}}
+
+	records := Records(
+		Record{OrderID: "o1", TransactionID: 1, Amount: 100, Currency: "TWD", Status: StatusCaptured},
+		Record{OrderID: "o2", TransactionID: 2, Amount: 200, Currency: "TWD", Status: StatusCaptured},
+		Record{OrderID: "o3", TransactionID: 3, Amount: 300, Currency: "TWD", Status: StatusCaptured},
+		Record{OrderID: "o4", TransactionID: 4, Amount: 400, Currency: "TWD", Status: StatusCaptured},
+		Record{OrderID: "o5", Amount: 500, Currency: "TWD", Status: StatusRefunded, Refunded: 500},
+		Record{OrderID: "o6", Amount: 600, Currency: "TWD", Status: StatusCaptured},
+		Record{OrderID: "o7", Amount: 700, Currency: "TWD", Status: StatusAuthorized},
+	)
+
+	report, err := New(details, &Options{BatchSize: 3}).Run(context.Background(), records, 1, 9)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	want := []Discrepancy{
+		{Kind: DiscrepancyAmountMismatch, OrderID: "o2", TransactionID: 2, Field: "amount", Expected: "200", Actual: "250"},
+		{Kind: DiscrepancyStatusMismatch, OrderID: "o3", TransactionID: 3, Field: "status", Expected: "CAPTURED", Actual: "VOIDED"},
+		{Kind: DiscrepancyUnaccountedRefund, OrderID: "o4", TransactionID: 4, Field: "refunded", Expected: "0", Actual: "100"},
+		{Kind: DiscrepancyMissingInLinePay, OrderID: "o6", Expected: "600 TWD"},
+		{Kind: DiscrepancyMissingInRecords, OrderID: "o7", TransactionID: 8, Actual: "700 TWD"},
+		{Kind: DiscrepancyMissingInRecords, OrderID: "o9", TransactionID: 9, Actual: "900 TWD"},
+	}
+	if len(report.Discrepancies) != len(want) {
+		t.Fatalf("Discrepancies = %+v", report.Discrepancies)
+	}
+	for i := range want {
+		if report.Discrepancies[i] != want[i] {
+			t.Errorf("Discrepancies[%d] = %+v, want %+v", i, report.Discrepancies[i], want[i])
+		}
+	}
+
+	if report.Records != 7 || report.Matched != 3 || report.Transactions != 8 || report.Count(DiscrepancyMissingInRecords) != 2 {
+		t.Errorf("Report = %d records, %d matched, %d transactions", report.Records, report.Matched, report.Transactions)
+	}
+
+	// 2 record batches by transaction ids and orders, then the settled transactions
+	if len(details.requests) != 5 {
+		t.Errorf("PaymentsDetails called %d times: %+v", len(details.requests), details.requests)
+	}
+}
+
+func TestReport_Write(t *testing.T) {
+
+	report := &Report{Records: 1, Discrepancies: []Discrepancy{
+		{Kind: DiscrepancyAmountMismatch, OrderID: "o,1", TransactionID: 2019049910005496810, Field: "amount", Expected: "200", Actual: "250"},
+		{Kind: DiscrepancyMissingInLinePay, OrderID: "o2", Expected: "600 TWD"},
+	}}
+
+	var b bytes.Buffer
+	if err := report.WriteCSV(&b); err != nil {
+		t.Fatalf("WriteCSV() error = %v", err)
+	}
+	want := "kind,orderId,transactionId,field,expected,actual\n" +
+		"AMOUNT_MISMATCH,\"o,1\",2019049910005496810,amount,200,250\n" +
+		"MISSING_IN_LINE_PAY,o2,,,600 TWD,\n"
+	if b.String() != want {
+		t.Errorf("WriteCSV() = %s", b.String())
+	}
+
+	b.Reset()
+	if err := report.WriteJSON(&b); err != nil {
+		t.Fatalf("WriteJSON() error = %v", err)
+	}
+	got := Report{}
+	if err := json.Unmarshal(b.Bytes(), &got); err != nil || got.Discrepancies[0] != report.Discrepancies[0] || strings.Contains(b.String(), `"transactionId": 0`) {
+		t.Errorf("WriteJSON() = %s, %v", b.String(), err)
+	}
+}
@@ -0,0 +1,76 @@
+package reconcile
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"time"
+
+	linepay "github.com/chy168/line-pay-sdk-go"
+)
+
+// DiscrepancyKind is the category of a Discrepancy
+type DiscrepancyKind string
+
+const (
+	DiscrepancyMissingInLinePay  DiscrepancyKind = "MISSING_IN_LINE_PAY" // a record without LINE Pay payment
+	DiscrepancyMissingInRecords  DiscrepancyKind = "MISSING_IN_RECORDS"  // a LINE Pay payment without record
+	DiscrepancyAmountMismatch    DiscrepancyKind = "AMOUNT_MISMATCH"     // `Field` amount, currency or refunded
+	DiscrepancyStatusMismatch    DiscrepancyKind = "STATUS_MISMATCH"
+	DiscrepancyUnaccountedRefund DiscrepancyKind = "UNACCOUNTED_REFUND" // LINE Pay refunded more than the record
+)
+
+// Discrepancy between a record and LINE Pay, `Expected` is the value of the record and `Actual` the value of LINE Pay
+type Discrepancy struct {
+	Kind          DiscrepancyKind       `json:"kind"`
+	OrderID       string                `json:"orderId,omitempty"`
+	TransactionID linepay.TransactionID `json:"transactionId,omitempty"`
+	Field         string                `json:"field,omitempty"`
+	Expected      string                `json:"expected,omitempty"`
+	Actual        string                `json:"actual,omitempty"`
+}
+
+// Report of a reconciliation run.
+// `Records` internal records compared, `Transactions` LINE Pay payments fetched, `Matched` records without discrepancy
+type Report struct {
+	GeneratedAt   time.Time     `json:"generatedAt"`
+	Records       int           `json:"records"`
+	Transactions  int           `json:"transactions"`
+	Matched       int           `json:"matched"`
+	Discrepancies []Discrepancy `json:"discrepancies"`
+}
+
+// Count returns the number of discrepancies of `kind`
+func (report *Report) Count(kind DiscrepancyKind) (n int) {
+	for _, d := range report.Discrepancies {
+		if d.Kind == kind {
+			n++
+		}
+	}
+	return
+}
+
+// WriteJSON writes the whole report as indented JSON
+func (report *Report) WriteJSON(w io.Writer) error {
+	e := json.NewEncoder(w)
+	e.SetIndent("", "  ")
+	return e.Encode(report)
+}
+
+// WriteCSV writes one line per discrepancy, with a header line
+func (report *Report) WriteCSV(w io.Writer) error {
+
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"kind", "orderId", "transactionId", "field", "expected", "actual"})
+
+	for _, d := range report.Discrepancies {
+		id := ""
+		if d.TransactionID != 0 {
+			id = d.TransactionID.String()
+		}
+		cw.Write([]string{string(d.Kind), d.OrderID, id, d.Field, d.Expected, d.Actual})
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,166 @@
+package linepay
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrChannelNotFound returned by Registry when no channel matches
+var ErrChannelNotFound = errors.New("linepay: channel not found")
+
+// ChannelConfig is one LINE Pay channel of a Registry
+// `Key` required, the merchant key calls are routed by (e.g. "tw", "jp-brand-a")
+// `Currencies` the currencies routed to this channel by `ForCurrency`, ISO 4217
+// `BranchIDs` the `PaymentsOptionsExtraRequest.BranchID` routed to this channel by `ForBranch`
+// `APIVersion` APIVersion3 by default, APIVersion2 for a channel still on the legacy API
+type ChannelConfig struct {
+	Key               string   `json:"key"`
+	ChannelID         string   `json:"channelId"`
+	ChannelSecret     string   `json:"channelSecret"`
+	ProductionEnabled bool     `json:"productionEnabled"`
+	APIVersion        int      `json:"apiVersion,omitempty"`
+	Currencies        []string `json:"currencies,omitempty"`
+	BranchIDs         []string `json:"branchIds,omitempty"`
+}
+
+// Registry holds a Client of every channel and routes calls to them
+type Registry struct {
+	mu         sync.RWMutex
+	clients    map[string]*Client
+	byCurrency map[string]string
+	byBranch   map[string]string
+	opts       ClientOpts
+}
+
+// NewRegistry creates a client of every channel, `opts` (may be nil) is shared by the clients except `ProductionEnabled` and `APIVersion`.
+func NewRegistry(channels []ChannelConfig, opts *ClientOpts) (*Registry, error) {
+	r := &Registry{
+		clients:    map[string]*Client{},
+		byCurrency: map[string]string{},
+		byBranch:   map[string]string{},
+	}
+	if opts != nil {
+		r.opts = *opts
+	}
+
+	for _, ch := range channels {
+		if err := r.Add(ch); err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
+// Add registers a channel, the key, currencies and branch ids must not be used by another channel.
+func (r *Registry) Add(ch ChannelConfig) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if ch.Key == "" {
+		return errors.New("linepay: channel key is required")
+	}
+	if _, ok := r.clients[ch.Key]; ok {
+		return fmt.Errorf("linepay: duplicated channel key '%s'", ch.Key)
+	}
+	for _, c := range ch.Currencies {
+		if k, ok := r.byCurrency[c]; ok {
+			return fmt.Errorf("linepay: currency '%s' of channel '%s' already routed to '%s'", c, ch.Key, k)
+		}
+	}
+	for _, b := range ch.BranchIDs {
+		if k, ok := r.byBranch[b]; ok {
+			return fmt.Errorf("linepay: branch '%s' of channel '%s' already routed to '%s'", b, ch.Key, k)
+		}
+	}
+
+	opts := r.opts
+	opts.ProductionEnabled = ch.ProductionEnabled
+	opts.APIVersion = ch.APIVersion
+
+	client, err := NewClient(ch.ChannelID, ch.ChannelSecret, nil, &opts)
+	if err != nil {
+		return fmt.Errorf("linepay: channel '%s': %s", ch.Key, err.Error())
+	}
+
+	r.clients[ch.Key] = client
+	for _, c := range ch.Currencies {
+		r.byCurrency[c] = ch.Key
+	}
+	for _, b := range ch.BranchIDs {
+		r.byBranch[b] = ch.Key
+	}
+
+	return nil
+}
+
+// Keys returns the sorted keys of the registered channels
+func (r *Registry) Keys() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	keys := make([]string, 0, len(r.clients))
+	for k := range r.clients {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Client returns the client of the merchant key
+func (r *Registry) Client(key string) (*Client, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	client, ok := r.clients[key]
+	if !ok {
+		return nil, ErrChannelNotFound
+	}
+	return client, nil
+}
+
+// ForCurrency returns the client the currency is routed to
+func (r *Registry) ForCurrency(currency string) (*Client, error) {
+	r.mu.RLock()
+	key, ok := r.byCurrency[currency]
+	r.mu.RUnlock()
+
+	if !ok {
+		return nil, ErrChannelNotFound
+	}
+	return r.Client(key)
+}
+
+// ForBranch returns the client the branch id is routed to
+func (r *Registry) ForBranch(branchID string) (*Client, error) {
+	r.mu.RLock()
+	key, ok := r.byBranch[branchID]
+	r.mu.RUnlock()
+
+	if !ok {
+		return nil, ErrChannelNotFound
+	}
+	return r.Client(key)
+}
+
+// ForRequest routes a payment request by `Options.Extra.BranchID` first, then by `Currency`
+func (r *Registry) ForRequest(request *PaymentsRequest) (*Client, error) {
+	if branchID := request.Options.Extra.BranchID; branchID != "" {
+		if client, err := r.ForBranch(branchID); err == nil {
+			return client, nil
+		}
+	}
+	return r.ForCurrency(request.Currency)
+}
+
+// RotateSecret replaces the secret of the channel, see `Client.RotateSecret`
+func (r *Registry) RotateSecret(key, channelSecret string, grace time.Duration) error {
+	client, err := r.Client(key)
+	if err != nil {
+		return err
+	}
+	return client.RotateSecret(channelSecret, grace)
+}
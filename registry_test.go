@@ -0,0 +1,89 @@
+package linepay
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestRegistry_Route(t *testing.T) {
+
+	r, err := NewRegistry([]ChannelConfig{
+		{Key: "tw", ChannelID: "1001", ChannelSecret: "s1", Currencies: []string{"TWD"}},
+		{Key: "jp", ChannelID: "1002", ChannelSecret: "s2", Currencies: []string{"JPY"}, BranchIDs: []string{"tokyo-1"}},
+	}, nil)
+	if err != nil {
+		t.Fatalf("NewRegistry() error = %v", err)
+	}
+
+	client, err := r.ForCurrency("TWD")
+	if err != nil || client.ChannelID() != "1001" {
+		t.Errorf("ForCurrency(TWD) = %v, %v", client, err)
+	}
+
+	req := &PaymentsRequest{Currency: "TWD"}
+	req.Options.Extra.BranchID = "tokyo-1"
+	if client, err = r.ForRequest(req); err != nil || client.ChannelID() != "1002" {
+		t.Errorf("ForRequest() by branch = %v, %v", client, err)
+	}
+
+	if _, err = r.Client("kr"); err != ErrChannelNotFound {
+		t.Errorf("Client(kr) error = %v", err)
+	}
+
+	if err = r.Add(ChannelConfig{Key: "tw2", ChannelID: "1003", ChannelSecret: "s3", Currencies: []string{"TWD"}}); err == nil {
+		t.Error("Add() with a routed currency should fail")
+	}
+}
+
+func TestClient_RotateSecret(t *testing.T) {
+
+	accepted := "old-secret"
+	var calls int
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		body, _ := ioutil.ReadAll(r.Body)
+		nonce := r.Header.Get("X-LINE-Authorization-Nonce")
+		if r.Header.Get("X-LINE-Authorization") != calculate(accepted, accepted+r.URL.Path+string(body)+nonce) {
+			fmt.Fprint(w, `{"returnCode":"1106","returnMessage":"Header information error."}`)
+			return
+		}
+		fmt.Fprint(w, `{"returnCode":"0000","returnMessage":"Success."}`)
+	}))
+	defer ts.Close()
+
+	r, _ := NewRegistry([]ChannelConfig{{Key: "tw", ChannelID: "1001", ChannelSecret: "old-secret"}}, nil)
+	client, _ := r.Client("tw")
+	client.apiEndpoint, _ = url.ParseRequestURI(ts.URL)
+
+	if err := r.RotateSecret("tw", "new-secret", time.Minute); err != nil {
+		t.Fatal(err)
+	}
+
+	// LINE Pay still has the old secret
+	res, err := client.PaymentsCapture(context.Background(), 1, &PaymentsCaptureRequest{Amount: 1, Currency: "TWD"})
+	if err != nil || res.ReturnCode != ApiReturnCodeSuccess || calls != 2 {
+		t.Fatalf("during cutover got %+v, %v after %d calls", res, err, calls)
+	}
+
+	// LINE Pay switched to the new secret
+	accepted, calls = "new-secret", 0
+	res, err = client.PaymentsCapture(context.Background(), 1, &PaymentsCaptureRequest{Amount: 1, Currency: "TWD"})
+	if err != nil || res.ReturnCode != ApiReturnCodeSuccess || calls != 1 {
+		t.Fatalf("after cutover got %+v, %v after %d calls", res, err, calls)
+	}
+
+	// grace is over, the old secret is not tried anymore
+	client.secrets.previousUntil = time.Now()
+	accepted, calls = "old-secret", 0
+	res, _ = client.PaymentsCapture(context.Background(), 1, &PaymentsCaptureRequest{Amount: 1, Currency: "TWD"})
+	if res.ReturnCode != ApiReturnCodeHeaderError || calls != 1 {
+		t.Fatalf("after grace got %+v after %d calls", res, calls)
+	}
+}
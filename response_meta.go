@@ -0,0 +1,138 @@
+package linepay
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ResponseMeta is attached to every response as `Meta`, the evidence of what LINE Pay answered.
+// `Body` is the raw response body, it holds the regKeys and payment access tokens the response structs redact: do not log it.
+// `Latency` is the duration of the call, retries included, until the body was read.
+// `Extensions` are the JSON fields the SDK does not know by path, e.g. `info.payInfo[0].point`, so new LINE Pay
+// fields are readable before an SDK release.
+// `Replayed` is set when the response is the one saved for the idempotency key of the call, see WithIdempotencyKey.
+type ResponseMeta struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+	Latency    time.Duration
+	Extensions map[string]json.RawMessage
+	Replayed   bool
+}
+
+func newResponseMeta(res *http.Response, body []byte, start time.Time) *ResponseMeta {
+	return &ResponseMeta{
+		StatusCode: res.StatusCode,
+		Header:     res.Header,
+		Body:       body,
+		Latency:    time.Since(start),
+	}
+}
+
+// Extension decodes the unknown field at `path` into `v`, it returns false when the response has no such field
+func (meta *ResponseMeta) Extension(path string, v interface{}) (bool, error) {
+	if meta == nil {
+		return false, nil
+	}
+	raw, ok := meta.Extensions[path]
+	if !ok {
+		return false, nil
+	}
+	return true, json.Unmarshal(raw, v)
+}
+
+var jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// collectExtensions fills `Extensions` with the fields of `Body` without a field in `response`
+func (meta *ResponseMeta) collectExtensions(response interface{}) {
+
+	var v interface{}
+	d := json.NewDecoder(bytes.NewReader(meta.Body))
+	d.UseNumber()
+	if err := d.Decode(&v); err != nil {
+		return
+	}
+
+	collectUnknown(v, reflect.TypeOf(response), "", func(path string, value interface{}) {
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return
+		}
+		if meta.Extensions == nil {
+			meta.Extensions = map[string]json.RawMessage{}
+		}
+		meta.Extensions[path] = raw
+	})
+}
+
+func collectUnknown(v interface{}, t reflect.Type, path string, unknown func(path string, value interface{})) {
+
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if reflect.PtrTo(t).Implements(jsonUnmarshalerType) {
+		return
+	}
+
+	switch v := v.(type) {
+	case map[string]interface{}:
+		if t.Kind() != reflect.Struct {
+			return
+		}
+		for key, value := range v {
+			field, ok := jsonField(t, key)
+			if !ok {
+				unknown(join(path, key), value)
+				continue
+			}
+			collectUnknown(value, field.Type, join(path, key), unknown)
+		}
+	case []interface{}:
+		if t.Kind() != reflect.Slice && t.Kind() != reflect.Array {
+			return
+		}
+		for i, value := range v {
+			collectUnknown(value, t.Elem(), path+"["+strconv.Itoa(i)+"]", unknown)
+		}
+	}
+}
+
+// jsonField finds the field decoded from `key` like encoding/json, case insensitive and through embedded structs
+func jsonField(t reflect.Type, key string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			if ef, ok := jsonField(f.Type, key); ok {
+				return ef, true
+			}
+			continue
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		if strings.EqualFold(name, key) {
+			return f, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+func join(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
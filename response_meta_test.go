@@ -0,0 +1,52 @@
+package linepay
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestResponseMeta(t *testing.T) {
+
+	body := `{"returnCode":"0000","returnMessage":"Success.","traceId":"t-1","info":{"transactionId":"2019049910005496810","orderId":"order-1",` +
+		`"payInfo":[{"method":"POINT","amount":100,"point":{"used":100,"balance":20}},{"method":"CREDIT_CARD","amount":50}]}}`
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-1")
+		fmt.Fprint(w, body)
+	}))
+	defer ts.Close()
+
+	client, _ := NewClient("1001", "secret", nil, &ClientOpts{APIEndpoint: ts.URL})
+
+	res, err := client.PaymentsConfirm(context.Background(), 2019049910005496810, &PaymentsConfirmRequest{Amount: 150, Currency: "TWD"})
+	if err != nil {
+		t.Fatalf("PaymentsConfirm() error = %v", err)
+	}
+
+	meta := res.Meta
+	if meta.StatusCode != http.StatusOK || meta.Header.Get("X-Request-Id") != "req-1" || string(meta.Body) != body || meta.Latency <= 0 {
+		t.Errorf("Meta = %+v", meta)
+	}
+
+	var paths []string
+	for path := range meta.Extensions {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	if strings.Join(paths, ",") != "info.payInfo[0].point,traceId" {
+		t.Errorf("Extensions = %v", paths)
+	}
+
+	point := struct{ Used, Balance int }{}
+	if ok, err := meta.Extension("info.payInfo[0].point", &point); !ok || err != nil || point.Balance != 20 {
+		t.Errorf("Extension(point) = %v, %v, %+v", ok, err, point)
+	}
+	if ok, _ := meta.Extension("info.shipping", &point); ok {
+		t.Errorf("Extension(info.shipping) found")
+	}
+}
@@ -0,0 +1,86 @@
+package linepay
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy of the Client, the zero value disables retries.
+// GET calls are retried on network errors, 429 and 5xx responses. POST calls move money and are only retried on
+// 429 and 503, when LINE Pay did not process the request.
+// `Backoff` is the delay before the first retry, doubled for every retry up to `MaxBackoff` (when set).
+type RetryPolicy struct {
+	MaxRetries int
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+}
+
+func (p RetryPolicy) delay(retry int) time.Duration {
+	d := p.Backoff
+	for i := 1; i < retry; i++ {
+		d *= 2
+		if p.MaxBackoff > 0 && d >= p.MaxBackoff {
+			return p.MaxBackoff
+		}
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		return p.MaxBackoff
+	}
+	return d
+}
+
+func (p RetryPolicy) retryable(class RetryClass, method string, res *http.Response, err error) bool {
+	switch class {
+	case RetryNever:
+		return false
+	case RetryIdempotent:
+		method = http.MethodGet
+	}
+
+	if err != nil {
+		return method == http.MethodGet
+	}
+
+	switch res.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	}
+	return method == http.MethodGet && res.StatusCode >= 500
+}
+
+// doRetry sends the request built by `build` and retries it according to the policy, every attempt waits for the
+// rate of `lim` and is signed again
+func (client *Client) doRetry(ctx context.Context, class RetryClass, lim *limiter, build func() (*http.Request, error)) (res *http.Response, err error) {
+
+	for retry := 0; ; retry++ {
+		if err = lim.wait(ctx); err != nil {
+			return nil, err
+		}
+
+		req, berr := build()
+		if berr != nil {
+			return nil, berr
+		}
+
+		client.observeAttempt(ctx, req, retry)
+
+		res, err = client.roundTrip(ctx, req)
+		lim.response(res)
+		if retry >= client.retry.MaxRetries || !client.retry.retryable(class, req.Method, res, err) {
+			return
+		}
+
+		if res != nil {
+			res.Body.Close()
+		}
+
+		t := time.NewTimer(client.retry.delay(retry + 1))
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return nil, ctx.Err()
+		case <-t.C:
+		}
+	}
+}
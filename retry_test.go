@@ -0,0 +1,40 @@
+package linepay
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClient_Retry(t *testing.T) {
+
+	var calls int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		fmt.Fprint(w, `{"returnCode":"0000","returnMessage":"Success."}`)
+	}))
+	defer ts.Close()
+
+	client, _ := NewClient("1001", "secret", nil, &ClientOpts{
+		APIEndpoint: ts.URL,
+		Retry:       RetryPolicy{MaxRetries: 2, Backoff: time.Millisecond},
+	})
+
+	res, err := client.PaymentsDetails(context.Background(), &PaymentsDetailsRequest{OrderIDs: []string{"o1"}})
+	if err != nil || res.ReturnCode != ApiReturnCodeSuccess || calls != 3 {
+		t.Fatalf("GET got %+v, %v after %d calls", res, err, calls)
+	}
+
+	// a POST is not retried on 502, LINE Pay may have processed it
+	calls = 0
+	if _, err = client.PaymentsCapture(context.Background(), 1, &PaymentsCaptureRequest{}); err == nil || calls != 1 {
+		t.Fatalf("POST got %v after %d calls", err, calls)
+	}
+}
@@ -0,0 +1,135 @@
+package salesreport
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"html/template"
+	"io"
+	"sort"
+	"strconv"
+)
+
+// WriteJSON writes the whole report as indented JSON
+func (report *Report) WriteJSON(w io.Writer) error {
+	e := json.NewEncoder(w)
+	e.SetIndent("", "  ")
+	return e.Encode(report)
+}
+
+// WriteCSV writes a line per day and currency, followed by a line per branch of the day.
+// The range totals are the lines without day. Methods are `method:<name>` columns.
+func (report *Report) WriteCSV(w io.Writer) error {
+
+	methods := report.methods()
+
+	header := []string{"day", "currency", "branchId", "branchName", "payments", "refundCount", "gross", "refunds", "net", "refundRatio"}
+	for _, m := range methods {
+		header = append(header, "method:"+m)
+	}
+
+	cw := csv.NewWriter(w)
+	cw.Write(header)
+
+	line := func(s *Summary, b *Branch, t Totals) {
+		record := []string{s.Day, s.Currency, "", "",
+			strconv.Itoa(t.Payments), strconv.Itoa(t.RefundCount), strconv.Itoa(t.Gross), strconv.Itoa(t.Refunds), strconv.Itoa(t.Net),
+			strconv.FormatFloat(t.RefundRatio, 'f', 4, 64)}
+		if b != nil {
+			record[2], record[3] = b.ID, b.Name
+		}
+		for _, m := range methods {
+			if b != nil {
+				record = append(record, "")
+			} else {
+				record = append(record, strconv.Itoa(s.Methods[m]))
+			}
+		}
+		cw.Write(record)
+	}
+
+	for _, summaries := range [][]*Summary{report.Days, report.Currencies} {
+		for _, s := range summaries {
+			line(s, nil, s.Totals)
+			for _, b := range s.Branches {
+				line(s, b, b.Totals)
+			}
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// methods returns every payment method of the report, sorted
+func (report *Report) methods() []string {
+	set := map[string]bool{}
+	for _, s := range report.Currencies {
+		for m := range s.Methods {
+			set[m] = true
+		}
+	}
+
+	var methods []string
+	for m := range set {
+		methods = append(methods, m)
+	}
+	sort.Strings(methods)
+	return methods
+}
+
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"percent": func(ratio float64) string { return strconv.FormatFloat(ratio*100, 'f', 2, 64) + "%" },
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>LINE Pay sales {{.Report.From.Format "2006-01-02"}} - {{.Report.To.Format "2006-01-02"}}</title>
+<style>
+body { font-family: sans-serif; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: right; }
+th:first-child, td:first-child { text-align: left; }
+tr.branch td { color: #666; }
+</style>
+</head>
+<body>
+<h1>LINE Pay sales {{.Report.From.Format "2006-01-02"}} - {{.Report.To.Format "2006-01-02"}}</h1>
+{{define "table"}}
+<table>
+<tr><th>{{.Title}}</th><th>Currency</th><th>Payments</th><th>Refunds</th><th>Gross</th><th>Refunded</th><th>Net</th><th>Refund ratio</th>{{range .Methods}}<th>{{.}}</th>{{end}}</tr>
+{{- $methods := .Methods}}
+{{- range .Summaries}}
+<tr><td>{{if .Day}}{{.Day}}{{else}}Total{{end}}</td><td>{{.Currency}}</td><td>{{.Payments}}</td><td>{{.RefundCount}}</td><td>{{.Gross}}</td><td>{{.Refunds}}</td><td>{{.Net}}</td><td>{{percent .RefundRatio}}</td>{{$s := .}}{{range $methods}}<td>{{index $s.Methods .}}</td>{{end}}</tr>
+{{- range .Branches}}
+<tr class="branch"><td>{{if or .ID .Name}}{{.ID}} {{.Name}}{{else}}(no branch){{end}}</td><td></td><td>{{.Payments}}</td><td>{{.RefundCount}}</td><td>{{.Gross}}</td><td>{{.Refunds}}</td><td>{{.Net}}</td><td>{{percent .RefundRatio}}</td>{{range $methods}}<td></td>{{end}}</tr>
+{{- end}}
+{{- end}}
+</table>
+{{end}}
+<h2>Totals</h2>
+{{template "table" (.Table "Range" .Report.Currencies)}}
+<h2>Days</h2>
+{{template "table" (.Table "Day" .Report.Days)}}
+</body>
+</html>
+`))
+
+type htmlData struct {
+	Report  *Report
+	methods []string
+}
+
+type htmlTable struct {
+	Title     string
+	Methods   []string
+	Summaries []*Summary
+}
+
+func (d htmlData) Table(title string, summaries []*Summary) htmlTable {
+	return htmlTable{Title: title, Methods: d.methods, Summaries: summaries}
+}
+
+// WriteHTML writes a standalone HTML page of the report
+func (report *Report) WriteHTML(w io.Writer) error {
+	return htmlTemplate.Execute(w, htmlData{Report: report, methods: report.methods()})
+}
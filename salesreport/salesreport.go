@@ -0,0 +1,319 @@
+package salesreport
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	linepay "github.com/chy168/line-pay-sdk-go"
+)
+
+// Detailer is the part of `linepay.Client` used by the Reporter
+type Detailer interface {
+	PaymentsDetails(ctx context.Context, request *linepay.PaymentsDetailsRequest) (*linepay.PaymentsDetailsResponse, error)
+}
+
+// Transaction saved by the application when the payment was requested,
+// `BranchName` and `BranchID` are the ones of `PaymentsOptionsExtraRequest` (see TransactionOf)
+type Transaction struct {
+	ID         linepay.TransactionID
+	BranchName string
+	BranchID   string
+}
+
+// TransactionOf returns the Transaction of a payment request and its response
+func TransactionOf(request *linepay.PaymentsRequest, response *linepay.PaymentsResponse) Transaction {
+	return Transaction{
+		ID:         response.Info.TransactionID,
+		BranchName: request.Options.Extra.BranchName,
+		BranchID:   request.Options.Extra.BranchID,
+	}
+}
+
+// Store lists the transactions of the application
+type Store interface {
+	// Transactions returns the transactions paid or refunded in [`from`, `to`)
+	Transactions(ctx context.Context, from, to time.Time) ([]Transaction, error)
+}
+
+// Totals of payments and refunds.
+// `Gross` is the amount paid, `Refunds` the amount refunded, `RefundRatio` is `Refunds / Gross`
+type Totals struct {
+	Payments    int     `json:"payments"`
+	RefundCount int     `json:"refundCount"`
+	Gross       int     `json:"gross"`
+	Refunds     int     `json:"refunds"`
+	Net         int     `json:"net"`
+	RefundRatio float64 `json:"refundRatio"`
+}
+
+func (t *Totals) pay(amount int) {
+	t.Payments++
+	t.Gross += amount
+	t.update()
+}
+
+func (t *Totals) refund(amount int) {
+	t.RefundCount++
+	t.Refunds += amount
+	t.update()
+}
+
+func (t *Totals) update() {
+	t.Net = t.Gross - t.Refunds
+	t.RefundRatio = 0
+	if t.Gross != 0 {
+		t.RefundRatio = float64(t.Refunds) / float64(t.Gross)
+	}
+}
+
+// Branch totals, a payment requested without `PaymentsOptionsExtraRequest` is in the branch without ID and name
+type Branch struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Totals
+}
+
+// Summary of a currency over a day, or over the whole range when `Day` is empty.
+// `Methods` is the amount paid by `payInfo` method, e.g. CREDIT_CARD, BALANCE, DISCOUNT
+type Summary struct {
+	Day      string `json:"day,omitempty"` // 2006-01-02
+	Currency string `json:"currency"`
+	Totals
+	Methods  map[string]int `json:"methods"`
+	Branches []*Branch      `json:"branches"`
+}
+
+func (s *Summary) branch(t Transaction) *Branch {
+	for _, b := range s.Branches {
+		if b.ID == t.BranchID && b.Name == t.BranchName {
+			return b
+		}
+	}
+	b := &Branch{ID: t.BranchID, Name: t.BranchName}
+	s.Branches = append(s.Branches, b)
+	return b
+}
+
+// Report of the sales in [`From`, `To`), `Days` by day then currency, `Currencies` the totals of the range
+type Report struct {
+	From       time.Time  `json:"from"`
+	To         time.Time  `json:"to"`
+	Days       []*Summary `json:"days"`
+	Currencies []*Summary `json:"currencies"`
+}
+
+// DefaultBatchSize is the number of transactions queried by one `PaymentsDetails` call
+const DefaultBatchSize = 100
+
+// `Location` optional, the time zone of the days, UTC when nil
+// `BatchSize` optional, DefaultBatchSize when 0
+type Options struct {
+	Location  *time.Location
+	BatchSize int
+}
+
+// Reporter builds sales reports from `PaymentsDetails`
+type Reporter struct {
+	details   Detailer
+	store     Store
+	location  *time.Location
+	batchSize int
+}
+
+// New creates a Reporter, `opts` may be nil
+func New(details Detailer, store Store, opts *Options) *Reporter {
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	r := &Reporter{details: details, store: store, location: opts.Location, batchSize: opts.BatchSize}
+	if r.location == nil {
+		r.location = time.UTC
+	}
+	if r.batchSize <= 0 {
+		r.batchSize = DefaultBatchSize
+	}
+	return r
+}
+
+// Build reports the payments and refunds made in [`from`, `to`), by their `transactionDate` and `refundTransactionDate`.
+// Only captured payments are sales, authorizations are skipped.
+func (r *Reporter) Build(ctx context.Context, from, to time.Time) (*Report, error) {
+
+	transactions, err := r.store.Transactions(ctx, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("store transactions error: %s", err.Error())
+	}
+
+	byID := map[linepay.TransactionID]Transaction{}
+	var ids []linepay.TransactionID
+	for _, t := range transactions {
+		if _, ok := byID[t.ID]; !ok {
+			byID[t.ID] = t
+			ids = append(ids, t.ID)
+		}
+	}
+
+	b := &builder{
+		report:     &Report{From: from, To: to},
+		days:       map[[2]string]*Summary{},
+		currencies: map[string]*Summary{},
+		refunds:    map[linepay.TransactionID]bool{},
+	}
+	inRange := func(t time.Time) bool {
+		return !t.Before(from) && t.Before(to)
+	}
+
+	for start := 0; start < len(ids); start += r.batchSize {
+		end := start + r.batchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		info, err := r.query(ctx, ids[start:end])
+		if err != nil {
+			return nil, err
+		}
+
+		for _, i := range info {
+			switch {
+			case i.OriginalTransactionID != 0:
+				if inRange(i.TransactionDate) {
+					b.refund(r.day(i.TransactionDate), i.Currency, byID[i.OriginalTransactionID], i.TransactionID, abs(amountOf(i)))
+				}
+
+			case i.PayStatus == "AUTHORIZATION" || i.PayStatus == "VOIDED_AUTHORIZATION" || i.PayStatus == "EXPIRED_AUTHORIZATION":
+				// not captured, not a sale
+
+			default:
+				t := byID[i.TransactionID]
+				if inRange(i.TransactionDate) {
+					b.pay(r.day(i.TransactionDate), i.Currency, t, i.PayInfo)
+				}
+				for _, rl := range i.RefundList {
+					if inRange(rl.RefundTransactionDate) {
+						b.refund(r.day(rl.RefundTransactionDate), i.Currency, t, rl.RefundTransactionID, abs(rl.RefundAmount))
+					}
+				}
+			}
+		}
+	}
+
+	b.sort()
+	return b.report, nil
+}
+
+func (r *Reporter) day(t time.Time) string {
+	return t.In(r.location).Format("2006-01-02")
+}
+
+func (r *Reporter) query(ctx context.Context, ids []linepay.TransactionID) ([]linepay.PaymentsDetailsInfoResponse, error) {
+
+	res, err := r.details.PaymentsDetails(ctx, &linepay.PaymentsDetailsRequest{TransactionIDs: ids})
+	if err != nil {
+		return nil, fmt.Errorf("PaymentsDetails error: %s", err.Error())
+	}
+
+	switch res.ReturnCode {
+	case linepay.ApiReturnCodeSuccess:
+		return res.Info, nil
+	case linepay.ApiReturnCodeTransactionNotFound:
+		return nil, nil
+	}
+	return nil, fmt.Errorf("PaymentsDetails failed: %s %s", res.ReturnCode, res.ReturnMessage)
+}
+
+type builder struct {
+	report     *Report
+	days       map[[2]string]*Summary
+	currencies map[string]*Summary
+	refunds    map[linepay.TransactionID]bool // a refund is in `refundList` of its payment and is a transaction too
+}
+
+func (b *builder) summaries(day, currency string) []*Summary {
+
+	d, ok := b.days[[2]string{day, currency}]
+	if !ok {
+		d = &Summary{Day: day, Currency: currency, Methods: map[string]int{}}
+		b.days[[2]string{day, currency}] = d
+		b.report.Days = append(b.report.Days, d)
+	}
+
+	c, ok := b.currencies[currency]
+	if !ok {
+		c = &Summary{Currency: currency, Methods: map[string]int{}}
+		b.currencies[currency] = c
+		b.report.Currencies = append(b.report.Currencies, c)
+	}
+
+	return []*Summary{d, c}
+}
+
+func (b *builder) pay(day, currency string, t Transaction, payInfo []linepay.PaymentsDetailsInfoPayInfoResponse) {
+
+	amount := 0
+	for _, p := range payInfo {
+		amount += p.Amount
+	}
+
+	for _, s := range b.summaries(day, currency) {
+		s.pay(amount)
+		s.branch(t).pay(amount)
+		for _, p := range payInfo {
+			s.Methods[p.Method] += p.Amount
+		}
+	}
+}
+
+func (b *builder) refund(day, currency string, t Transaction, refundID linepay.TransactionID, amount int) {
+
+	if b.refunds[refundID] {
+		return
+	}
+	b.refunds[refundID] = true
+
+	for _, s := range b.summaries(day, currency) {
+		s.refund(amount)
+		s.branch(t).refund(amount)
+	}
+}
+
+func (b *builder) sort() {
+
+	sort.Slice(b.report.Days, func(i, j int) bool {
+		if b.report.Days[i].Day != b.report.Days[j].Day {
+			return b.report.Days[i].Day < b.report.Days[j].Day
+		}
+		return b.report.Days[i].Currency < b.report.Days[j].Currency
+	})
+	sort.Slice(b.report.Currencies, func(i, j int) bool {
+		return b.report.Currencies[i].Currency < b.report.Currencies[j].Currency
+	})
+
+	for _, summaries := range [][]*Summary{b.report.Days, b.report.Currencies} {
+		for _, s := range summaries {
+			sort.Slice(s.Branches, func(i, j int) bool {
+				if s.Branches[i].ID != s.Branches[j].ID {
+					return s.Branches[i].ID < s.Branches[j].ID
+				}
+				return s.Branches[i].Name < s.Branches[j].Name
+			})
+		}
+	}
+}
+
+func amountOf(info linepay.PaymentsDetailsInfoResponse) (amount int) {
+	for _, p := range info.PayInfo {
+		amount += p.Amount
+	}
+	return
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,112 @@
+package salesreport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	linepay "github.com/chy168/line-pay-sdk-go"
+)
+
+type fakeStore []Transaction
+
+func (s fakeStore) Transactions(ctx context.Context, from, to time.Time) ([]Transaction, error) {
+	return s, nil
+}
+
+type fakeDetailer struct {
+	info  []linepay.PaymentsDetailsInfoResponse
+	calls int
+}
+
+func (f *fakeDetailer) PaymentsDetails(ctx context.Context, request *linepay.PaymentsDetailsRequest) (*linepay.PaymentsDetailsResponse, error) {
+	f.calls++
+	res := &linepay.PaymentsDetailsResponse{ReturnCode: linepay.ApiReturnCodeSuccess}
+	for _, i := range f.info {
+		for _, id := range request.TransactionIDs {
+			if i.TransactionID == id {
+				res.Info = append(res.Info, i)
+			}
+		}
+	}
+	return res, nil
+}
+
+func TestReporter_Build(t *testing.T) {
+
+	tw := time.FixedZone("Asia/Taipei", 8*3600)
+	at := func(day, hour int) time.Time { return time.Date(2020, 1, day, hour, 0, 0, 0, tw) }
+	pay := func(method string, amount int) []linepay.PaymentsDetailsInfoPayInfoResponse {
+		return []linepay.PaymentsDetailsInfoPayInfoResponse{{Method: method, Amount: amount}}
+	}
+
+	details := &fakeDetailer{info: []linepay.PaymentsDetailsInfoResponse{
+		{TransactionID: 1, TransactionDate: at(1, 1), Currency: "TWD", PayInfo: append(pay("CREDIT_CARD", 900), pay("DISCOUNT", 100)...),
+			RefundList: []linepay.PaymentsDetailsInfoRefundListResponse{{RefundTransactionID: 11, RefundAmount: -250, RefundTransactionDate: at(2, 10)}}},
+		// the refund 11 queried as a transaction is counted once
+		{TransactionID: 11, OriginalTransactionID: 1, TransactionDate: at(2, 10), Currency: "TWD", PayInfo: pay("CREDIT_CARD", -250)},
+		{TransactionID: 2, TransactionDate: at(1, 23), Currency: "TWD", PayInfo: pay("BALANCE", 500)},
+		{TransactionID: 3, TransactionDate: at(2, 9), Currency: "JPY", PayInfo: pay("CREDIT_CARD", 3000)},
+		{TransactionID: 4, TransactionDate: at(2, 9), Currency: "TWD", PayStatus: "AUTHORIZATION", PayInfo: pay("CREDIT_CARD", 700)},
+		{TransactionID: 5, TransactionDate: at(9, 9), Currency: "TWD", PayInfo: pay("CREDIT_CARD", 800)},
+	}}
+	store := fakeStore{
+		{ID: 1, BranchID: "b1", BranchName: "Taipei"},
+		{ID: 11},
+		{ID: 2, BranchID: "b2", BranchName: "Tainan"},
+		{ID: 3, BranchID: "b1", BranchName: "Taipei"},
+		{ID: 4},
+		{ID: 5},
+	}
+
+	report, err := New(details, store, &Options{Location: tw, BatchSize: 4}).Build(context.Background(), at(1, 0), at(3, 0))
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if details.calls != 2 {
+		t.Errorf("PaymentsDetails called %d times", details.calls)
+	}
+
+	if len(report.Days) != 3 || report.Days[0].Day != "2020-01-01" || report.Days[1].Currency != "JPY" || report.Days[2].Currency != "TWD" {
+		t.Fatalf("Days = %+v", report.Days)
+	}
+
+	day1 := report.Days[0]
+	if day1.Gross != 1500 || day1.Payments != 2 || day1.Methods["CREDIT_CARD"] != 900 || day1.Methods["DISCOUNT"] != 100 || len(day1.Branches) != 2 {
+		t.Errorf("2020-01-01 = %+v", day1)
+	}
+
+	twd := report.Currencies[1]
+	want := Totals{Payments: 2, RefundCount: 1, Gross: 1500, Refunds: 250, Net: 1250, RefundRatio: 250.0 / 1500}
+	if twd.Currency != "TWD" || twd.Totals != want {
+		t.Errorf("TWD totals = %+v, want %+v", twd.Totals, want)
+	}
+	if b := twd.Branches[0]; b.ID != "b1" || b.Gross != 1000 || b.Refunds != 250 {
+		t.Errorf("TWD branch b1 = %+v", b)
+	}
+
+	var csv, html, js bytes.Buffer
+	if err := report.WriteCSV(&csv); err != nil {
+		t.Fatalf("WriteCSV() error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(csv.String()), "\n")
+	if lines[0] != "day,currency,branchId,branchName,payments,refundCount,gross,refunds,net,refundRatio,method:BALANCE,method:CREDIT_CARD,method:DISCOUNT" ||
+		lines[1] != "2020-01-01,TWD,,,2,0,1500,0,1500,0.0000,500,900,100" || len(lines) != 13 {
+		t.Errorf("WriteCSV() = %s", csv.String())
+	}
+
+	if err := report.WriteHTML(&html); err != nil || !strings.Contains(html.String(), "<td>16.67%</td>") || !strings.Contains(html.String(), "b2 Tainan") {
+		t.Errorf("WriteHTML() = %s, %v", html.String(), err)
+	}
+
+	if err := report.WriteJSON(&js); err != nil {
+		t.Fatalf("WriteJSON() error = %v", err)
+	}
+	got := Report{}
+	if err := json.Unmarshal(js.Bytes(), &got); err != nil || got.Currencies[1].Totals != want {
+		t.Errorf("WriteJSON() = %s, %v", js.String(), err)
+	}
+}
@@ -0,0 +1,137 @@
+package linepay
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const redacted = "[REDACTED]"
+
+// ErrRedactedSecret is returned when decoding a SecretString whose JSON value is the redacted placeholder, a
+// SecretString stored with `json.Marshal` lost its value, store a RevealedSecret instead
+var ErrRedactedSecret = errors.New("linepay: secret is redacted")
+
+// SecretString holds a credential which can move money, like `regKey` or `paymentAccessToken`.
+// It is redacted by `fmt` (every verb) and by `json.Marshal`, call `Reveal` to get the real value.
+type SecretString string
+
+// Reveal returns the plain value
+func (s SecretString) Reveal() string {
+	return string(s)
+}
+
+func (s SecretString) String() string {
+	if s == "" {
+		return ""
+	}
+	return redacted
+}
+
+// Format redacts the secret for `%v`, `%+v`, `%s`, `%q` and any other verb
+func (s SecretString) Format(f fmt.State, verb rune) {
+	if verb == 'q' {
+		fmt.Fprintf(f, "%q", s.String())
+		return
+	}
+	io.WriteString(f, s.String())
+}
+
+func (s SecretString) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+func (s *SecretString) UnmarshalJSON(b []byte) error {
+	var v string
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	if v == redacted {
+		return ErrRedactedSecret
+	}
+	*s = SecretString(v)
+	return nil
+}
+
+// RevealedSecret is a SecretString encoded in plain by `json.Marshal`, for the structs persisting a secret.
+// It is still redacted by `fmt`.
+//
+//	type record struct {
+//		RegKey linepay.RevealedSecret `json:"regKey"`
+//	}
+//	b, err := json.Marshal(record{RegKey: linepay.RevealedSecret(res.Info.RegKey)})
+type RevealedSecret SecretString
+
+// Secret returns the SecretString, redacted again by `json.Marshal`
+func (s RevealedSecret) Secret() SecretString {
+	return SecretString(s)
+}
+
+// Reveal returns the plain value
+func (s RevealedSecret) Reveal() string {
+	return string(s)
+}
+
+func (s RevealedSecret) String() string {
+	return SecretString(s).String()
+}
+
+func (s RevealedSecret) Format(f fmt.State, verb rune) {
+	SecretString(s).Format(f, verb)
+}
+
+func (s RevealedSecret) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(s))
+}
+
+func (s *RevealedSecret) UnmarshalJSON(b []byte) error {
+	return (*SecretString)(s).UnmarshalJSON(b)
+}
+
+// Sealed is a SecretString encrypted by a Vault, it is safe to store and log.
+type Sealed string
+
+// Vault encrypts and decrypts secrets, see package `vault` for an AES-GCM implementation.
+type Vault interface {
+	Seal(ctx context.Context, secret SecretString) (Sealed, error)
+	Open(ctx context.Context, sealed Sealed) (SecretString, error)
+}
+
+// ErrNoVault returned by the `*Sealed` methods when `ClientOpts.Vault` is not set
+var ErrNoVault = errors.New("linepay: client has no vault")
+
+func (client *Client) open(ctx context.Context, sealed Sealed) (SecretString, error) {
+	if client.vault == nil {
+		return "", ErrNoVault
+	}
+	return client.vault.Open(ctx, sealed)
+}
+
+// PaymentsPreapprovedSealed is `PaymentsPreapproved` with a regKey sealed by `ClientOpts.Vault`
+func (client *Client) PaymentsPreapprovedSealed(ctx context.Context, regKey Sealed, request *PaymentsPreapprovedRequest) (response *PaymentsPreapprovedResponse, err error) {
+	key, err := client.open(ctx, regKey)
+	if err != nil {
+		return
+	}
+	return client.PaymentsPreapproved(ctx, key.Reveal(), request)
+}
+
+// PaymentsCheckRegKeySealed is `PaymentsCheckRegKey` with a regKey sealed by `ClientOpts.Vault`
+func (client *Client) PaymentsCheckRegKeySealed(ctx context.Context, regKey Sealed, request *PaymentsCheckRegKeyRequest) (response *PaymentsCheckRegKeyResponse, err error) {
+	key, err := client.open(ctx, regKey)
+	if err != nil {
+		return
+	}
+	return client.PaymentsCheckRegKey(ctx, key.Reveal(), request)
+}
+
+// PaymentsExpireRegKeySealed is `PaymentsExpireRegKey` with a regKey sealed by `ClientOpts.Vault`
+func (client *Client) PaymentsExpireRegKeySealed(ctx context.Context, regKey Sealed) (response *PaymentsExpireRegKeyResponse, err error) {
+	key, err := client.open(ctx, regKey)
+	if err != nil {
+		return
+	}
+	return client.PaymentsExpireRegKey(ctx, key.Reveal())
+}
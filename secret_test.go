@@ -0,0 +1,59 @@
+package linepay
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestSecretString_Redact(t *testing.T) {
+
+	res := PaymentsConfirmResponse{}
+	if err := json.Unmarshal([]byte(`{"returnCode":"0000","info":{"regKey":"RK9A7B6C5D4E3F2"}}`), &res); err != nil {
+		t.Fatal(err)
+	}
+
+	if res.Info.RegKey.Reveal() != "RK9A7B6C5D4E3F2" {
+		t.Errorf("regKey not decoded, got '%s'", res.Info.RegKey.Reveal())
+	}
+
+	b, _ := json.Marshal(res)
+	for _, out := range []string{
+		fmt.Sprintf("%v", res),
+		fmt.Sprintf("%+v", res),
+		fmt.Sprintf("%#v", res),
+		fmt.Sprintf("%s %q", res.Info.RegKey, res.Info.RegKey),
+		string(b),
+	} {
+		if strings.Contains(out, "RK9A7B6C5D4E3F2") {
+			t.Errorf("regKey leaked in '%s'", out)
+		}
+	}
+}
+
+func TestSecretString_Persist(t *testing.T) {
+
+	// a redacted secret must not be decoded as the regKey "[REDACTED]"
+	redactedJSON, _ := json.Marshal(struct{ RegKey SecretString }{"RK9A7B6C5D4E3F2"})
+	var secret struct{ RegKey SecretString }
+	if err := json.Unmarshal(redactedJSON, &secret); !errors.Is(err, ErrRedactedSecret) {
+		t.Errorf("Unmarshal(%s) error = %v, want ErrRedactedSecret", redactedJSON, err)
+	}
+
+	type record struct {
+		RegKey RevealedSecret `json:"regKey"`
+	}
+	b, err := json.Marshal(record{RegKey: RevealedSecret("RK9A7B6C5D4E3F2")})
+	if err != nil || string(b) != `{"regKey":"RK9A7B6C5D4E3F2"}` {
+		t.Fatalf("Marshal() = %s, %v", b, err)
+	}
+	var r record
+	if err := json.Unmarshal(b, &r); err != nil || r.RegKey.Secret().Reveal() != "RK9A7B6C5D4E3F2" {
+		t.Errorf("Unmarshal(%s) = %+v, %v", b, r, err)
+	}
+	if out := fmt.Sprintf("%v %s", r, r.RegKey); strings.Contains(out, "RK9A7B6C5D4E3F2") {
+		t.Errorf("regKey leaked in '%s'", out)
+	}
+}
@@ -9,9 +9,13 @@ import (
 	"encoding/base64"
 
 	"github.com/google/uuid"
-	"github.com/sirupsen/logrus"
 )
 
+// Authenticator adds the authentication headers of a request, implemented by Signer (v3) and HeaderAuth (v2)
+type Authenticator interface {
+	SignWithBody(r *http.Request, channelSecret string, requestBody string) (header http.Header, err error)
+}
+
 type Signer struct {
 	ChannelId string
 }
@@ -24,8 +28,6 @@ type Signer struct {
 // Signature = Base64(HMAC-SHA256(Your ChannelSecret, (Your ChannelSecret + URL Path + Query String + nonce))) Query String : A query string except ? (Example: Name1=Value1&Name2=Value2...)
 func (v3 Signer) SignWithBody(r *http.Request, channelSecret string, requestBody string) (header http.Header, err error) {
 
-	logrus.Debugf("sign with body dump body: %s", requestBody)
-
 	myid, err := uuid.NewRandom()
 	if err != nil {
 		return
@@ -34,7 +36,6 @@ func (v3 Signer) SignWithBody(r *http.Request, channelSecret string, requestBody
 	nonce := myid.String()
 
 	sign := channelSecret + r.URL.Path + requestBody + nonce
-	logrus.Debugf("To sign '%s'", sign)
 
 	encResult := calculate(channelSecret, sign)
 
@@ -56,3 +57,17 @@ func calculate(secret, body string) (enc string) {
 
 	return
 }
+
+// HeaderAuth implements API Authentication of the LINE Pay v2 API, the channel id and secret are sent as headers
+type HeaderAuth struct {
+	ChannelId string
+}
+
+func (v2 HeaderAuth) SignWithBody(r *http.Request, channelSecret string, requestBody string) (header http.Header, err error) {
+
+	header = http.Header{}
+	header.Add("X-LINE-ChannelId", v2.ChannelId)
+	header.Add("X-LINE-ChannelSecret", channelSecret)
+
+	return
+}
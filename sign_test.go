@@ -1,7 +1,14 @@
 package linepay
 
 import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
 	"testing"
+
+	"github.com/sirupsen/logrus"
 )
 
 func Test_calculate(t *testing.T) {
@@ -14,3 +21,24 @@ func Test_calculate(t *testing.T) {
 	}
 
 }
+
+func TestSigner_noSecretLogged(t *testing.T) {
+
+	var logs bytes.Buffer
+	logrus.SetOutput(&logs)
+	logrus.SetLevel(logrus.DebugLevel)
+	defer func() {
+		logrus.SetOutput(os.Stderr)
+		logrus.SetLevel(logrus.InfoLevel)
+	}()
+
+	r := httptest.NewRequest(http.MethodPost, "/v3/payments/preapprovedPay/RK9A4BD2D0E4DC1AB/payment", nil)
+	if _, err := (Signer{ChannelId: "1001"}).SignWithBody(r, "channel-secret", `{"paymentAccessToken":"187568751124"}`); err != nil {
+		t.Fatalf("SignWithBody() error = %v", err)
+	}
+	for _, secret := range []string{"channel-secret", "RK9A4BD2D0E4DC1AB", "187568751124"} {
+		if strings.Contains(logs.String(), secret) {
+			t.Errorf("debug logs hold %s: %s", secret, logs.String())
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package subscriptions
+
+import (
+	"time"
+
+	linepay "github.com/chy168/line-pay-sdk-go"
+)
+
+// Unit of a billing Interval
+type Unit int
+
+const (
+	Day Unit = iota
+	Week
+	Month
+	Year
+)
+
+// Interval is the billing period of a Plan, e.g. `Interval{Unit: Month, Count: 3}` for quarterly billing.
+// `Count` less than 1 is treated as 1.
+type Interval struct {
+	Unit  Unit
+	Count int
+}
+
+// Next returns the end of the period starting at `t`.
+func (i Interval) Next(t time.Time) time.Time {
+	return i.Add(t, 1)
+}
+
+// Add returns `t` plus `periods` intervals. A monthly or yearly date past the end of the month is the last day of
+// the month: Jan 31 plus 1 month is Feb 28 (or 29), plus 2 months is Mar 31.
+func (i Interval) Add(t time.Time, periods int) time.Time {
+	n := i.Count
+	if n < 1 {
+		n = 1
+	}
+	n *= periods
+
+	switch i.Unit {
+	case Week:
+		return t.AddDate(0, 0, 7*n)
+	case Month:
+		return addMonths(t, n)
+	case Year:
+		return addMonths(t, 12*n)
+	default:
+		return t.AddDate(0, 0, n)
+	}
+}
+
+// addMonths adds `n` months to `t`, clamped to the last day of the month instead of overflowing into the next one
+func addMonths(t time.Time, n int) time.Time {
+	year, month, day := t.Date()
+	hour, min, sec := t.Clock()
+
+	last := time.Date(year, month+time.Month(n)+1, 0, 0, 0, 0, 0, t.Location()).Day()
+	if day > last {
+		day = last
+	}
+	return time.Date(year, month+time.Month(n), day, hour, min, sec, t.Nanosecond(), t.Location())
+}
+
+// Plan is what a subscriber is charged for every Interval.
+// `Name` is sent as `productName` of the preapproved payment.
+// `Currency` is ISO 4217, supported: USD, JPY, TWD, THB
+type Plan struct {
+	ID       string
+	Name     string
+	Amount   int
+	Currency string
+	Interval Interval
+}
+
+// Status of a Subscription
+type Status string
+
+const (
+	StatusActive   Status = "ACTIVE"
+	StatusPastDue  Status = "PAST_DUE" // last charge failed, waiting for the next retry
+	StatusCanceled Status = "CANCELED"
+)
+
+// Subscription binds a `regKey` of a `PREAPPROVED` payment to a Plan.
+// `RegKey` is redacted by `fmt` but marshaled in plain, so a JSON Store keeps it.
+// [`PeriodStart`, `PeriodEnd`) is the period already paid, `NextBillingAt` is `PeriodEnd` unless a retry is scheduled.
+// `Credit` is the proration credit deducted from the next charge.
+// The periods are counted from `Anchor`, the start of the period of `AnchorCycle`, so the billing day does not drift
+// after a short month.
+type Subscription struct {
+	ID             string
+	PlanID         string
+	RegKey         linepay.RevealedSecret
+	Status         Status
+	PeriodStart    time.Time
+	PeriodEnd      time.Time
+	NextBillingAt  time.Time
+	Anchor         time.Time
+	AnchorCycle    int
+	Cycle          int // successful renewals
+	Attempts       int // failed attempts of the current renewal
+	Credit         int
+	CanceledAt     time.Time
+	CanceledReason string
+}
+
+// Charge is the result of one preapproved payment made by the Scheduler.
+// `Error` is empty when the charge succeeded.
+type Charge struct {
+	SubscriptionID string
+	OrderID        string
+	Amount         int
+	Currency       string
+	TransactionID  linepay.TransactionID
+	ReturnCode     string
+	ReturnMessage  string
+	ChargedAt      time.Time
+	Error          string
+}
+
+// Succeeded tells whether LINE Pay accepted the charge
+func (c *Charge) Succeeded() bool {
+	return c.Error == ""
+}
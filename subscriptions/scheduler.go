@@ -0,0 +1,355 @@
+package subscriptions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	linepay "github.com/chy168/line-pay-sdk-go"
+	"github.com/sirupsen/logrus"
+)
+
+// Payer is the part of `linepay.Client` used by the Scheduler
+type Payer interface {
+	PaymentsPreapproved(ctx context.Context, regKey string, request *linepay.PaymentsPreapprovedRequest) (*linepay.PaymentsPreapprovedResponse, error)
+	PaymentsCheckRegKey(ctx context.Context, regKey string, request *linepay.PaymentsCheckRegKeyRequest) (*linepay.PaymentsCheckRegKeyResponse, error)
+	PaymentsExpireRegKey(ctx context.Context, regKey string) (*linepay.PaymentsExpireRegKeyResponse, error)
+	PaymentsDetails(ctx context.Context, request *linepay.PaymentsDetailsRequest) (*linepay.PaymentsDetailsResponse, error)
+}
+
+// Clock is injected into the Scheduler so billing can be tested without waiting
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// ErrNotActive returned when changing the plan of a subscription which is not `StatusActive`
+var ErrNotActive = errors.New("subscriptions: subscription is not active")
+
+// DefaultRetryDelays is the dunning schedule used when `Options.RetryDelays` is nil
+var DefaultRetryDelays = []time.Duration{24 * time.Hour, 3 * 24 * time.Hour, 7 * 24 * time.Hour}
+
+// Options of the Scheduler
+// `RetryDelays` the delay before each retry of a failed renewal, the subscription is canceled when all retries failed.
+// if `ExpireRegKeyOnCancel` true: `Expire RegKey API` is called when a subscription is canceled
+type Options struct {
+	Clock                Clock
+	RetryDelays          []time.Duration
+	ExpireRegKeyOnCancel bool
+}
+
+// Scheduler renews subscriptions with `Pay Preapproved API`
+type Scheduler struct {
+	payer                Payer
+	store                Store
+	clock                Clock
+	retryDelays          []time.Duration
+	expireRegKeyOnCancel bool
+}
+
+func NewScheduler(payer Payer, store Store, opts *Options) *Scheduler {
+	s := &Scheduler{
+		payer:       payer,
+		store:       store,
+		clock:       systemClock{},
+		retryDelays: DefaultRetryDelays,
+	}
+
+	if opts != nil {
+		if opts.Clock != nil {
+			s.clock = opts.Clock
+		}
+		if opts.RetryDelays != nil {
+			s.retryDelays = opts.RetryDelays
+		}
+		s.expireRegKeyOnCancel = opts.ExpireRegKeyOnCancel
+	}
+
+	return s
+}
+
+// Subscribe starts a subscription of the plan with the `regKey` from `PaymentsConfirmInfoResponse`.
+// The first period is considered paid by the `PREAPPROVED` payment which issued the `regKey`.
+func (s *Scheduler) Subscribe(ctx context.Context, id, planID string, regKey linepay.SecretString) (*Subscription, error) {
+
+	plan, err := s.store.GetPlan(ctx, planID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.clock.Now()
+	sub := &Subscription{
+		ID:            id,
+		PlanID:        plan.ID,
+		RegKey:        linepay.RevealedSecret(regKey),
+		Status:        StatusActive,
+		PeriodStart:   now,
+		PeriodEnd:     plan.Interval.Next(now),
+		NextBillingAt: plan.Interval.Next(now),
+		Anchor:        now,
+	}
+
+	if err := s.store.SaveSubscription(ctx, sub); err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+// RunDue charges every subscription whose billing date has come, it should be called periodically (e.g. by cron).
+// A failed charge does not stop the run, it is recorded and retried according to `Options.RetryDelays`.
+func (s *Scheduler) RunDue(ctx context.Context) (charges []*Charge, err error) {
+
+	due, err := s.store.DueSubscriptions(ctx, s.clock.Now())
+	if err != nil {
+		return
+	}
+
+	for _, sub := range due {
+		if err = ctx.Err(); err != nil {
+			return
+		}
+
+		charge, rerr := s.renew(ctx, sub)
+		if rerr != nil {
+			err = rerr
+			return
+		}
+		if charge != nil {
+			charges = append(charges, charge)
+		}
+	}
+
+	return
+}
+
+func (s *Scheduler) renew(ctx context.Context, sub *Subscription) (*Charge, error) {
+
+	plan, err := s.store.GetPlan(ctx, sub.PlanID)
+	if err != nil {
+		return nil, fmt.Errorf("subscription '%s' get plan error: %s", sub.ID, err.Error())
+	}
+
+	// an invalid regKey will never succeed, cancel instead of retrying
+	check, err := s.payer.PaymentsCheckRegKey(ctx, sub.RegKey.Reveal(), nil)
+	if err == nil {
+		switch check.ReturnCode {
+		case linepay.ApiReturnCodeRegKeyNotFound, linepay.ApiReturnCodeRegKeyExpired, linepay.ApiReturnCodePreapprovedForbidden:
+			reason := fmt.Sprintf("regKey not available: %s %s", check.ReturnCode, check.ReturnMessage)
+			return nil, s.cancel(ctx, sub, reason, false)
+		}
+	}
+
+	now := s.clock.Now()
+	amount := plan.Amount - sub.Credit
+	if amount <= 0 {
+		// the proration credit covers the whole period
+		sub.Credit = -amount
+		s.advance(sub, plan)
+		return nil, s.store.SaveSubscription(ctx, sub)
+	}
+
+	// the orderId of a renewal is kept by its retries: LINE Pay refuses to charge it twice
+	charge := &Charge{
+		SubscriptionID: sub.ID,
+		OrderID:        fmt.Sprintf("%s-%d", sub.ID, sub.Cycle+1),
+		Amount:         amount,
+		Currency:       plan.Currency,
+		ChargedAt:      now,
+	}
+
+	if err != nil {
+		charge.Error = fmt.Sprintf("check regKey error: %s", err.Error())
+	} else if check.ReturnCode != linepay.ApiReturnCodeSuccess {
+		charge.Error = fmt.Sprintf("check regKey failed: %s %s", check.ReturnCode, check.ReturnMessage)
+	} else {
+		s.pay(ctx, sub.RegKey.Secret(), plan.Name, charge)
+	}
+
+	if err := s.store.SaveCharge(ctx, charge); err != nil {
+		return nil, err
+	}
+
+	if charge.Succeeded() {
+		sub.Credit = 0
+		s.advance(sub, plan)
+		return charge, s.store.SaveSubscription(ctx, sub)
+	}
+
+	logrus.Warnf("subscription '%s' renewal failed (attempt %d): %s", sub.ID, sub.Attempts+1, charge.Error)
+
+	sub.Attempts++
+	if sub.Attempts > len(s.retryDelays) {
+		return charge, s.cancel(ctx, sub, "renewal failed: "+charge.Error, true)
+	}
+
+	sub.Status = StatusPastDue
+	sub.NextBillingAt = now.Add(s.retryDelays[sub.Attempts-1])
+	return charge, s.store.SaveSubscription(ctx, sub)
+}
+
+func (s *Scheduler) pay(ctx context.Context, regKey linepay.SecretString, productName string, charge *Charge) {
+
+	res, err := s.payer.PaymentsPreapproved(ctx, regKey.Reveal(), &linepay.PaymentsPreapprovedRequest{
+		ProductName: productName,
+		Amount:      charge.Amount,
+		Currency:    charge.Currency,
+		OrderID:     charge.OrderID,
+		Capture:     true,
+	})
+	if err != nil {
+		charge.Error = err.Error()
+		return
+	}
+
+	charge.ReturnCode = res.ReturnCode
+	charge.ReturnMessage = res.ReturnMessage
+	charge.TransactionID = res.Info.TransactionID
+	switch res.ReturnCode {
+	case linepay.ApiReturnCodeSuccess:
+	case linepay.ApiReturnCodeOrderIDExists:
+		// an earlier attempt was charged, e.g. its response was lost
+		s.charged(ctx, charge)
+	default:
+		charge.Error = fmt.Sprintf("pay preapproved failed: %s %s", res.ReturnCode, res.ReturnMessage)
+	}
+}
+
+// charged completes `charge` with the payment of its orderId, already made by LINE Pay
+func (s *Scheduler) charged(ctx context.Context, charge *Charge) {
+
+	res, err := s.payer.PaymentsDetails(ctx, &linepay.PaymentsDetailsRequest{OrderIDs: []string{charge.OrderID}})
+	if err != nil {
+		charge.Error = fmt.Sprintf("order exists, payment details error: %s", err.Error())
+		return
+	}
+	if res.ReturnCode != linepay.ApiReturnCodeSuccess || len(res.Info) == 0 {
+		charge.Error = fmt.Sprintf("order exists, payment details failed: %s %s", res.ReturnCode, res.ReturnMessage)
+		return
+	}
+
+	logrus.Infof("order '%s' was already charged, transaction %s", charge.OrderID, res.Info[0].TransactionID)
+	charge.TransactionID = res.Info[0].TransactionID
+}
+
+// advance moves the subscription to the next period, counted from the anchor so the billing date does not drift
+// with retries or short months
+func (s *Scheduler) advance(sub *Subscription, plan *Plan) {
+	if sub.Anchor.IsZero() {
+		sub.Anchor, sub.AnchorCycle = sub.PeriodStart, sub.Cycle
+	}
+
+	sub.Status = StatusActive
+	sub.Cycle++
+	sub.Attempts = 0
+	sub.PeriodStart = sub.PeriodEnd
+	sub.PeriodEnd = plan.Interval.Add(sub.Anchor, sub.Cycle-sub.AnchorCycle+1)
+	sub.NextBillingAt = sub.PeriodEnd
+}
+
+// ChangePlan switches the subscription to another plan immediately, the billing date is kept.
+// The unused part of the current period is prorated: when the new plan costs more, the difference is charged now,
+// otherwise it becomes `Credit` deducted from the next renewal.
+func (s *Scheduler) ChangePlan(ctx context.Context, id, planID string) (*Charge, error) {
+
+	sub, err := s.store.GetSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if sub.Status != StatusActive {
+		return nil, ErrNotActive
+	}
+
+	oldPlan, err := s.store.GetPlan(ctx, sub.PlanID)
+	if err != nil {
+		return nil, err
+	}
+	newPlan, err := s.store.GetPlan(ctx, planID)
+	if err != nil {
+		return nil, err
+	}
+	if oldPlan.Currency != newPlan.Currency {
+		return nil, fmt.Errorf("subscriptions: can not change currency from %s to %s", oldPlan.Currency, newPlan.Currency)
+	}
+
+	diff := prorate(newPlan.Amount-oldPlan.Amount, sub.PeriodStart, sub.PeriodEnd, s.clock.Now())
+
+	var charge *Charge
+	if diff > 0 {
+		// like a renewal, a retried change keeps its orderId: an order LINE Pay already charged is looked up
+		charge = &Charge{
+			SubscriptionID: sub.ID,
+			OrderID:        fmt.Sprintf("%s-%d-change-%s", sub.ID, sub.Cycle, newPlan.ID),
+			Amount:         diff,
+			Currency:       newPlan.Currency,
+			ChargedAt:      s.clock.Now(),
+		}
+		s.pay(ctx, sub.RegKey.Secret(), newPlan.Name, charge)
+
+		if err := s.store.SaveCharge(ctx, charge); err != nil {
+			return nil, err
+		}
+		if !charge.Succeeded() {
+			return charge, fmt.Errorf("subscriptions: proration charge failed: %s", charge.Error)
+		}
+	} else {
+		sub.Credit += -diff
+	}
+
+	if newPlan.Interval != oldPlan.Interval {
+		// the periods of the new plan start at the billing date
+		sub.Anchor, sub.AnchorCycle = sub.PeriodEnd, sub.Cycle+1
+	}
+	sub.PlanID = newPlan.ID
+	return charge, s.store.SaveSubscription(ctx, sub)
+}
+
+// prorate returns the part of `amount` for the time left in [start, end)
+func prorate(amount int, start, end, now time.Time) int {
+	total := end.Sub(start)
+	left := end.Sub(now)
+	if total <= 0 || left <= 0 {
+		return 0
+	}
+	if left > total {
+		left = total
+	}
+	return int(math.Round(float64(amount) * float64(left) / float64(total)))
+}
+
+// Cancel stops the subscription, it will not be charged anymore.
+func (s *Scheduler) Cancel(ctx context.Context, id, reason string) error {
+
+	sub, err := s.store.GetSubscription(ctx, id)
+	if err != nil {
+		return err
+	}
+	if sub.Status == StatusCanceled {
+		return nil
+	}
+	return s.cancel(ctx, sub, reason, true)
+}
+
+func (s *Scheduler) cancel(ctx context.Context, sub *Subscription, reason string, expire bool) error {
+
+	sub.Status = StatusCanceled
+	sub.CanceledAt = s.clock.Now()
+	sub.CanceledReason = reason
+
+	if expire && s.expireRegKeyOnCancel {
+		res, err := s.payer.PaymentsExpireRegKey(ctx, sub.RegKey.Reveal())
+		if err != nil {
+			logrus.Warnf("subscription '%s' expire regKey error: %s", sub.ID, err.Error())
+		} else if res.ReturnCode != linepay.ApiReturnCodeSuccess {
+			logrus.Warnf("subscription '%s' expire regKey failed: %s %s", sub.ID, res.ReturnCode, res.ReturnMessage)
+		}
+	}
+
+	return s.store.SaveSubscription(ctx, sub)
+}
@@ -0,0 +1,311 @@
+package subscriptions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	linepay "github.com/chy168/line-pay-sdk-go"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+type fakePayer struct {
+	checkCode string
+	payCodes  []string // consumed one per payment, ApiReturnCodeSuccess when empty
+	payments  []linepay.PaymentsPreapprovedRequest
+	expired   []string
+	charged   map[string]linepay.TransactionID // orderIds found by PaymentsDetails
+}
+
+func (p *fakePayer) PaymentsPreapproved(ctx context.Context, regKey string, request *linepay.PaymentsPreapprovedRequest) (*linepay.PaymentsPreapprovedResponse, error) {
+	p.payments = append(p.payments, *request)
+
+	code := linepay.ApiReturnCodeSuccess
+	if len(p.payCodes) > 0 {
+		code, p.payCodes = p.payCodes[0], p.payCodes[1:]
+	}
+
+	res := &linepay.PaymentsPreapprovedResponse{ReturnCode: code}
+	res.Info.TransactionID = linepay.TransactionID(len(p.payments))
+	return res, nil
+}
+
+func (p *fakePayer) PaymentsCheckRegKey(ctx context.Context, regKey string, request *linepay.PaymentsCheckRegKeyRequest) (*linepay.PaymentsCheckRegKeyResponse, error) {
+	code := p.checkCode
+	if code == "" {
+		code = linepay.ApiReturnCodeSuccess
+	}
+	return &linepay.PaymentsCheckRegKeyResponse{ReturnCode: code}, nil
+}
+
+func (p *fakePayer) PaymentsExpireRegKey(ctx context.Context, regKey string) (*linepay.PaymentsExpireRegKeyResponse, error) {
+	p.expired = append(p.expired, regKey)
+	return &linepay.PaymentsExpireRegKeyResponse{ReturnCode: linepay.ApiReturnCodeSuccess}, nil
+}
+
+func (p *fakePayer) PaymentsDetails(ctx context.Context, request *linepay.PaymentsDetailsRequest) (*linepay.PaymentsDetailsResponse, error) {
+	res := &linepay.PaymentsDetailsResponse{ReturnCode: linepay.ApiReturnCodeSuccess}
+	for _, orderID := range request.OrderIDs {
+		if id, ok := p.charged[orderID]; ok {
+			res.Info = append(res.Info, linepay.PaymentsDetailsInfoResponse{TransactionID: id, OrderID: orderID})
+		}
+	}
+	if len(res.Info) == 0 {
+		res.ReturnCode = linepay.ApiReturnCodeTransactionNotFound
+	}
+	return res, nil
+}
+
+func newTestScheduler(t *testing.T) (*Scheduler, *fakePayer, *fakeClock, *MemoryStore) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	store.SavePlan(ctx, &Plan{ID: "basic", Name: "Basic", Amount: 300, Currency: "TWD", Interval: Interval{Unit: Month, Count: 1}})
+	store.SavePlan(ctx, &Plan{ID: "pro", Name: "Pro", Amount: 900, Currency: "TWD", Interval: Interval{Unit: Month, Count: 1}})
+
+	payer := &fakePayer{}
+	clock := &fakeClock{now: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+	s := NewScheduler(payer, store, &Options{
+		Clock:                clock,
+		RetryDelays:          []time.Duration{24 * time.Hour, 48 * time.Hour},
+		ExpireRegKeyOnCancel: true,
+	})
+
+	if _, err := s.Subscribe(ctx, "sub1", "basic", "RK01"); err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+
+	return s, payer, clock, store
+}
+
+func TestScheduler_RunDue(t *testing.T) {
+	ctx := context.Background()
+	s, payer, clock, store := newTestScheduler(t)
+
+	charges, err := s.RunDue(ctx)
+	if err != nil || len(charges) != 0 {
+		t.Fatalf("RunDue() before billing date = %v, %v", charges, err)
+	}
+
+	clock.now = time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC)
+	charges, err = s.RunDue(ctx)
+	if err != nil {
+		t.Fatalf("RunDue() error = %v", err)
+	}
+	if len(charges) != 1 || !charges[0].Succeeded() || charges[0].Amount != 300 {
+		t.Fatalf("RunDue() charges = %+v", charges)
+	}
+	if payer.payments[0].OrderID != "sub1-1" || !payer.payments[0].Capture {
+		t.Errorf("unexpected payment request %+v", payer.payments[0])
+	}
+
+	sub, _ := store.GetSubscription(ctx, "sub1")
+	if want := time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC); !sub.NextBillingAt.Equal(want) || sub.Cycle != 1 {
+		t.Errorf("next billing = %v cycle = %d, want %v", sub.NextBillingAt, sub.Cycle, want)
+	}
+}
+
+func TestScheduler_Dunning(t *testing.T) {
+	ctx := context.Background()
+	s, payer, clock, store := newTestScheduler(t)
+	payer.payCodes = []string{"1141", "1141", "1141"}
+
+	clock.now = time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC)
+	s.RunDue(ctx)
+
+	sub, _ := store.GetSubscription(ctx, "sub1")
+	if sub.Status != StatusPastDue || !sub.NextBillingAt.Equal(clock.now.Add(24*time.Hour)) {
+		t.Fatalf("after first failure got %s %v", sub.Status, sub.NextBillingAt)
+	}
+
+	clock.now = sub.NextBillingAt
+	s.RunDue(ctx)
+	clock.now = clock.now.Add(48 * time.Hour)
+	s.RunDue(ctx)
+
+	sub, _ = store.GetSubscription(ctx, "sub1")
+	if sub.Status != StatusCanceled {
+		t.Fatalf("after retries exhausted got %s", sub.Status)
+	}
+	if len(payer.expired) != 1 || payer.expired[0] != "RK01" {
+		t.Errorf("regKey not expired: %v", payer.expired)
+	}
+	if got := len(store.Charges("sub1")); got != 3 {
+		t.Errorf("recorded %d charges, want 3", got)
+	}
+	for _, payment := range payer.payments {
+		if payment.OrderID != "sub1-1" {
+			t.Errorf("retry orderId = %s, want the orderId of the renewal", payment.OrderID)
+		}
+	}
+}
+
+func TestScheduler_AlreadyCharged(t *testing.T) {
+	ctx := context.Background()
+	s, payer, clock, store := newTestScheduler(t)
+
+	// the first attempt timed out after LINE Pay charged it
+	payer.payCodes = []string{linepay.ApiReturnCodeOrderIDExists, linepay.ApiReturnCodeOrderIDExists}
+
+	clock.now = time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC)
+	charges, _ := s.RunDue(ctx)
+	if len(charges) != 1 || charges[0].Succeeded() {
+		t.Fatalf("duplicate order without payment = %+v, want a failure", charges)
+	}
+
+	payer.charged = map[string]linepay.TransactionID{"sub1-1": 2020020112345}
+	clock.now = clock.now.Add(24 * time.Hour)
+	charges, _ = s.RunDue(ctx)
+	if len(charges) != 1 || !charges[0].Succeeded() || charges[0].TransactionID != 2020020112345 {
+		t.Fatalf("duplicate order of a payment = %+v, want the payment", charges)
+	}
+
+	sub, _ := store.GetSubscription(ctx, "sub1")
+	if sub.Status != StatusActive || sub.Cycle != 1 {
+		t.Errorf("subscription after the duplicate order = %s cycle %d", sub.Status, sub.Cycle)
+	}
+}
+
+func TestScheduler_InvalidRegKey(t *testing.T) {
+	ctx := context.Background()
+	s, payer, clock, store := newTestScheduler(t)
+	payer.checkCode = linepay.ApiReturnCodeRegKeyExpired
+
+	clock.now = time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC)
+	s.RunDue(ctx)
+
+	sub, _ := store.GetSubscription(ctx, "sub1")
+	if sub.Status != StatusCanceled || len(payer.payments) != 0 {
+		t.Errorf("got status %s with %d payments", sub.Status, len(payer.payments))
+	}
+}
+
+func TestScheduler_ChangePlan(t *testing.T) {
+	ctx := context.Background()
+	s, payer, clock, store := newTestScheduler(t)
+
+	// 2020-01 has 31 days, upgrade with 10 days left
+	clock.now = time.Date(2020, 1, 22, 0, 0, 0, 0, time.UTC)
+	charge, err := s.ChangePlan(ctx, "sub1", "pro")
+	if err != nil {
+		t.Fatalf("ChangePlan() error = %v", err)
+	}
+	if want := 194; charge.Amount != want {
+		t.Errorf("upgrade charged %d, want %d", charge.Amount, want)
+	}
+
+	// downgrade back gives credit for the next renewal
+	charge, err = s.ChangePlan(ctx, "sub1", "basic")
+	if err != nil || charge != nil {
+		t.Fatalf("ChangePlan() = %v, %v", charge, err)
+	}
+	sub, _ := store.GetSubscription(ctx, "sub1")
+	if sub.Credit != 194 {
+		t.Errorf("credit = %d, want 194", sub.Credit)
+	}
+
+	clock.now = time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC)
+	charges, _ := s.RunDue(ctx)
+	if len(charges) != 1 || charges[0].Amount != 106 || len(payer.payments) != 2 {
+		t.Errorf("renewal after credit = %+v", charges)
+	}
+}
+
+func TestScheduler_MonthEnd(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	store.SavePlan(ctx, &Plan{ID: "basic", Name: "Basic", Amount: 300, Currency: "TWD", Interval: Interval{Unit: Month, Count: 1}})
+
+	clock := &fakeClock{now: time.Date(2021, 1, 31, 9, 0, 0, 0, time.UTC)}
+	s := NewScheduler(&fakePayer{}, store, &Options{Clock: clock})
+	if _, err := s.Subscribe(ctx, "sub1", "basic", "RK01"); err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+
+	for _, want := range []time.Time{
+		time.Date(2021, 2, 28, 9, 0, 0, 0, time.UTC),
+		time.Date(2021, 3, 31, 9, 0, 0, 0, time.UTC),
+		time.Date(2021, 4, 30, 9, 0, 0, 0, time.UTC),
+		time.Date(2021, 5, 31, 9, 0, 0, 0, time.UTC),
+	} {
+		sub, _ := store.GetSubscription(ctx, "sub1")
+		if !sub.NextBillingAt.Equal(want) {
+			t.Fatalf("billing date of cycle %d = %v, want %v", sub.Cycle, sub.NextBillingAt, want)
+		}
+		clock.now = sub.NextBillingAt
+		if charges, err := s.RunDue(ctx); err != nil || len(charges) != 1 {
+			t.Fatalf("RunDue() = %v, %v", charges, err)
+		}
+	}
+}
+
+func TestInterval_Add(t *testing.T) {
+	leap := time.Date(2020, 2, 29, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		interval Interval
+		periods  int
+		want     time.Time
+	}{
+		{Interval{Unit: Month, Count: 1}, 1, time.Date(2020, 3, 29, 0, 0, 0, 0, time.UTC)},
+		{Interval{Unit: Month, Count: 3}, 1, time.Date(2020, 5, 29, 0, 0, 0, 0, time.UTC)},
+		{Interval{Unit: Year, Count: 1}, 1, time.Date(2021, 2, 28, 0, 0, 0, 0, time.UTC)},
+		{Interval{Unit: Year, Count: 1}, 4, time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{Interval{Unit: Week, Count: 2}, 1, time.Date(2020, 3, 14, 0, 0, 0, 0, time.UTC)},
+		{Interval{Unit: Day}, 2, time.Date(2020, 3, 2, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		if got := tt.interval.Add(leap, tt.periods); !got.Equal(tt.want) {
+			t.Errorf("%+v.Add(%v, %d) = %v, want %v", tt.interval, leap, tt.periods, got, tt.want)
+		}
+	}
+}
+
+// a JSON Store keeps the regKey, the logs do not show it
+func TestSubscription_JSON(t *testing.T) {
+
+	sub := &Subscription{ID: "sub-1", PlanID: "monthly", RegKey: "RK9A7B6C5D4E3F2", Status: StatusActive}
+	b, err := json.Marshal(sub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var saved Subscription
+	if err := json.Unmarshal(b, &saved); err != nil || saved.RegKey.Reveal() != "RK9A7B6C5D4E3F2" {
+		t.Errorf("Unmarshal(%s) = %+v, %v", b, saved, err)
+	}
+	if out := fmt.Sprintf("%v %+v", sub, saved); strings.Contains(out, "RK9A7B6C5D4E3F2") {
+		t.Errorf("regKey leaked in '%s'", out)
+	}
+}
+
+// a change retried after a lost response is not charged twice
+func TestScheduler_ChangePlanRetry(t *testing.T) {
+	ctx := context.Background()
+	s, payer, clock, _ := newTestScheduler(t)
+
+	clock.now = time.Date(2020, 1, 22, 0, 0, 0, 0, time.UTC)
+	payer.payCodes = []string{"9000"}
+	if _, err := s.ChangePlan(ctx, "sub1", "pro"); err == nil {
+		t.Fatalf("ChangePlan() of a failed charge no error")
+	}
+
+	// LINE Pay charged the order, the retry finds it
+	clock.now = clock.now.Add(time.Minute)
+	payer.payCodes = []string{linepay.ApiReturnCodeOrderIDExists}
+	payer.charged = map[string]linepay.TransactionID{payer.payments[0].OrderID: 77}
+	charge, err := s.ChangePlan(ctx, "sub1", "pro")
+	if err != nil || charge.TransactionID != 77 {
+		t.Fatalf("ChangePlan() retry = %+v, %v", charge, err)
+	}
+	if len(payer.payments) != 2 || payer.payments[1].OrderID != payer.payments[0].OrderID {
+		t.Errorf("orderIds of the retry %+v", payer.payments)
+	}
+}
@@ -0,0 +1,120 @@
+package subscriptions
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrNotFound returned by Store when the plan or subscription does not exist
+var ErrNotFound = errors.New("subscriptions: not found")
+
+// Store persists plans, subscriptions and charges. Implementations must be safe for concurrent use.
+// A Store not encoding with `encoding/json` must persist `Subscription.RegKey.Reveal()`, or seal it with a
+// linepay.Vault.
+type Store interface {
+	GetPlan(ctx context.Context, id string) (*Plan, error)
+	SavePlan(ctx context.Context, plan *Plan) error
+	GetSubscription(ctx context.Context, id string) (*Subscription, error)
+	SaveSubscription(ctx context.Context, sub *Subscription) error
+	// DueSubscriptions returns the not canceled subscriptions with `NextBillingAt` not after `now`
+	DueSubscriptions(ctx context.Context, now time.Time) ([]*Subscription, error)
+	SaveCharge(ctx context.Context, charge *Charge) error
+}
+
+// MemoryStore is an in-memory Store, useful for tests and single instance deployments.
+type MemoryStore struct {
+	mu            sync.RWMutex
+	plans         map[string]Plan
+	subscriptions map[string]Subscription
+	charges       []Charge
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		plans:         map[string]Plan{},
+		subscriptions: map[string]Subscription{},
+	}
+}
+
+func (m *MemoryStore) GetPlan(ctx context.Context, id string) (*Plan, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	p, ok := m.plans[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &p, nil
+}
+
+func (m *MemoryStore) SavePlan(ctx context.Context, plan *Plan) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.plans[plan.ID] = *plan
+	return nil
+}
+
+func (m *MemoryStore) GetSubscription(ctx context.Context, id string) (*Subscription, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	s, ok := m.subscriptions[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &s, nil
+}
+
+func (m *MemoryStore) SaveSubscription(ctx context.Context, sub *Subscription) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.subscriptions[sub.ID] = *sub
+	return nil
+}
+
+func (m *MemoryStore) DueSubscriptions(ctx context.Context, now time.Time) ([]*Subscription, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var due []*Subscription
+	for _, s := range m.subscriptions {
+		if s.Status == StatusCanceled || s.NextBillingAt.After(now) {
+			continue
+		}
+		s := s
+		due = append(due, &s)
+	}
+
+	sort.Slice(due, func(i, j int) bool {
+		return due[i].NextBillingAt.Before(due[j].NextBillingAt)
+	})
+
+	return due, nil
+}
+
+func (m *MemoryStore) SaveCharge(ctx context.Context, charge *Charge) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.charges = append(m.charges, *charge)
+	return nil
+}
+
+// Charges returns every charge of the subscription in the order they were made
+func (m *MemoryStore) Charges(subscriptionID string) []Charge {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var charges []Charge
+	for _, c := range m.charges {
+		if c.SubscriptionID == subscriptionID {
+			charges = append(charges, c)
+		}
+	}
+	return charges
+}
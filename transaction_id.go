@@ -0,0 +1,91 @@
+package linepay
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// TransactionID is a LINE Pay transaction id, a 19 digit number beyond the 2^53 precision of JavaScript numbers.
+// It is decoded from a JSON number or a JSON string, and encoded as a JSON number like LINE Pay does.
+type TransactionID int64
+
+// TransactionIDString is a TransactionID encoded as a JSON string, for the JSON given to JavaScript clients
+//
+//	type Order struct {
+//		TransactionID linepay.TransactionIDString `json:"transactionId"`
+//	}
+type TransactionIDString TransactionID
+
+// ParseTransactionID parses a decimal transaction id, like the `transactionId` of the confirm URL
+func ParseTransactionID(s string) (TransactionID, error) {
+	id, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid transaction id '%s'", s)
+	}
+	return TransactionID(id), nil
+}
+
+func (id TransactionID) String() string {
+	return strconv.FormatInt(int64(id), 10)
+}
+
+func (id TransactionID) Int64() int64 {
+	return int64(id)
+}
+
+func (id TransactionID) MarshalJSON() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+func (id *TransactionID) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(b, []byte("null")) {
+		return nil
+	}
+
+	s := string(b)
+	if strings.HasPrefix(s, `"`) {
+		var err error
+		if s, err = strconv.Unquote(s); err != nil {
+			return fmt.Errorf("invalid transaction id %s", b)
+		}
+		if s == "" {
+			*id = 0
+			return nil
+		}
+	}
+
+	v, err := ParseTransactionID(s)
+	if err != nil {
+		return err
+	}
+	*id = v
+	return nil
+}
+
+// MarshalText encodes the id as a map key or a form value
+func (id TransactionID) MarshalText() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+func (id *TransactionID) UnmarshalText(b []byte) error {
+	v, err := ParseTransactionID(string(b))
+	if err != nil {
+		return err
+	}
+	*id = v
+	return nil
+}
+
+func (id TransactionIDString) String() string {
+	return TransactionID(id).String()
+}
+
+func (id TransactionIDString) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + id.String() + `"`), nil
+}
+
+func (id *TransactionIDString) UnmarshalJSON(b []byte) error {
+	return (*TransactionID)(id).UnmarshalJSON(b)
+}
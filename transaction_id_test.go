@@ -0,0 +1,51 @@
+package linepay
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestTransactionID_JSON(t *testing.T) {
+
+	for _, body := range []string{
+		`{"transactionId":2019049910005496810}`,
+		`{"transactionId":"2019049910005496810"}`,
+	} {
+		info := PaymentsInfoResponse{}
+		if err := json.Unmarshal([]byte(body), &info); err != nil || info.TransactionID != 2019049910005496810 {
+			t.Errorf("Unmarshal(%s) = %d, %v", body, info.TransactionID, err)
+		}
+	}
+
+	for _, body := range []string{`"20190499x"`, `"2019049910005496810`, `2019049910005496810"`, `"2019049910005496810""`, `2019049910005496810.5`} {
+		info := PaymentsInfoResponse{}
+		if err := json.Unmarshal([]byte(`{"transactionId":`+body+`}`), &info); err == nil {
+			t.Errorf("Unmarshal(%s) no error", body)
+		}
+	}
+
+	refund := PaymentsRefundInfoResponse{RefundTransactionID: 2019049910005496811}
+	if b, _ := json.Marshal(refund.RefundTransactionID); string(b) != `2019049910005496811` {
+		t.Errorf("Marshal() = %s", b)
+	}
+
+	b, _ := json.Marshal(map[TransactionID]TransactionIDString{1: 2019049910005496811})
+	if string(b) != `{"1":"2019049910005496811"}` {
+		t.Errorf("Marshal() as string = %s", b)
+	}
+	var order struct {
+		TransactionID TransactionIDString `json:"transactionId"`
+	}
+	if err := json.Unmarshal([]byte(`{"transactionId":2019049910005496811}`), &order); err != nil || order.TransactionID != 2019049910005496811 {
+		t.Errorf("Unmarshal() as string = %d, %v", order.TransactionID, err)
+	}
+}
+
+func TestParseTransactionID(t *testing.T) {
+	if id, err := ParseTransactionID("2019049910005496810"); err != nil || id.String() != "2019049910005496810" || id.Int64() != 2019049910005496810 {
+		t.Errorf("ParseTransactionID() = %v, %v", id, err)
+	}
+	if _, err := ParseTransactionID(""); err == nil {
+		t.Errorf("ParseTransactionID(\"\") no error")
+	}
+}
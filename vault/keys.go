@@ -0,0 +1,105 @@
+package vault
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+)
+
+// ErrUnknownKey returned by KeyProvider when the key id does not exist (e.g. retired after a rotation)
+var ErrUnknownKey = errors.New("vault: unknown key")
+
+// Key is an AES key, `Material` must be 16, 24 or 32 bytes.
+// `ID` is stored with every sealed secret and must not contain ':'.
+type Key struct {
+	ID       string
+	Material []byte
+}
+
+// KeyProvider supplies the keys of a Vault, implement it to load keys from a KMS or secret manager.
+type KeyProvider interface {
+	// CurrentKey returns the key new secrets are sealed with
+	CurrentKey(ctx context.Context) (Key, error)
+	// Key returns the key with the id, to open secrets sealed before a rotation
+	Key(ctx context.Context, id string) (Key, error)
+}
+
+// StaticKeys is an in-memory KeyProvider
+type StaticKeys struct {
+	mu      sync.RWMutex
+	current string
+	keys    map[string]Key
+}
+
+// NewStaticKeys creates a KeyProvider sealing with `current`, `old` keys are only used to open.
+func NewStaticKeys(current Key, old ...Key) (*StaticKeys, error) {
+	s := &StaticKeys{keys: map[string]Key{}}
+
+	for _, k := range old {
+		if err := s.add(k); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.Rotate(current); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *StaticKeys) add(k Key) error {
+	if k.ID == "" || strings.Contains(k.ID, ":") {
+		return errors.New("vault: invalid key id")
+	}
+	switch len(k.Material) {
+	case 16, 24, 32:
+	default:
+		return errors.New("vault: key must be 16, 24 or 32 bytes")
+	}
+
+	s.keys[k.ID] = k
+	return nil
+}
+
+// Rotate makes `k` the current key, the previous keys are kept to open existing secrets.
+func (s *StaticKeys) Rotate(k Key) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.add(k); err != nil {
+		return err
+	}
+	s.current = k.ID
+	return nil
+}
+
+// Retire removes a key which is not current, secrets still sealed with it can not be opened anymore.
+func (s *StaticKeys) Retire(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if id == s.current {
+		return errors.New("vault: can not retire the current key")
+	}
+	delete(s.keys, id)
+	return nil
+}
+
+func (s *StaticKeys) CurrentKey(ctx context.Context) (Key, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.keys[s.current], nil
+}
+
+func (s *StaticKeys) Key(ctx context.Context, id string) (Key, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	k, ok := s.keys[id]
+	if !ok {
+		return Key{}, ErrUnknownKey
+	}
+	return k, nil
+}
@@ -0,0 +1,147 @@
+package vault
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	linepay "github.com/chy168/line-pay-sdk-go"
+)
+
+// sealed format: `lpv1:<key id>:<base64url(nonce + ciphertext)>`, the key id is authenticated as additional data
+const sealedPrefix = "lpv1"
+
+var (
+	ErrMalformed = errors.New("vault: malformed sealed secret")
+	ErrDecrypt   = errors.New("vault: decrypt failed")
+)
+
+// Vault seals secrets with AES-GCM under the keys of a KeyProvider, it implements `linepay.Vault`.
+type Vault struct {
+	keys KeyProvider
+}
+
+var _ linepay.Vault = (*Vault)(nil)
+
+func New(keys KeyProvider) *Vault {
+	return &Vault{keys: keys}
+}
+
+// Seal encrypts the secret with the current key
+func (v *Vault) Seal(ctx context.Context, secret linepay.SecretString) (linepay.Sealed, error) {
+
+	key, err := v.keys.CurrentKey(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+
+	ct := aead.Seal(nonce, nonce, []byte(secret.Reveal()), []byte(key.ID))
+
+	return linepay.Sealed(sealedPrefix + ":" + key.ID + ":" + base64.RawURLEncoding.EncodeToString(ct)), nil
+}
+
+// Open decrypts the secret with the key it was sealed with
+func (v *Vault) Open(ctx context.Context, sealed linepay.Sealed) (linepay.SecretString, error) {
+
+	keyID, data, err := parse(sealed)
+	if err != nil {
+		return "", err
+	}
+
+	key, err := v.keys.Key(ctx, keyID)
+	if err != nil {
+		return "", err
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return "", err
+	}
+
+	if len(data) < aead.NonceSize() {
+		return "", ErrMalformed
+	}
+
+	nonce, ct := data[:aead.NonceSize()], data[aead.NonceSize():]
+	pt, err := aead.Open(nil, nonce, ct, []byte(keyID))
+	if err != nil {
+		return "", ErrDecrypt
+	}
+
+	return linepay.SecretString(pt), nil
+}
+
+// Reseal re-encrypts the secret with the current key, `changed` is false when it is already sealed with the current key.
+// Run it over the stored secrets after `StaticKeys.Rotate`, then the old key can be retired.
+func (v *Vault) Reseal(ctx context.Context, sealed linepay.Sealed) (resealed linepay.Sealed, changed bool, err error) {
+
+	keyID, _, err := parse(sealed)
+	if err != nil {
+		return
+	}
+
+	current, err := v.keys.CurrentKey(ctx)
+	if err != nil {
+		return
+	}
+	if current.ID == keyID {
+		return sealed, false, nil
+	}
+
+	secret, err := v.Open(ctx, sealed)
+	if err != nil {
+		return
+	}
+
+	resealed, err = v.Seal(ctx, secret)
+	return resealed, err == nil, err
+}
+
+// KeyID returns the id of the key the secret was sealed with
+func KeyID(sealed linepay.Sealed) (string, error) {
+	id, _, err := parse(sealed)
+	return id, err
+}
+
+func parse(sealed linepay.Sealed) (keyID string, data []byte, err error) {
+
+	parts := strings.SplitN(string(sealed), ":", 3)
+	if len(parts) != 3 || parts[0] != sealedPrefix || parts[1] == "" {
+		err = ErrMalformed
+		return
+	}
+
+	data, err = base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		err = ErrMalformed
+		return
+	}
+
+	return parts[1], data, nil
+}
+
+func newAEAD(key Key) (cipher.AEAD, error) {
+
+	block, err := aes.NewCipher(key.Material)
+	if err != nil {
+		return nil, fmt.Errorf("vault: key '%s': %s", key.ID, err.Error())
+	}
+
+	return cipher.NewGCM(block)
+}
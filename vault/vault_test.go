@@ -0,0 +1,74 @@
+package vault
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	linepay "github.com/chy168/line-pay-sdk-go"
+)
+
+func TestVault_SealOpen(t *testing.T) {
+	ctx := context.Background()
+	keys, err := NewStaticKeys(Key{ID: "k1", Material: bytes.Repeat([]byte{1}, 32)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := New(keys)
+
+	sealed, err := v.Seal(ctx, "RK0123456789")
+	if err != nil {
+		t.Fatalf("Seal() error = %v", err)
+	}
+	if strings.Contains(string(sealed), "RK0123456789") || !strings.HasPrefix(string(sealed), "lpv1:k1:") {
+		t.Errorf("unexpected sealed value '%s'", sealed)
+	}
+
+	secret, err := v.Open(ctx, sealed)
+	if err != nil || secret.Reveal() != "RK0123456789" {
+		t.Errorf("Open() = '%s', %v", secret.Reveal(), err)
+	}
+
+	// tampered key id must not be accepted
+	if err := keys.Rotate(Key{ID: "k2", Material: bytes.Repeat([]byte{1}, 32)}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := v.Open(ctx, linepay.Sealed(strings.Replace(string(sealed), ":k1:", ":k2:", 1))); err != ErrDecrypt {
+		t.Errorf("Open() tampered error = %v, want %v", err, ErrDecrypt)
+	}
+}
+
+func TestVault_Reseal(t *testing.T) {
+	ctx := context.Background()
+	keys, _ := NewStaticKeys(Key{ID: "k1", Material: bytes.Repeat([]byte{1}, 16)})
+	v := New(keys)
+
+	sealed, _ := v.Seal(ctx, "token")
+
+	if err := keys.Rotate(Key{ID: "k2", Material: bytes.Repeat([]byte{2}, 32)}); err != nil {
+		t.Fatal(err)
+	}
+
+	resealed, changed, err := v.Reseal(ctx, sealed)
+	if err != nil || !changed {
+		t.Fatalf("Reseal() = %v, %v", changed, err)
+	}
+	if id, _ := KeyID(resealed); id != "k2" {
+		t.Errorf("resealed with key '%s', want 'k2'", id)
+	}
+
+	if err := keys.Retire("k1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := v.Open(ctx, sealed); err != ErrUnknownKey {
+		t.Errorf("Open() with retired key error = %v", err)
+	}
+	if secret, err := v.Open(ctx, resealed); err != nil || secret.Reveal() != "token" {
+		t.Errorf("Open() resealed = '%s', %v", secret.Reveal(), err)
+	}
+
+	if _, changed, _ := v.Reseal(ctx, resealed); changed {
+		t.Error("Reseal() of a current secret should not change it")
+	}
+}
@@ -0,0 +1,454 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	linepay "github.com/chy168/line-pay-sdk-go"
+	"github.com/sirupsen/logrus"
+)
+
+// Endpoint of a downstream service.
+// `Name` identifies the deliveries of the endpoint in the Queue, it must not change while deliveries are queued.
+// `Secret` signs the requests, your own secret shared with the service, not the channel secret.
+// `Types` optional, the events sent to the endpoint, every event when empty
+type Endpoint struct {
+	Name   string
+	URL    string
+	Secret string
+	Types  []linepay.EventType
+}
+
+func (e *Endpoint) wants(t linepay.EventType) bool {
+	if len(e.Types) == 0 {
+		return true
+	}
+	for _, w := range e.Types {
+		if w == t {
+			return true
+		}
+	}
+	return false
+}
+
+// Defaults of Options
+const (
+	DefaultMaxAttempts = 10
+	DefaultBackoff     = 30 * time.Second
+	DefaultMaxBackoff  = time.Hour
+	DefaultInterval    = time.Second
+	DefaultTimeout     = 10 * time.Second
+	DefaultConcurrency = 4
+	DefaultRetention   = 7 * 24 * time.Hour
+)
+
+// deliverBatch is the number of due deliveries Deliver reads from the Queue at a time
+const deliverBatch = 100
+
+// defaultClient posts when no client is given, http.DefaultClient has no timeout
+var defaultClient = &http.Client{Timeout: DefaultTimeout}
+
+// Options of a Dispatcher, every field is optional.
+// `MaxAttempts` attempts of a delivery before it is marked StatusFailed, DefaultMaxAttempts when 0
+// `Backoff` the delay before the second attempt, doubled for every attempt up to `MaxBackoff`
+// `Interval` how often Run looks for deliveries due, DefaultInterval when 0
+// `Timeout` limits an attempt, DefaultTimeout when 0
+// `Concurrency` endpoints delivered at the same time, DefaultConcurrency when 0. The deliveries of an endpoint are
+// sent one at a time.
+// `Retention` how long a StatusFailed delivery is kept for Replay, DefaultRetention when 0
+// `Client` a client with DefaultTimeout when nil
+type Options struct {
+	MaxAttempts int
+	Backoff     time.Duration
+	MaxBackoff  time.Duration
+	Interval    time.Duration
+	Timeout     time.Duration
+	Concurrency int
+	Retention   time.Duration
+	Client      *http.Client
+}
+
+// Dispatcher sends the events to the registered endpoints through a persistent Queue, a failed request is sent
+// again with an exponential backoff. Set it as `ClientOpts.Events`, or subscribe it to an `events.Bus`,
+// and start Run in a goroutine.
+type Dispatcher struct {
+	queue Queue
+	opts  Options
+
+	mu        sync.RWMutex
+	endpoints map[string]*Endpoint
+
+	deliver sync.Mutex // a delivery is updated by one Deliver or Replay at a time
+	wake    chan struct{}
+}
+
+var _ linepay.Publisher = (*Dispatcher)(nil)
+
+// New returns a Dispatcher, `opts` may be nil
+func New(queue Queue, opts *Options) *Dispatcher {
+
+	if opts == nil {
+		opts = &Options{}
+	}
+	d := &Dispatcher{queue: queue, opts: *opts, endpoints: map[string]*Endpoint{}, wake: make(chan struct{}, 1)}
+	if d.opts.MaxAttempts <= 0 {
+		d.opts.MaxAttempts = DefaultMaxAttempts
+	}
+	if d.opts.Backoff <= 0 {
+		d.opts.Backoff = DefaultBackoff
+	}
+	if d.opts.MaxBackoff <= 0 {
+		d.opts.MaxBackoff = DefaultMaxBackoff
+	}
+	if d.opts.Interval <= 0 {
+		d.opts.Interval = DefaultInterval
+	}
+	if d.opts.Timeout <= 0 {
+		d.opts.Timeout = DefaultTimeout
+	}
+	if d.opts.Concurrency <= 0 {
+		d.opts.Concurrency = DefaultConcurrency
+	}
+	if d.opts.Retention <= 0 {
+		d.opts.Retention = DefaultRetention
+	}
+	if d.opts.Client == nil {
+		d.opts.Client = defaultClient
+	}
+	return d
+}
+
+// Register adds the endpoint, or replaces the one of the same name
+func (d *Dispatcher) Register(endpoint Endpoint) error {
+
+	if endpoint.Name == "" || endpoint.URL == "" {
+		return errors.New("webhook endpoint requires a Name and an URL")
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.endpoints[endpoint.Name] = &endpoint
+	return nil
+}
+
+// Unregister removes the endpoint, its queued deliveries fail at their next attempt
+func (d *Dispatcher) Unregister(name string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.endpoints, name)
+}
+
+func (d *Dispatcher) endpoint(name string) *Endpoint {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.endpoints[name]
+}
+
+// Publish queues the event, an error is logged
+func (d *Dispatcher) Publish(ctx context.Context, event linepay.Event) {
+	if err := d.Handle(ctx, event); err != nil {
+		logrus.Errorf("webhook event '%s' not queued: %s", event.Header().ID, err.Error())
+	}
+}
+
+// Handle queues a delivery of the event for every endpoint which wants it, an event already queued for an endpoint
+// is not queued twice. It makes the Dispatcher an `events.Handler`.
+func (d *Dispatcher) Handle(ctx context.Context, event linepay.Event) error {
+
+	payload, err := NewPayload(event)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("webhook marshal payload error: %s", err.Error())
+	}
+
+	d.mu.RLock()
+	var names []string
+	for name, endpoint := range d.endpoints {
+		if endpoint.wants(payload.Type) {
+			names = append(names, name)
+		}
+	}
+	d.mu.RUnlock()
+
+	now := time.Now()
+	for _, name := range names {
+		err := d.queue.Add(ctx, &Delivery{
+			ID:          name + "/" + payload.ID,
+			Endpoint:    name,
+			EventID:     payload.ID,
+			EventType:   payload.Type,
+			Body:        body,
+			Status:      StatusPending,
+			NextAttempt: now,
+			CreatedAt:   now,
+		})
+		if err != nil && err != ErrDuplicate {
+			return fmt.Errorf("webhook queue error: %s", err.Error())
+		}
+	}
+
+	if len(names) > 0 {
+		d.notify()
+	}
+	return nil
+}
+
+func (d *Dispatcher) notify() {
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run delivers the queued events until `ctx` is done, then returns its error.
+// The deliveries left by a previous process are sent first.
+func (d *Dispatcher) Run(ctx context.Context) error {
+
+	ticker := time.NewTicker(d.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := d.Deliver(ctx); err != nil && ctx.Err() == nil {
+			logrus.Errorf("webhook deliver error: %s", err.Error())
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		case <-d.wake:
+		}
+	}
+}
+
+// Deliver sends the pending deliveries due now and returns the number delivered, then drops the deliveries failed
+// for longer than `Options.Retention`. Run calls it, call it directly to deliver from a cron job.
+func (d *Dispatcher) Deliver(ctx context.Context) (delivered int, err error) {
+
+	d.deliver.Lock()
+	defer d.deliver.Unlock()
+
+	now := time.Now()
+	for {
+		deliveries, err := d.queue.Due(ctx, now, deliverBatch)
+		if err != nil {
+			return delivered, fmt.Errorf("webhook queue error: %s", err.Error())
+		}
+		if len(deliveries) == 0 {
+			break
+		}
+
+		n, err := d.deliverAll(ctx, deliveries)
+		delivered += n
+		if err != nil {
+			return delivered, err
+		}
+		if ctx.Err() != nil {
+			return delivered, ctx.Err()
+		}
+	}
+
+	pruned, err := d.queue.Prune(ctx, now.Add(-d.opts.Retention))
+	if err != nil {
+		return delivered, fmt.Errorf("webhook queue error: %s", err.Error())
+	}
+	if pruned > 0 {
+		logrus.Infof("webhook pruned %d failed deliveries", pruned)
+	}
+	return
+}
+
+// deliverAll sends `deliveries` in order for every endpoint, `Options.Concurrency` endpoints at a time
+func (d *Dispatcher) deliverAll(ctx context.Context, deliveries []*Delivery) (delivered int, err error) {
+
+	var endpoints []string
+	byEndpoint := map[string][]*Delivery{}
+	for _, delivery := range deliveries {
+		if _, ok := byEndpoint[delivery.Endpoint]; !ok {
+			endpoints = append(endpoints, delivery.Endpoint)
+		}
+		byEndpoint[delivery.Endpoint] = append(byEndpoint[delivery.Endpoint], delivery)
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, d.opts.Concurrency)
+	for _, name := range endpoints {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(deliveries []*Delivery) {
+			defer func() { <-slots; wg.Done() }()
+
+			for _, delivery := range deliveries {
+				if ctx.Err() != nil {
+					return
+				}
+				ok, qerr := d.attempt(ctx, delivery)
+
+				mu.Lock()
+				if ok {
+					delivered++
+				}
+				if qerr != nil && err == nil {
+					err = qerr
+				}
+				mu.Unlock()
+				if qerr != nil {
+					return
+				}
+			}
+		}(byEndpoint[name])
+	}
+	wg.Wait()
+	return
+}
+
+// attempt sends `delivery` and updates the queue, `err` is an error of the queue
+func (d *Dispatcher) attempt(ctx context.Context, delivery *Delivery) (delivered bool, err error) {
+
+	sendErr := d.send(ctx, delivery)
+	if sendErr == nil {
+		if err := d.queue.Remove(ctx, delivery.ID); err != nil {
+			return true, fmt.Errorf("webhook queue error: %s", err.Error())
+		}
+		return true, nil
+	}
+
+	delivery.Attempts++
+	delivery.LastError = sendErr.Error()
+	if delivery.Attempts >= d.opts.MaxAttempts {
+		delivery.Status = StatusFailed
+		delivery.FailedAt = time.Now()
+		logrus.Warnf("webhook delivery '%s' failed after %d attempts: %s", delivery.ID, delivery.Attempts, sendErr.Error())
+	} else {
+		delivery.NextAttempt = time.Now().Add(d.backoff(delivery.Attempts))
+	}
+	if err := d.queue.Update(ctx, delivery); err != nil {
+		return false, fmt.Errorf("webhook queue error: %s", err.Error())
+	}
+	return false, nil
+}
+
+// backoff after the attempt `attempt`
+func (d *Dispatcher) backoff(attempt int) time.Duration {
+	backoff := d.opts.Backoff
+	for i := 1; i < attempt && backoff < d.opts.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > d.opts.MaxBackoff {
+		backoff = d.opts.MaxBackoff
+	}
+	return backoff
+}
+
+func (d *Dispatcher) send(ctx context.Context, delivery *Delivery) error {
+
+	endpoint := d.endpoint(delivery.Endpoint)
+	if endpoint == nil {
+		return fmt.Errorf("webhook endpoint '%s' not registered", delivery.Endpoint)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.opts.Timeout)
+	defer cancel()
+	return Post(ctx, d.opts.Client, endpoint.URL, endpoint.Secret, delivery)
+}
+
+// Post sends an attempt of `delivery` to `url`, signed with `secret`: the request of the Dispatcher, also sent by
+// `events.Webhook`. A response other than 2xx is an error. `client` may be nil for a client with DefaultTimeout.
+func Post(ctx context.Context, client *http.Client, url, secret string, delivery *Delivery) error {
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(delivery.Body))
+	if err != nil {
+		return err
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderDeliveryID, delivery.ID)
+	req.Header.Set(HeaderEventType, string(delivery.EventType))
+	req.Header.Set(HeaderAttempt, strconv.Itoa(delivery.Attempts+1))
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, Sign(secret, timestamp, delivery.Body))
+
+	if client == nil {
+		client = defaultClient
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook post error: %s", err.Error())
+	}
+	defer res.Body.Close()
+	io.Copy(ioutil.Discard, res.Body)
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("webhook failed response, StatusCode: %d", res.StatusCode)
+	}
+	return nil
+}
+
+// Failed returns the deliveries which exhausted their attempts, the oldest first
+func (d *Dispatcher) Failed(ctx context.Context) (failed []*Delivery, err error) {
+
+	deliveries, err := d.queue.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("webhook queue error: %s", err.Error())
+	}
+	for _, delivery := range deliveries {
+		if delivery.Status == StatusFailed {
+			failed = append(failed, delivery)
+		}
+	}
+	return
+}
+
+// Replay queues the failed deliveries again with their attempts reset, every failed delivery when no `ids` are given.
+// It returns the number of deliveries replayed, a delivery which is not failed is an error.
+func (d *Dispatcher) Replay(ctx context.Context, ids ...string) (replayed int, err error) {
+
+	d.deliver.Lock()
+	defer d.deliver.Unlock()
+
+	var deliveries []*Delivery
+	if len(ids) == 0 {
+		if deliveries, err = d.Failed(ctx); err != nil {
+			return 0, err
+		}
+	}
+	for _, id := range ids {
+		delivery, err := d.queue.Get(ctx, id)
+		if err != nil {
+			return 0, fmt.Errorf("webhook delivery '%s': %s", id, err.Error())
+		}
+		if delivery.Status != StatusFailed {
+			return 0, fmt.Errorf("webhook delivery '%s' is not failed", id)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	for _, delivery := range deliveries {
+		delivery.Status = StatusPending
+		delivery.Attempts = 0
+		delivery.NextAttempt = time.Now()
+		delivery.FailedAt = time.Time{}
+		if err = d.queue.Update(ctx, delivery); err != nil {
+			return replayed, fmt.Errorf("webhook queue error: %s", err.Error())
+		}
+		replayed++
+	}
+
+	if replayed > 0 {
+		d.notify()
+	}
+	return
+}
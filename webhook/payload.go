@@ -0,0 +1,145 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	linepay "github.com/chy168/line-pay-sdk-go"
+)
+
+// Headers of the requests of the Dispatcher
+const (
+	HeaderDeliveryID = "X-LinePay-Webhook-Id"
+	HeaderEventType  = "X-LinePay-Webhook-Event"
+	HeaderAttempt    = "X-LinePay-Webhook-Attempt"
+	HeaderTimestamp  = "X-LinePay-Webhook-Timestamp"
+	HeaderSignature  = "X-LinePay-Webhook-Signature"
+)
+
+// DefaultTolerance is the age of a request accepted by Verify when `tolerance` is 0
+const DefaultTolerance = 5 * time.Minute
+
+// Payload is the body posted to the endpoints: the header of the event, the event itself and the response of the
+// API call which published it. Only the response of the event type is set:
+// `Confirm` payment.confirmed (nil for a preapproved payment), `Capture` payment.captured,
+// `Refund` payment.refunded, `Details` payment.expired (the expired transaction).
+// Secrets like regKeys are redacted, the shipping address of a confirm is sent as is.
+type Payload struct {
+	ID         string            `json:"id"`
+	Type       linepay.EventType `json:"type"`
+	ChannelID  string            `json:"channelId"`
+	OccurredAt time.Time         `json:"occurredAt"`
+	Event      json.RawMessage   `json:"event"`
+
+	Confirm *linepay.PaymentsConfirmResponse     `json:"confirm,omitempty"`
+	Capture *linepay.PaymentsCaptureResponse     `json:"capture,omitempty"`
+	Refund  *linepay.PaymentsRefundResponse      `json:"refund,omitempty"`
+	Details *linepay.PaymentsDetailsInfoResponse `json:"details,omitempty"`
+}
+
+// NewPayload wraps the event and the response it keeps
+func NewPayload(event linepay.Event) (payload *Payload, err error) {
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("webhook marshal event error: %s", err.Error())
+	}
+
+	h := event.Header()
+	payload = &Payload{ID: h.ID, Type: h.Type, ChannelID: h.ChannelID, OccurredAt: h.OccurredAt, Event: body}
+
+	switch e := event.(type) {
+	case *linepay.PaymentConfirmed:
+		payload.Confirm = e.Response
+	case *linepay.PaymentCaptured:
+		payload.Capture = e.Response
+	case *linepay.PaymentRefunded:
+		payload.Refund = e.Response
+	case *linepay.PaymentExpired:
+		payload.Details = e.Info
+	}
+	return
+}
+
+// DecodeEvent returns the `Payment*` event struct of the payload type, without its response
+func (p *Payload) DecodeEvent() (event linepay.Event, err error) {
+
+	switch p.Type {
+	case linepay.EventPaymentRequested:
+		event = &linepay.PaymentRequested{}
+	case linepay.EventPaymentConfirmed:
+		event = &linepay.PaymentConfirmed{}
+	case linepay.EventPaymentCaptured:
+		event = &linepay.PaymentCaptured{}
+	case linepay.EventPaymentVoided:
+		event = &linepay.PaymentVoided{}
+	case linepay.EventPaymentRefunded:
+		event = &linepay.PaymentRefunded{}
+	case linepay.EventPaymentExpired:
+		event = &linepay.PaymentExpired{}
+	default:
+		return nil, fmt.Errorf("webhook unknown event type '%s'", p.Type)
+	}
+
+	if err = json.Unmarshal(p.Event, event); err != nil {
+		return nil, fmt.Errorf("webhook unmarshal event error: %s", err.Error())
+	}
+	return
+}
+
+// Sign returns `Base64(HMAC-SHA256(secret, timestamp + "." + body))`, `timestamp` is the HeaderTimestamp value
+func Sign(secret string, timestamp string, body []byte) string {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(timestamp + "."))
+	h.Write(body)
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+// VerifySignature checks HeaderSignature, the age of the timestamp is checked by Verify
+func VerifySignature(secret string, timestamp string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
+
+// ErrInvalidSignature returned by Verify when the signature does not match or the request is too old
+var ErrInvalidSignature = errors.New("webhook invalid signature")
+
+// Verify is used by the receiver: it checks the signature of the request and its timestamp, no older or newer
+// than `tolerance` (DefaultTolerance when 0), then decodes the payload. The same delivery may be received again,
+// use `Payload.ID` (or HeaderDeliveryID) to ignore duplicates.
+func Verify(r *http.Request, secret string, tolerance time.Duration) (payload *Payload, err error) {
+
+	if tolerance <= 0 {
+		tolerance = DefaultTolerance
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, fmt.Errorf("webhook read body error: %s", err.Error())
+	}
+
+	timestamp := r.Header.Get(HeaderTimestamp)
+	sec, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return nil, ErrInvalidSignature
+	}
+	if age := time.Since(time.Unix(sec, 0)); age > tolerance || age < -tolerance {
+		return nil, ErrInvalidSignature
+	}
+	if !VerifySignature(secret, timestamp, body, r.Header.Get(HeaderSignature)) {
+		return nil, ErrInvalidSignature
+	}
+
+	payload = &Payload{}
+	if err = json.Unmarshal(body, payload); err != nil {
+		return nil, fmt.Errorf("webhook unmarshal payload error: %s", err.Error())
+	}
+	return
+}
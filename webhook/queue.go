@@ -0,0 +1,347 @@
+package webhook
+
+import (
+	"container/heap"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	linepay "github.com/chy168/line-pay-sdk-go"
+)
+
+var (
+	// ErrDuplicate returned by Queue when a delivery of the same ID is queued
+	ErrDuplicate = errors.New("webhook: duplicate delivery")
+
+	// ErrNotFound returned by Queue when the delivery does not exist
+	ErrNotFound = errors.New("webhook: delivery not found")
+)
+
+// Status of a queued delivery, a delivered one is removed from the queue
+type Status string
+
+const (
+	StatusPending Status = "PENDING" // waiting for its `NextAttempt`
+	StatusFailed  Status = "FAILED"  // every attempt failed, delivered again by Replay
+)
+
+// Delivery of an event to an endpoint. `Body` is the JSON Payload, signed again at every attempt.
+type Delivery struct {
+	ID          string            `json:"id"`
+	Endpoint    string            `json:"endpoint"`
+	EventID     string            `json:"eventId"`
+	EventType   linepay.EventType `json:"eventType"`
+	Body        json.RawMessage   `json:"body"`
+	Status      Status            `json:"status"`
+	Attempts    int               `json:"attempts"`
+	NextAttempt time.Time         `json:"nextAttempt"`
+	LastError   string            `json:"lastError,omitempty"`
+	CreatedAt   time.Time         `json:"createdAt"`
+	FailedAt    time.Time         `json:"failedAt"`
+}
+
+// Queue persists the deliveries until they succeed. Implementations must be safe for concurrent use.
+type Queue interface {
+	// Add queues a new delivery, ErrDuplicate when its ID is queued
+	Add(ctx context.Context, delivery *Delivery) error
+	Update(ctx context.Context, delivery *Delivery) error
+	Remove(ctx context.Context, id string) error
+	Get(ctx context.Context, id string) (*Delivery, error)
+	// List returns every queued delivery, the oldest first
+	List(ctx context.Context) ([]*Delivery, error)
+	// Due returns at most `limit` pending deliveries whose NextAttempt is not after `now`, the earliest first
+	Due(ctx context.Context, now time.Time, limit int) ([]*Delivery, error)
+	// Prune removes the deliveries failed before `before`, and returns their number
+	Prune(ctx context.Context, before time.Time) (int, error)
+}
+
+// FileQueue is a Queue keeping a JSON file per delivery in a directory, deliveries survive a restart.
+// A file is replaced atomically, the directory must not be shared by several processes.
+// The status and next attempt of the deliveries are indexed in memory, Due and Prune read no other file.
+type FileQueue struct {
+	mu      sync.Mutex
+	dir     string
+	pending pendingIndex
+	failed  map[string]time.Time // FailedAt of the failed deliveries
+}
+
+// OpenFileQueue uses the directory `dir`, created when it does not exist, and indexes its deliveries
+func OpenFileQueue(dir string) (*FileQueue, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+
+	q := &FileQueue{dir: dir, pending: pendingIndex{byID: map[string]*pendingEntry{}}, failed: map[string]time.Time{}}
+	deliveries, err := q.list()
+	if err != nil {
+		return nil, err
+	}
+	for _, delivery := range deliveries {
+		q.index(delivery)
+	}
+	return q, nil
+}
+
+// index the status of `delivery`
+func (q *FileQueue) index(delivery *Delivery) {
+	switch delivery.Status {
+	case StatusPending:
+		delete(q.failed, delivery.ID)
+		q.pending.set(delivery.ID, delivery.NextAttempt)
+	case StatusFailed:
+		q.pending.remove(delivery.ID)
+		failedAt := delivery.FailedAt
+		if failedAt.IsZero() {
+			failedAt = time.Now()
+		}
+		q.failed[delivery.ID] = failedAt
+	}
+}
+
+func (q *FileQueue) unindex(id string) {
+	q.pending.remove(id)
+	delete(q.failed, id)
+}
+
+func (q *FileQueue) path(id string) string {
+	return filepath.Join(q.dir, base64.RawURLEncoding.EncodeToString([]byte(id))+".json")
+}
+
+func (q *FileQueue) write(delivery *Delivery) error {
+
+	body, err := json.Marshal(delivery)
+	if err != nil {
+		return err
+	}
+
+	f, err := ioutil.TempFile(q.dir, ".tmp-")
+	if err != nil {
+		return err
+	}
+	if _, err = f.Write(body); err == nil {
+		err = f.Sync()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), q.path(delivery.ID))
+}
+
+func (q *FileQueue) read(path string) (*Delivery, error) {
+
+	body, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	delivery := &Delivery{}
+	if err := json.Unmarshal(body, delivery); err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+func (q *FileQueue) Add(ctx context.Context, delivery *Delivery) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if _, err := os.Stat(q.path(delivery.ID)); err == nil {
+		return ErrDuplicate
+	}
+	if err := q.write(delivery); err != nil {
+		return err
+	}
+	q.index(delivery)
+	return nil
+}
+
+func (q *FileQueue) Update(ctx context.Context, delivery *Delivery) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if _, err := os.Stat(q.path(delivery.ID)); os.IsNotExist(err) {
+		return ErrNotFound
+	}
+	if err := q.write(delivery); err != nil {
+		return err
+	}
+	q.index(delivery)
+	return nil
+}
+
+func (q *FileQueue) Remove(ctx context.Context, id string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.remove(id)
+}
+
+func (q *FileQueue) remove(id string) error {
+	err := os.Remove(q.path(id))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	q.unindex(id)
+	return nil
+}
+
+func (q *FileQueue) Get(ctx context.Context, id string) (*Delivery, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.read(q.path(id))
+}
+
+func (q *FileQueue) List(ctx context.Context) ([]*Delivery, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.list()
+}
+
+func (q *FileQueue) list() ([]*Delivery, error) {
+
+	files, err := ioutil.ReadDir(q.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var deliveries []*Delivery
+	for _, f := range files {
+		if f.IsDir() || strings.HasPrefix(f.Name(), ".") || !strings.HasSuffix(f.Name(), ".json") {
+			continue
+		}
+		delivery, err := q.read(filepath.Join(q.dir, f.Name()))
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	sort.SliceStable(deliveries, func(i, j int) bool {
+		if !deliveries[i].CreatedAt.Equal(deliveries[j].CreatedAt) {
+			return deliveries[i].CreatedAt.Before(deliveries[j].CreatedAt)
+		}
+		return deliveries[i].ID < deliveries[j].ID
+	})
+	return deliveries, nil
+}
+
+func (q *FileQueue) Due(ctx context.Context, now time.Time, limit int) ([]*Delivery, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	var deliveries []*Delivery
+	for _, id := range q.pending.due(now, limit) {
+		delivery, err := q.read(q.path(id))
+		if err == ErrNotFound {
+			q.unindex(id)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, nil
+}
+
+func (q *FileQueue) Prune(ctx context.Context, before time.Time) (pruned int, err error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for id, failedAt := range q.failed {
+		if !failedAt.Before(before) {
+			continue
+		}
+		if err = q.remove(id); err != nil {
+			return
+		}
+		pruned++
+	}
+	return
+}
+
+// pendingIndex is a heap of the pending deliveries by next attempt
+type pendingIndex struct {
+	entries []*pendingEntry
+	byID    map[string]*pendingEntry
+}
+
+type pendingEntry struct {
+	id    string
+	next  time.Time
+	index int
+}
+
+func (p *pendingIndex) set(id string, next time.Time) {
+	if e, ok := p.byID[id]; ok {
+		e.next = next
+		heap.Fix(p, e.index)
+		return
+	}
+	e := &pendingEntry{id: id, next: next}
+	p.byID[id] = e
+	heap.Push(p, e)
+}
+
+func (p *pendingIndex) remove(id string) {
+	if e, ok := p.byID[id]; ok {
+		heap.Remove(p, e.index)
+		delete(p.byID, id)
+	}
+}
+
+// due returns the ids of at most `limit` entries not after `now`, the earliest first
+func (p *pendingIndex) due(now time.Time, limit int) (ids []string) {
+	var popped []*pendingEntry
+	for p.Len() > 0 && len(popped) < limit && !p.entries[0].next.After(now) {
+		popped = append(popped, heap.Pop(p).(*pendingEntry))
+	}
+	for _, e := range popped {
+		ids = append(ids, e.id)
+		heap.Push(p, e)
+	}
+	return
+}
+
+func (p *pendingIndex) Len() int { return len(p.entries) }
+
+func (p *pendingIndex) Less(i, j int) bool {
+	if !p.entries[i].next.Equal(p.entries[j].next) {
+		return p.entries[i].next.Before(p.entries[j].next)
+	}
+	return p.entries[i].id < p.entries[j].id
+}
+
+func (p *pendingIndex) Swap(i, j int) {
+	p.entries[i], p.entries[j] = p.entries[j], p.entries[i]
+	p.entries[i].index, p.entries[j].index = i, j
+}
+
+func (p *pendingIndex) Push(x interface{}) {
+	e := x.(*pendingEntry)
+	e.index = len(p.entries)
+	p.entries = append(p.entries, e)
+}
+
+func (p *pendingIndex) Pop() interface{} {
+	e := p.entries[len(p.entries)-1]
+	p.entries = p.entries[:len(p.entries)-1]
+	return e
+}
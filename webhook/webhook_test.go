@@ -0,0 +1,234 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	linepay "github.com/chy168/line-pay-sdk-go"
+)
+
+func captured(id linepay.TransactionID) *linepay.PaymentCaptured {
+	return &linepay.PaymentCaptured{
+		EventHeader:   linepay.EventHeader{ID: "payment.captured:" + id.String(), Type: linepay.EventPaymentCaptured, ChannelID: "1001"},
+		TransactionID: id,
+		Amount:        100,
+		Currency:      "TWD",
+		Response:      &linepay.PaymentsCaptureResponse{ReturnCode: "0000", ReturnMessage: "OK"},
+	}
+}
+
+func refunded(id linepay.TransactionID) *linepay.PaymentRefunded {
+	event := &linepay.PaymentRefunded{
+		EventHeader:         linepay.EventHeader{ID: "payment.refunded:" + (id + 1).String(), Type: linepay.EventPaymentRefunded},
+		TransactionID:       id,
+		RefundTransactionID: id + 1,
+		Response:            &linepay.PaymentsRefundResponse{ReturnCode: "0000"},
+	}
+	event.Response.Info.RefundTransactionID = id + 1
+	return event
+}
+
+type receiver struct {
+	mu       sync.Mutex
+	secret   string
+	fail     int // requests answered 500 before the receiver works
+	payloads []*Payload
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	payload, err := Verify(req, r.secret, 0)
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	if r.fail != 0 {
+		r.fail--
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	r.payloads = append(r.payloads, payload)
+}
+
+func TestDispatcher(t *testing.T) {
+
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	crm := &receiver{secret: "crm-secret", fail: 1}
+	crmServer := httptest.NewServer(crm)
+	defer crmServer.Close()
+	accounting := &receiver{secret: "accounting-secret", fail: 1000}
+	accountingServer := httptest.NewServer(accounting)
+	defer accountingServer.Close()
+
+	queue, err := OpenFileQueue(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := New(queue, &Options{MaxAttempts: 2, Backoff: time.Nanosecond})
+	d.Register(Endpoint{Name: "crm", URL: crmServer.URL, Secret: "crm-secret"})
+	d.Register(Endpoint{Name: "accounting", URL: accountingServer.URL, Secret: "accounting-secret", Types: []linepay.EventType{linepay.EventPaymentRefunded}})
+	if err := d.Register(Endpoint{Name: "no-url"}); err == nil {
+		t.Errorf("Register() without URL no error")
+	}
+
+	d.Publish(ctx, captured(2019049910005496810))
+	d.Publish(ctx, refunded(2019049910005496810))
+	d.Publish(ctx, captured(2019049910005496810)) // already queued
+
+	// crm fails the first request then receives both events, accounting fails twice
+	for i, want := range []int{1, 1, 0} {
+		if delivered, err := d.Deliver(ctx); err != nil || delivered != want {
+			t.Errorf("Deliver() #%d = %d, %v, want %d", i, delivered, err, want)
+		}
+	}
+	if len(crm.payloads) != 2 || len(accounting.payloads) != 0 {
+		t.Fatalf("crm got %d payloads, accounting %d", len(crm.payloads), len(accounting.payloads))
+	}
+
+	// the failed delivery is kept on disk
+	reopened, _ := OpenFileQueue(dir)
+	d = New(reopened, &Options{MaxAttempts: 2, Backoff: time.Nanosecond})
+	d.Register(Endpoint{Name: "accounting", URL: accountingServer.URL, Secret: "accounting-secret"})
+
+	failed, err := d.Failed(ctx)
+	if err != nil || len(failed) != 1 || failed[0].ID != "accounting/payment.refunded:2019049910005496811" || failed[0].Attempts != 2 ||
+		failed[0].LastError != "webhook failed response, StatusCode: 500" {
+		t.Fatalf("Failed() = %+v, %v", failed, err)
+	}
+	if _, err := d.Replay(ctx, "accounting/unknown"); err == nil {
+		t.Errorf("Replay() of an unknown delivery no error")
+	}
+
+	accounting.fail = 0
+	if replayed, err := d.Replay(ctx); err != nil || replayed != 1 {
+		t.Errorf("Replay() = %d, %v", replayed, err)
+	}
+	if delivered, err := d.Deliver(ctx); err != nil || delivered != 1 {
+		t.Errorf("Deliver() after replay = %d, %v", delivered, err)
+	}
+	if left, _ := reopened.List(ctx); len(left) != 0 {
+		t.Errorf("queue not empty: %+v", left)
+	}
+
+	got := crm.payloads[1] // the refund was received first, the capture failed once
+	if got.ID != "payment.captured:2019049910005496810" || got.ChannelID != "1001" || got.Capture == nil || got.Capture.ReturnCode != "0000" || got.Refund != nil {
+		t.Errorf("crm payload = %+v", got)
+	}
+	event, err := got.DecodeEvent()
+	if c, ok := event.(*linepay.PaymentCaptured); err != nil || !ok || c.TransactionID != 2019049910005496810 || c.Amount != 100 {
+		t.Errorf("DecodeEvent() = %+v, %v", event, err)
+	}
+	if got := accounting.payloads[0]; got.Refund == nil || got.Refund.Info.RefundTransactionID != 2019049910005496811 {
+		t.Errorf("accounting payload = %+v", got)
+	}
+}
+
+func TestDispatcherTimeout(t *testing.T) {
+
+	ctx := context.Background()
+	hang := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-hang:
+		}
+	}))
+	defer server.Close()
+	defer close(hang)
+
+	queue, _ := OpenFileQueue(t.TempDir())
+	d := New(queue, &Options{Timeout: 20 * time.Millisecond})
+	d.Register(Endpoint{Name: "crm", URL: server.URL, Secret: "crm-secret"})
+	d.Publish(ctx, captured(1))
+
+	start := time.Now()
+	if delivered, err := d.Deliver(ctx); err != nil || delivered != 0 {
+		t.Errorf("Deliver() to a hanging endpoint = %d, %v", delivered, err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Deliver() took %v, want the timeout", elapsed)
+	}
+	if pending, _ := queue.List(ctx); len(pending) != 1 || pending[0].Attempts != 1 || pending[0].Status != StatusPending {
+		t.Errorf("queue after a timeout = %+v", pending)
+	}
+}
+
+func TestFileQueue(t *testing.T) {
+
+	ctx := context.Background()
+	dir := t.TempDir()
+	queue, _ := OpenFileQueue(dir)
+
+	now := time.Now()
+	for i, next := range []time.Duration{time.Minute, -time.Minute, -time.Hour, 0} {
+		queue.Add(ctx, &Delivery{ID: "crm/" + strconv.Itoa(i), Endpoint: "crm", Status: StatusPending, NextAttempt: now.Add(next), CreatedAt: now})
+	}
+	queue.Add(ctx, &Delivery{ID: "crm/old", Endpoint: "crm", Status: StatusFailed, FailedAt: now.Add(-48 * time.Hour), CreatedAt: now})
+	queue.Add(ctx, &Delivery{ID: "crm/recent", Endpoint: "crm", Status: StatusFailed, FailedAt: now.Add(-time.Hour), CreatedAt: now})
+
+	// the index is rebuilt from the directory
+	reopened, err := OpenFileQueue(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, q := range []*FileQueue{queue, reopened} {
+		due, err := q.Due(ctx, now, 2)
+		if err != nil || len(due) != 2 || due[0].ID != "crm/2" || due[1].ID != "crm/1" {
+			t.Errorf("Due() = %+v, %v", due, err)
+		}
+		if due, _ := q.Due(ctx, now, 10); len(due) != 3 {
+			t.Errorf("Due() of every delivery = %d deliveries, want 3", len(due))
+		}
+	}
+
+	delivery, _ := reopened.Get(ctx, "crm/2")
+	delivery.NextAttempt = now.Add(time.Hour)
+	reopened.Update(ctx, delivery)
+	reopened.Remove(ctx, "crm/1")
+	if due, _ := reopened.Due(ctx, now, 10); len(due) != 1 || due[0].ID != "crm/3" {
+		t.Errorf("Due() after update and remove = %+v", due)
+	}
+
+	if pruned, err := reopened.Prune(ctx, now.Add(-24*time.Hour)); err != nil || pruned != 1 {
+		t.Errorf("Prune() = %d, %v", pruned, err)
+	}
+	if _, err := reopened.Get(ctx, "crm/old"); err != ErrNotFound {
+		t.Errorf("Get() of a pruned delivery error = %v", err)
+	}
+	if _, err := reopened.Get(ctx, "crm/recent"); err != nil {
+		t.Errorf("Get() of a recent failed delivery error = %v", err)
+	}
+}
+
+func TestVerify(t *testing.T) {
+
+	body := []byte(`{"id":"payment.voided:1","type":"payment.voided","event":{"transactionId":1}}`)
+	request := func(secret string, at time.Time) *http.Request {
+		timestamp := strconv.FormatInt(at.Unix(), 10)
+		r := httptest.NewRequest(http.MethodPost, "/hooks/linepay", bytes.NewReader(body))
+		r.Header.Set(HeaderTimestamp, timestamp)
+		r.Header.Set(HeaderSignature, Sign(secret, timestamp, body))
+		return r
+	}
+
+	payload, err := Verify(request("secret", time.Now()), "secret", 0)
+	if err != nil || payload.Type != linepay.EventPaymentVoided {
+		t.Errorf("Verify() = %+v, %v", payload, err)
+	}
+	if _, err := Verify(request("other", time.Now()), "secret", 0); err != ErrInvalidSignature {
+		t.Errorf("Verify() with a wrong secret = %v", err)
+	}
+	if _, err := Verify(request("secret", time.Now().Add(-time.Hour)), "secret", time.Minute); err != ErrInvalidSignature {
+		t.Errorf("Verify() of an old request = %v", err)
+	}
+}